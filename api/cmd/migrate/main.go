@@ -3,7 +3,11 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	"rec-mind/config"
@@ -12,35 +16,150 @@ import (
 )
 
 func main() {
-	var migrationsDir = flag.String("dir", "migrations", "Directory containing migration files")
+	dir := flag.String("dir", "migrations", "Directory containing migration files")
+	flag.Usage = printUsage
 	flag.Parse()
 
+	args := flag.Args()
+	if len(args) < 1 {
+		printUsage()
+		os.Exit(1)
+	}
+	command := args[0]
+
+	if command == "create" {
+		if len(args) < 2 {
+			log.Fatal("create requires a migration name, e.g. `migrate create add_widgets`")
+		}
+		if err := createMigration(*dir, args[1]); err != nil {
+			log.Fatalf("Failed to create migration: %v", err)
+		}
+		return
+	}
+
 	log.Println("🚀 Starting database migrations...")
+	config.LoadEnv()
 
-	// Load database configuration
 	dbConfig, err := config.LoadDatabaseConfig()
 	if err != nil {
 		log.Fatalf("Failed to load database config: %v", err)
 	}
 
-	// Initialize database connection
 	db, err := database.NewConnection(dbConfig)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer db.Close()
 
-	// Create migration runner
 	runner := migrations.NewMigrationRunner(db.Pool)
 
-	// Run migrations with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
-	err = runner.RunMigrations(ctx, *migrationsDir)
+	switch command {
+	case "up":
+		steps := 0
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				log.Fatalf("Invalid step count %q: %v", args[1], err)
+			}
+		}
+		if err := runner.MigrateUp(ctx, *dir, steps); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		log.Println("✅ All migrations completed successfully!")
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				log.Fatalf("Invalid step count %q: %v", args[1], err)
+			}
+		}
+		if err := runner.MigrateDown(ctx, *dir, steps); err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+		log.Println("✅ Rollback completed successfully!")
+
+	case "status":
+		statuses, err := runner.Status(ctx, *dir)
+		if err != nil {
+			log.Fatalf("Failed to get migration status: %v", err)
+		}
+		printStatus(statuses)
+
+	case "force":
+		if len(args) < 2 {
+			log.Fatal("force requires a version number, e.g. `migrate force 3`")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			log.Fatalf("Invalid version %q: %v", args[1], err)
+		}
+		if err := runner.Force(ctx, *dir, version); err != nil {
+			log.Fatalf("Force failed: %v", err)
+		}
+		log.Printf("✅ Forced version %d", version)
+
+	default:
+		printUsage()
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Println("Usage: migrate [-dir <migrations dir>] <command> [args]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  up [n]          Apply all pending migrations, or only the next n")
+	fmt.Println("  down [n]        Roll back the most recently applied n migrations (default 1)")
+	fmt.Println("  status          Show which migrations are applied, pending, or drifted")
+	fmt.Println("  force <version> Accept the on-disk checksum for version, clearing drift")
+	fmt.Println("  create <name>   Scaffold a new NNNN_name.up.sql / NNNN_name.down.sql pair")
+}
+
+func printStatus(statuses []migrations.MigrationStatus) {
+	for _, s := range statuses {
+		state := "pending"
+		if s.Applied {
+			state = fmt.Sprintf("applied at %s", s.AppliedAt.Format(time.RFC3339))
+			if s.Drifted {
+				state += " (CHECKSUM DRIFT)"
+			}
+		}
+		fmt.Printf("%04d_%s: %s\n", s.Version, s.Name, state)
+	}
+}
+
+// createMigration scaffolds the next "NNNN_name.up.sql" / "NNNN_name.down.sql"
+// pair, numbering it one past the highest version already in dir.
+func createMigration(dir, name string) error {
+	entries, err := os.ReadDir(dir)
 	if err != nil {
-		log.Fatalf("Migration failed: %v", err)
+		return fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	next := 1
+	for _, entry := range entries {
+		if len(entry.Name()) < 5 {
+			continue
+		}
+		if version, err := strconv.Atoi(entry.Name()[:4]); err == nil && version >= next {
+			next = version + 1
+		}
+	}
+
+	base := fmt.Sprintf("%04d_%s", next, name)
+	upPath := filepath.Join(dir, base+".up.sql")
+	downPath := filepath.Join(dir, base+".down.sql")
+
+	if err := os.WriteFile(upPath, []byte(fmt.Sprintf("-- %s (up)\n", name)), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", upPath, err)
+	}
+	if err := os.WriteFile(downPath, []byte(fmt.Sprintf("-- %s (down)\n", name)), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", downPath, err)
 	}
 
-	log.Println("✅ All migrations completed successfully!")
-}
\ No newline at end of file
+	fmt.Printf("Created %s and %s\n", upPath, downPath)
+	return nil
+}
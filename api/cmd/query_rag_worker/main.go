@@ -2,107 +2,143 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"log"
+	"flag"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"rec-mind/config"
+	"rec-mind/internal/analytics"
 	"rec-mind/internal/database"
+	"rec-mind/internal/events"
+	"rec-mind/internal/logging"
+	"rec-mind/internal/metrics"
 	"rec-mind/internal/redis"
 	"rec-mind/internal/repository"
 	"rec-mind/internal/worker"
-	"rec-mind/models"
 	"rec-mind/mq"
 )
 
+var workerLog = logging.New("query-rag-worker")
+
+const (
+	defaultQueryWorkerConcurrency = 8
+	defaultQueryJobTimeout        = 30 * time.Second
+	defaultMetricsAddr            = ":9091"
+)
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
 func main() {
+	enrichmentCacheSize := flag.Int("enrichment-cache-size", worker.DefaultEnrichmentCacheSize, "Number of articles to keep in the enrichment LRU cache")
+	archive := flag.Bool("archive", true, "Persist completed query results to Postgres for history (disable for local testing without the query_results migrations applied)")
+	tenantConcurrencyLimit := flag.Int("tenant-concurrency-limit", worker.DefaultTenantConcurrencyLimit, "Max concurrent query search jobs per TenantID, enforced via Redis so the cap holds across the whole fleet")
+	flag.Parse()
+
 	dbConfig, err := config.LoadDatabaseConfig()
 	if err != nil {
-		log.Fatalf("X Failed to load database config: %v", err)
+		workerLog.Error("failed to load database config", "error", err)
+		os.Exit(1)
 	}
 
 	db, err := database.NewConnection(dbConfig)
 	if err != nil {
-		log.Fatalf("X Failed to connect to database: %v", err)
+		workerLog.Error("failed to connect to database", "error", err)
+		os.Exit(1)
 	}
 	defer db.Close()
 
 	ctx := context.Background()
 	if err := db.Pool.Ping(ctx); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
+		workerLog.Error("failed to ping database", "error", err)
+		os.Exit(1)
 	}
-	log.Println("- Database connection established")
+	workerLog.Info("database connection established")
 
 	if err := redis.InitRedis(); err != nil {
-		log.Fatalf("X Failed to initialize Redis: %v", err)
+		workerLog.Error("failed to initialize redis", "error", err)
+		os.Exit(1)
 	}
 	defer redis.CloseRedis()
-	log.Println("- Redis connection established")
+	workerLog.Info("redis connection established")
 
 	mq.InitRabbitMQ()
-	log.Println("- RabbitMQ connection established")
+	workerLog.Info("rabbitmq connection established")
 
-	articleRepo := repository.NewArticleRepository(db.Pool)
+	metrics.RegisterDBPoolStats(db.Pool)
+	metrics.RegisterRedisPoolStats(redis.RedisClient)
+	metrics.SetBuildInfo(getEnvOrDefault("GIT_SHA", "unknown"), getEnvOrDefault("APP_VERSION", "unknown"))
 
-	queryWorker, err := worker.NewQueryRAGWorker(articleRepo, redis.RedisClient)
-	if err != nil {
-		log.Fatalf("Failed to create query RAG worker: %v", err)
+	articleRepo := repository.NewArticleRepository(db.Pool, nil, dbConfig.ArticleCacheMaxBytes)
+
+	var archiveRepo repository.QueryResultRepository
+	var jobRepo repository.JobRepository
+	if *archive {
+		archiveRepo = repository.NewQueryResultRepository(db.Pool)
+		jobRepo = repository.NewJobRepository(db.Pool)
 	}
-	log.Println("✅ Query RAG Worker initialized")
 
-	go func() {
-		queue, err := mq.MQChannel.QueueDeclare(
-			"query_search_jobs", // name
-			true,                // durable
-			false,               // delete when unused
-			false,               // exclusive
-			false,               // no-wait
-			nil,                 // arguments
-		)
-		if err != nil {
-			log.Fatalf("Failed to declare query_search_jobs queue: %v", err)
-		}
+	analyticsClient := analytics.New(config.LoadAnalyticsConfig(), db.Pool)
+	defer analyticsClient.Close()
+
+	searchEventPublisher := events.NewPublisher(redis.RedisClient, "search_jobs")
 
-		msgs, err := mq.MQChannel.Consume(
-			queue.Name, // queue
-			"",         // consumer
-			false,      // auto-ack
-			false,      // exclusive
-			false,      // no-local
-			false,      // no-wait
-			nil,        // args
-		)
-		if err != nil {
-			log.Fatalf("Failed to register query search jobs consumer: %v", err)
+	queryWorker, err := worker.NewQueryRAGWorker(articleRepo, redis.RedisClient, *enrichmentCacheSize, archiveRepo, analyticsClient, searchEventPublisher, jobRepo)
+	if err != nil {
+		workerLog.Error("failed to create query RAG worker", "error", err)
+		os.Exit(1)
+	}
+	workerLog.Info("query RAG worker initialized")
+
+	concurrency := defaultQueryWorkerConcurrency
+	if raw := getEnvOrDefault("QUERY_WORKER_CONCURRENCY", ""); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			concurrency = parsed
+		} else {
+			workerLog.Warn("invalid QUERY_WORKER_CONCURRENCY, using default", "value", raw, "default", defaultQueryWorkerConcurrency)
 		}
+	}
 
-		log.Println("📡 Started consuming query search jobs")
-
-		for d := range msgs {
-			var job models.QuerySearchJob
-			if err := json.Unmarshal(d.Body, &job); err != nil {
-				log.Printf("X Failed to unmarshal query search job: %v", err)
-				d.Nack(false, false)
-				continue
-			}
-
-			log.Printf("📥 Received query search job %s for query: \"%s\"", job.JobID, job.Query)
-
-			if err := queryWorker.ProcessQuerySearchJob(job); err != nil {
-				log.Printf("X Failed to process query search job %s: %v", job.JobID, err)
-				d.Nack(false, true) // Requeue on failure
-			} else {
-				log.Printf("✅ Successfully processed query search job %s", job.JobID)
-				d.Ack(false)
-			}
+	tenantSem := worker.NewTenantSemaphore(redis.RedisClient, *tenantConcurrencyLimit)
+
+	pool, err := worker.NewQueryWorkerPool(queryWorker, mq.MQChannel, concurrency, defaultQueryJobTimeout, tenantSem)
+	if err != nil {
+		workerLog.Error("failed to create query worker pool", "error", err)
+		os.Exit(1)
+	}
+
+	if err := pool.Start(); err != nil {
+		workerLog.Error("failed to start query worker pool", "error", err)
+		os.Exit(1)
+	}
+
+	metricsAddr := getEnvOrDefault("QUERY_WORKER_METRICS_ADDR", defaultMetricsAddr)
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		if err := http.ListenAndServe(metricsAddr, metricsMux); err != nil {
+			workerLog.Error("metrics server stopped", "error", err)
 		}
 	}()
+	workerLog.Info("metrics server listening", "addr", metricsAddr)
+
+	workerLog.Info("query RAG worker running, press Ctrl+C to stop")
 
-	log.Println("Query RAG Worker is running... Press Ctrl+C to stop")
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	<-sig
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-	<-c
+	workerLog.Info("shutting down query RAG worker")
+	pool.Stop(30 * time.Second)
+	queryWorker.Close()
 }
\ No newline at end of file
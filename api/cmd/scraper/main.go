@@ -6,11 +6,13 @@ import (
 	"os"
 	"time"
 
-	"github.com/owenHochwald/rec-mind-api/config"
-	"github.com/owenHochwald/rec-mind-api/internal/database"
-	"github.com/owenHochwald/rec-mind-api/internal/repository"
-	"github.com/owenHochwald/rec-mind-api/internal/services"
-	"github.com/owenHochwald/rec-mind-api/mq"
+	"rec-mind/config"
+	"rec-mind/internal/analytics"
+	"rec-mind/internal/database"
+	"rec-mind/internal/outbox"
+	"rec-mind/internal/repository"
+	"rec-mind/internal/services"
+	"rec-mind/mq"
 )
 
 func main() {
@@ -38,16 +40,29 @@ func main() {
 	defer mq.MQConn.Close()
 	defer mq.MQChannel.Close()
 
-	// Initialize repository
-	articleRepo := repository.NewArticleRepository(db.Pool)
-
-	// Initialize scraper service
-	scraperService := services.NewScraperService(articleRepo, mq.MQChannel)
+	// Initialize repositories
+	articleRepo := repository.NewArticleRepository(db.Pool, nil, dbConfig.ArticleCacheMaxBytes)
+	feedStateRepo := repository.NewFeedStateRepository(db.Pool)
 
 	// Run scraper
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 	defer cancel()
 
+	// Initialize the durable publisher: confirms + retry + an outbox
+	// fallback so a RabbitMQ hiccup doesn't drop a scraped article.
+	outboxStore := outbox.NewPostgresStore(db.Pool)
+	publisher, err := outbox.NewPublisher(mq.MQChannel, outboxStore)
+	if err != nil {
+		log.Fatalf("Failed to initialize durable publisher: %v", err)
+	}
+	publisher.StartDrainer(ctx, 30*time.Second)
+
+	// Initialize scraper service
+	extractor := services.NewHTTPContentExtractor(nil, 0)
+	analyticsClient := analytics.New(config.LoadAnalyticsConfig(), db.Pool)
+	defer analyticsClient.Close()
+	scraperService := services.NewScraperService(articleRepo, mq.MQChannel, extractor, publisher, feedStateRepo, analyticsClient)
+
 	log.Println("🔍 Starting article scraping process...")
 	result, err := scraperService.ScrapeAllFeeds(ctx)
 	if err != nil {
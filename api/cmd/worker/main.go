@@ -2,42 +2,57 @@ package main
 
 import (
 	"context"
-	"log"
+	"flag"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"rec-mind/config"
+	"rec-mind/internal/analytics"
 	"rec-mind/internal/database"
+	"rec-mind/internal/events"
+	"rec-mind/internal/jobs"
+	"rec-mind/internal/logging"
+	"rec-mind/internal/metrics"
+	"rec-mind/internal/mlclient"
 	"rec-mind/internal/redis"
 	"rec-mind/internal/repository"
+	"rec-mind/internal/services"
 	"rec-mind/internal/worker"
 	"rec-mind/mq"
 )
 
+var workerLog = logging.New("rag-worker")
+
 func main() {
-	log.Println("🚀 Starting RAG Worker Service")
+	archive := flag.Bool("archive", true, "Persist completed query results to Postgres for history (disable for local testing without the query_results migrations applied)")
+	flag.Parse()
+
+	workerLog.Info("starting RAG worker service")
 
 	// Initialize database connection
 	dbConfig, err := config.LoadDatabaseConfig()
 	if err != nil {
-		log.Fatalf("❌ Failed to load database config: %v", err)
+		workerLog.Error("failed to load database config", "error", err)
+		os.Exit(1)
 	}
 
 	db, err := database.NewConnection(dbConfig)
 	if err != nil {
-		log.Fatalf("❌ Failed to initialize database: %v", err)
+		workerLog.Error("failed to initialize database", "error", err)
+		os.Exit(1)
 	}
 	defer db.Close()
 
 	// Initialize Redis connection
 	if err := redis.InitRedis(); err != nil {
-		log.Fatalf("❌ Failed to initialize Redis: %v", err)
+		workerLog.Error("failed to initialize Redis", "error", err)
+		os.Exit(1)
 	}
 	defer func() {
 		if err := redis.CloseRedis(); err != nil {
-			log.Printf("⚠️ Error closing Redis: %v", err)
+			workerLog.Error("error closing Redis", "error", err)
 		}
 	}()
 
@@ -53,19 +68,85 @@ func main() {
 	}()
 
 	// Initialize repositories
-	articleRepo := repository.NewArticleRepository(db.Pool)
-	chunkRepo := repository.NewArticleChunkRepository(db.Pool)
+	articleRepo := repository.NewArticleRepository(db.Pool, nil, dbConfig.ArticleCacheMaxBytes)
+	chunkRepo := repository.NewArticleChunkRepository(db.Pool, nil)
+
+	var archiveRepo repository.QueryResultRepository
+	var jobRepo repository.JobRepository
+	if *archive {
+		archiveRepo = repository.NewQueryResultRepository(db.Pool)
+		jobRepo = repository.NewJobRepository(db.Pool)
+	}
+
+	analyticsClient := analytics.New(config.LoadAnalyticsConfig(), db.Pool)
+	defer analyticsClient.Close()
+
+	searchEventPublisher := events.NewPublisher(redis.RedisClient, "search_jobs")
 
 	// Create and start job consumer
-	jobConsumer, err := worker.NewJobConsumer(chunkRepo, articleRepo)
+	jobConsumer, err := worker.NewJobConsumer(chunkRepo, articleRepo, archiveRepo, analyticsClient, searchEventPublisher, jobRepo)
 	if err != nil {
-		log.Fatalf("❌ Failed to create job consumer: %v", err)
+		workerLog.Error("failed to create job consumer", "error", err)
+		os.Exit(1)
 	}
 
 	if err := jobConsumer.Start(); err != nil {
-		log.Fatalf("❌ Failed to start job consumer: %v", err)
+		workerLog.Error("failed to start job consumer", "error", err)
+		os.Exit(1)
+	}
+
+	// Create and start article deletion (reaper) consumer
+	mlClient := mlclient.NewMLClient()
+	reaperWorker, err := worker.NewArticleReaperWorker(articleRepo, chunkRepo, mlClient)
+	if err != nil {
+		workerLog.Error("failed to create article reaper worker", "error", err)
+		os.Exit(1)
+	}
+
+	if err := reaperWorker.Start(); err != nil {
+		workerLog.Error("failed to start article reaper worker", "error", err)
+		os.Exit(1)
 	}
 
+	// Create and start the article_events consumer: article.created and
+	// article.updated enqueue embedding jobs onto the same durable queue
+	// the API process's pool drains (see ArticleService.StartEmbeddingWorkers
+	// in server/main.go), and article.deleted cleans up Redis/Pinecone.
+	articleEventPublisher := events.NewPublisher(redis.RedisClient, "article_events")
+	embeddingJobs := jobs.NewQueue(redis.RedisClient, "embeddings", jobs.DefaultMaxAttempts)
+	articleService := services.NewArticleService(articleRepo, mlClient, articleEventPublisher, embeddingJobs)
+
+	articleEventsWorker, err := worker.NewArticleEventsWorker(articleService, mlClient)
+	if err != nil {
+		workerLog.Error("failed to create article events worker", "error", err)
+		os.Exit(1)
+	}
+
+	if err := articleEventsWorker.Start(); err != nil {
+		workerLog.Error("failed to start article events worker", "error", err)
+		os.Exit(1)
+	}
+
+	// Create and start the articles.batch consumer: durably enqueues
+	// chunked, concurrent batch embedding for every manifest
+	// BulkArticleIngestor publishes.
+	articleBatchWorker, err := worker.NewArticleBatchWorker(articleService)
+	if err != nil {
+		workerLog.Error("failed to create article batch worker", "error", err)
+		os.Exit(1)
+	}
+
+	if err := articleBatchWorker.Start(); err != nil {
+		workerLog.Error("failed to start article batch worker", "error", err)
+		os.Exit(1)
+	}
+
+	// Queue depth gauge for the queue job_consumer.go consumes from,
+	// surfaced on /metrics as rec_mind_queue_depth{queue="query_search_jobs"}.
+	pollerCtx, stopPoller := context.WithCancel(context.Background())
+	defer stopPoller()
+	metrics.StartQueueDepthPoller(pollerCtx, mq.MQChannel, "query_search_jobs")
+
 	// Health check goroutine
 	go func() {
 		ticker := time.NewTicker(30 * time.Second)
@@ -73,33 +154,35 @@ func main() {
 
 		for range ticker.C {
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			
+
 			// Check Redis health
 			if err := redis.HealthCheck(ctx); err != nil {
-				log.Printf("⚠️ Redis health check failed: %v", err)
+				workerLog.Warn("Redis health check failed", "error", err)
 			}
 
 			// Check database health
 			if err := db.Pool.Ping(ctx); err != nil {
-				log.Printf("⚠️ Database health check failed: %v", err)
+				workerLog.Warn("database health check failed", "error", err)
 			}
 
 			cancel()
 		}
 	}()
 
-	log.Println("✅ RAG Worker Service started successfully")
-	log.Println("📋 Listening for recommendation jobs...")
+	workerLog.Info("RAG worker service started successfully, listening for query search jobs")
 
 	// Wait for interrupt signal to gracefully shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("🛑 Shutting down RAG Worker Service...")
+	workerLog.Info("shutting down RAG worker service")
 
 	// Graceful shutdown
 	jobConsumer.Stop()
+	reaperWorker.Stop()
+	articleEventsWorker.Stop()
+	articleBatchWorker.Stop()
 
-	log.Println("✅ RAG Worker Service stopped gracefully")
+	workerLog.Info("RAG worker service stopped gracefully")
 }
\ No newline at end of file
@@ -0,0 +1,25 @@
+package config
+
+// AnalyticsConfig selects the backend for the query/feed event analytics
+// store. "timescale" and "postgres" use the same writer and query_events/
+// feed_events tables - the only difference is whether migration 0007
+// converted them into TimescaleDB hypertables, which is transparent to
+// this process. "none" disables analytics entirely.
+type AnalyticsConfig struct {
+	Backend string
+}
+
+const (
+	AnalyticsBackendTimescale = "timescale"
+	AnalyticsBackendPostgres  = "postgres"
+	AnalyticsBackendNone      = "none"
+)
+
+// LoadAnalyticsConfig reads ANALYTICS_BACKEND, defaulting to "none" so
+// analytics is opt-in rather than silently writing to tables a deployment
+// may not have migrated yet.
+func LoadAnalyticsConfig() AnalyticsConfig {
+	return AnalyticsConfig{
+		Backend: getEnvOrDefault("ANALYTICS_BACKEND", AnalyticsBackendNone),
+	}
+}
@@ -19,6 +19,12 @@ type DatabaseConfig struct {
 	SSLMode        string
 	MaxConnections int32
 	MaxIdleTime    time.Duration
+
+	// ArticleCacheMaxBytes bounds the in-process LRU cache that
+	// articleRepository keeps in front of GetByID/GetByURL, to avoid
+	// re-querying Postgres for articles that were just read. Byte-bounded
+	// rather than count-bounded since articles vary widely in content size.
+	ArticleCacheMaxBytes int64
 }
 
 func LoadDatabaseConfig() (*DatabaseConfig, error) {
@@ -42,15 +48,21 @@ func LoadDatabaseConfig() (*DatabaseConfig, error) {
 		return nil, fmt.Errorf("invalid DB_MAX_IDLE_TIME: %w", err)
 	}
 
+	articleCacheMaxBytes, err := strconv.ParseInt(getEnvOrDefault("DB_ARTICLE_CACHE_MAX_BYTES", "16777216"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_ARTICLE_CACHE_MAX_BYTES: %w", err)
+	}
+
 	config := &DatabaseConfig{
-		Host:           getEnvOrDefault("DB_HOST", "localhost"),
-		Port:           port,
-		Name:           getEnvOrDefault("DB_NAME", "postgres"),
-		User:           getEnvOrDefault("DB_USER", "postgres"),
-		Password:       getEnvOrDefault("DB_PASSWORD", "secret"),
-		SSLMode:        getEnvOrDefault("DB_SSL_MODE", "disable"),
-		MaxConnections: int32(maxConnections),
-		MaxIdleTime:    maxIdleTime,
+		Host:                 getEnvOrDefault("DB_HOST", "localhost"),
+		Port:                 port,
+		Name:                 getEnvOrDefault("DB_NAME", "postgres"),
+		User:                 getEnvOrDefault("DB_USER", "postgres"),
+		Password:             getEnvOrDefault("DB_PASSWORD", "secret"),
+		SSLMode:              getEnvOrDefault("DB_SSL_MODE", "disable"),
+		MaxConnections:       int32(maxConnections),
+		MaxIdleTime:          maxIdleTime,
+		ArticleCacheMaxBytes: articleCacheMaxBytes,
 	}
 
 	return config, nil
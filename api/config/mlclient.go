@@ -0,0 +1,79 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+// MLClientRetryPolicy configures pythonclient.Client's retry-with-backoff
+// loop: up to MaxRetries attempts, starting at InitialInterval and doubling
+// each attempt, capped by MaxElapsed total time spent retrying a single
+// call.
+type MLClientRetryPolicy struct {
+	MaxRetries      int
+	InitialInterval time.Duration
+	MaxElapsed      time.Duration
+}
+
+// LoadMLClientRetryPolicy reads ML_MAX_RETRIES, ML_INITIAL_INTERVAL (a Go
+// duration string), and ML_MAX_ELAPSED, falling back to 5 attempts starting
+// at 500ms and capped at 30s total when unset or unparseable.
+func LoadMLClientRetryPolicy() MLClientRetryPolicy {
+	policy := MLClientRetryPolicy{
+		MaxRetries:      5,
+		InitialInterval: 500 * time.Millisecond,
+		MaxElapsed:      30 * time.Second,
+	}
+
+	if raw := getEnvOrDefault("ML_MAX_RETRIES", ""); raw != "" {
+		if v, err := strconv.Atoi(raw); err == nil && v > 0 {
+			policy.MaxRetries = v
+		}
+	}
+
+	if raw := getEnvOrDefault("ML_INITIAL_INTERVAL", ""); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			policy.InitialInterval = d
+		}
+	}
+
+	if raw := getEnvOrDefault("ML_MAX_ELAPSED", ""); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			policy.MaxElapsed = d
+		}
+	}
+
+	return policy
+}
+
+// BatchPolicy configures how ArticleService.ProcessBatchArticles splits a
+// large article slice into chunks before calling the Python service, so one
+// oversized /embeddings/batch-and-upload request can't OOM or time it out.
+type BatchPolicy struct {
+	ChunkSize   int
+	Concurrency int
+}
+
+// LoadBatchPolicy reads ML_BATCH_SIZE and ML_BATCH_CONCURRENCY, falling back
+// to 32 articles per chunk with up to 4 chunks in flight at once when unset
+// or unparseable.
+func LoadBatchPolicy() BatchPolicy {
+	policy := BatchPolicy{
+		ChunkSize:   32,
+		Concurrency: 4,
+	}
+
+	if raw := getEnvOrDefault("ML_BATCH_SIZE", ""); raw != "" {
+		if v, err := strconv.Atoi(raw); err == nil && v > 0 {
+			policy.ChunkSize = v
+		}
+	}
+
+	if raw := getEnvOrDefault("ML_BATCH_CONCURRENCY", ""); raw != "" {
+		if v, err := strconv.Atoi(raw); err == nil && v > 0 {
+			policy.Concurrency = v
+		}
+	}
+
+	return policy
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures a widening-delay retry ladder: MaxAttempts tiers
+// are generated from BaseDelay * Multiplier^i (see mq.TiersFromPolicy),
+// so a queue's retry/DLQ topology can be tuned per-environment instead of
+// hardcoded.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	Multiplier  float64
+}
+
+// LoadRetryPolicy reads MQ_RETRY_MAX_ATTEMPTS, MQ_RETRY_BASE_DELAY_MS, and
+// MQ_RETRY_MULTIPLIER, falling back to a 3-tier ladder starting at 5s and
+// widening by 6x per tier (5s, 30s, 3m) when unset.
+func LoadRetryPolicy() RetryPolicy {
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   5 * time.Second,
+		Multiplier:  6,
+	}
+
+	if raw := getEnvOrDefault("MQ_RETRY_MAX_ATTEMPTS", ""); raw != "" {
+		if v, err := strconv.Atoi(raw); err == nil && v > 0 {
+			policy.MaxAttempts = v
+		}
+	}
+
+	if raw := getEnvOrDefault("MQ_RETRY_BASE_DELAY_MS", ""); raw != "" {
+		if v, err := strconv.Atoi(raw); err == nil && v > 0 {
+			policy.BaseDelay = time.Duration(v) * time.Millisecond
+		}
+	}
+
+	if raw := getEnvOrDefault("MQ_RETRY_MULTIPLIER", ""); raw != "" {
+		if v, err := strconv.ParseFloat(raw, 64); err == nil && v > 1 {
+			policy.Multiplier = v
+		}
+	}
+
+	return policy
+}
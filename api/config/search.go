@@ -0,0 +1,22 @@
+package config
+
+import "time"
+
+// SearchConfig configures the query search job endpoints.
+type SearchConfig struct {
+	// IdempotencyTTL bounds how long a (tenant, correlation_id) pair
+	// returned by SearchController.claimIdempotencyKey keeps mapping to its
+	// original job_id, after which a resubmission with the same pair is
+	// treated as a brand new job.
+	IdempotencyTTL time.Duration
+}
+
+// LoadSearchConfig reads SEARCH_JOB_IDEMPOTENCY_TTL (a Go duration string,
+// e.g. "10m"), falling back to 10 minutes when unset or unparseable.
+func LoadSearchConfig() SearchConfig {
+	ttl, err := time.ParseDuration(getEnvOrDefault("SEARCH_JOB_IDEMPOTENCY_TTL", "10m"))
+	if err != nil {
+		ttl = 10 * time.Minute
+	}
+	return SearchConfig{IdempotencyTTL: ttl}
+}
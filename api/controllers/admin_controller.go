@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"rec-mind/mq"
+)
+
+// deadLetterableQueues whitelists which origin queues' dead queues the admin
+// endpoints may inspect, so a caller can't point ListDeadLetters/Get at an
+// arbitrary queue name.
+var deadLetterableQueues = map[string]bool{
+	"query_search_jobs":   true,
+	"recommendation_jobs": true,
+	"article_events":      true,
+}
+
+// ListDeadLetters returns up to `limit` messages currently sitting in
+// {queue}.dead without removing them, so an operator can inspect what's
+// failing before deciding whether to requeue it.
+// @Summary List dead-lettered jobs
+// @Description List messages in a queue's dead-letter queue without consuming them
+// @Tags admin
+// @Produce json
+// @Param queue path string true "Origin queue name (e.g. query_search_jobs)"
+// @Param limit query int false "Maximum messages to return (default 50)"
+// @Success 200 {object} object{queue=string,messages=[]mq.DeadLetterMessage}
+// @Failure 400 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /api/v1/admin/dead-letters/{queue} [get]
+func ListDeadLetters(c *gin.Context) {
+	queue := c.Param("queue")
+	if !deadLetterableQueues[queue] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown or non-dead-letterable queue", "queue": queue})
+		return
+	}
+
+	limit := 50
+	if raw := c.Query("limit"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	messages, err := mq.ListDeadLetters(mq.MQChannel, queue+".dead", limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list dead letters", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"queue": queue, "messages": messages})
+}
+
+// RequeueDeadLetter pops one message off {queue}.dead and republishes it to
+// queue for another attempt, resetting its retry count.
+// @Summary Requeue a dead-lettered job
+// @Description Pop one message off a queue's dead-letter queue and republish it for reprocessing
+// @Tags admin
+// @Produce json
+// @Param queue path string true "Origin queue name (e.g. query_search_jobs)"
+// @Success 200 {object} object{requeued=bool}
+// @Failure 400 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /api/v1/admin/dead-letters/{queue}/requeue [post]
+func RequeueDeadLetter(c *gin.Context) {
+	queue := c.Param("queue")
+	if !deadLetterableQueues[queue] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown or non-dead-letterable queue", "queue": queue})
+		return
+	}
+
+	requeued, err := mq.RequeueDeadLetter(mq.MQChannel, queue+".dead", queue)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to requeue dead letter", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"requeued": requeued})
+}
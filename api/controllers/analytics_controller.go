@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"rec-mind/internal/analytics"
+)
+
+// GetQueryAnalytics serves aggregate query_events stats for the ?since= and
+// ?status= window, backed by whatever Analytics backend the process was
+// configured with (a no-op store just returns an empty list).
+func GetQueryAnalytics(analyticsClient analytics.Analytics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := analytics.QueryEventFilter{
+			Status: c.Query("status"),
+		}
+
+		if sinceRaw := c.Query("since"); sinceRaw != "" {
+			since, err := time.Parse(time.RFC3339, sinceRaw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since, expected RFC3339"})
+				return
+			}
+			filter.Since = since
+		} else {
+			filter.Since = time.Now().Add(-24 * time.Hour)
+		}
+
+		if limitRaw := c.Query("limit"); limitRaw != "" {
+			limit, err := strconv.Atoi(limitRaw)
+			if err != nil || limit <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+				return
+			}
+			filter.Limit = limit
+		}
+
+		events, err := analyticsClient.SearchQueryEvents(c.Request.Context(), filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch query analytics"})
+			return
+		}
+
+		var totalLatencyMS int64
+		var totalMatchedChunks int
+		statusCounts := make(map[string]int)
+		for _, event := range events {
+			totalLatencyMS += event.LatencyMS
+			totalMatchedChunks += event.MatchedChunks
+			statusCounts[event.Status]++
+		}
+
+		var avgLatencyMS float64
+		if len(events) > 0 {
+			avgLatencyMS = float64(totalLatencyMS) / float64(len(events))
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"count":                len(events),
+			"avg_latency_ms":       avgLatencyMS,
+			"total_matched_chunks": totalMatchedChunks,
+			"status_counts":        statusCounts,
+			"events":               events,
+		})
+	}
+}
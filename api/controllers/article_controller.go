@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"errors"
+	"io"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -12,11 +14,26 @@ import (
 	"rec-mind/pkg/response"
 )
 
+// idempotencyKeyHeader lets a client retry a bulk deletion request (e.g.
+// after a dropped connection) without risking a second cascade job for the
+// same set of articles.
+const idempotencyKeyHeader = "X-Idempotency-Key"
+
+// maxInlineUploadSize bounds how large a multipart file body UploadArticle
+// will read directly into memory; larger files should use the resumable
+// /articles/uploads session protocol instead.
+const maxInlineUploadSize = 32 << 20 // 32MB
+
+var (
+	errInvalidRequestFormat = errors.New("Invalid request format")
+	errUploadTooLarge       = errors.New("File exceeds the inline upload limit; use the resumable upload endpoint")
+)
+
 func UploadArticle(articleService *services.ArticleService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req models.CreateArticleRequest
-		if err := c.ShouldBindJSON(&req); err != nil {
-			response.BadRequest(c, "Invalid request format")
+		req, err := bindUploadArticleRequest(c)
+		if err != nil {
+			response.BadRequest(c, err.Error())
 			return
 		}
 
@@ -44,10 +61,16 @@ func UploadArticle(articleService *services.ArticleService) gin.HandlerFunc {
 				}
 			}
 
+			if result.Duplicate {
+				data["duplicate"] = true
+				response.Success(c, data)
+				return
+			}
+
 			response.CreatedWithMessage(c, data, "Article created with embeddings")
 
 		default:
-			article, err := articleService.CreateArticleWithAsyncEmbedding(ctx, &req)
+			article, duplicate, err := articleService.CreateArticleWithAsyncEmbedding(ctx, &req)
 			if err != nil {
 				response.InternalServerError(c, "Failed to create article")
 				return
@@ -58,11 +81,63 @@ func UploadArticle(articleService *services.ArticleService) gin.HandlerFunc {
 				"processing_mode": "async_chunking",
 			}
 
+			if duplicate {
+				data["duplicate"] = true
+				response.Success(c, data)
+				return
+			}
+
 			response.CreatedWithMessage(c, data, "Article created successfully. Chunking and embedding generation are processing in the background.")
 		}
 	}
 }
 
+// bindUploadArticleRequest accepts either a JSON body (the original format,
+// content embedded as a string field) or multipart/form-data with the
+// article content attached as a file part, so callers can POST large text
+// files (.txt, .md, extracted PDF text) directly instead of JSON-escaping them.
+func bindUploadArticleRequest(c *gin.Context) (models.CreateArticleRequest, error) {
+	var req models.CreateArticleRequest
+
+	if !isMultipartRequest(c) {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return req, errInvalidRequestFormat
+		}
+		return req, nil
+	}
+
+	req.Title = c.PostForm("title")
+	req.URL = c.PostForm("url")
+	req.Category = c.PostForm("category")
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return req, errInvalidRequestFormat
+	}
+	if fileHeader.Size > maxInlineUploadSize {
+		return req, errUploadTooLarge
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return req, errInvalidRequestFormat
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxInlineUploadSize))
+	if err != nil {
+		return req, errInvalidRequestFormat
+	}
+	req.Content = string(content)
+
+	return req, nil
+}
+
+func isMultipartRequest(c *gin.Context) bool {
+	contentType := c.ContentType()
+	return contentType == "multipart/form-data"
+}
+
 func ListArticles(repo repository.ArticleRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var filter models.ArticleFilter
@@ -106,6 +181,111 @@ func GetArticle(repo repository.ArticleRepository) gin.HandlerFunc {
 	}
 }
 
+// GetArticleByHash looks up an article by the SHA-256 of its normalized
+// content, letting a caller check whether a piece of text has already been
+// ingested before attempting an upload.
+// @Summary Get article by content hash
+// @Description Looks up an article by the hex-encoded SHA-256 of its normalized content
+// @Tags articles
+// @Produce json
+// @Param sha256 path string true "Hex-encoded SHA-256 content hash"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /articles/by-hash/{sha256} [get]
+func GetArticleByHash(repo repository.ArticleRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		hash := c.Param("sha256")
+		if len(hash) != 64 {
+			response.BadRequest(c, "Invalid content hash")
+			return
+		}
+
+		article, err := repo.GetByContentHash(c.Request.Context(), hash)
+		if err != nil {
+			response.NotFound(c, "Article not found")
+			return
+		}
+
+		response.Success(c, article.ToResponse())
+	}
+}
+
+// DeleteArticlesBulk resolves the articles targeted by an explicit ID list
+// or a filter and, unless dry_run=true, publishes a single cascade-deletion
+// job so chunks, cached Redis keys, and vectors are cleaned up by a worker
+// rather than blocking this request. Idempotent on X-Idempotency-Key: a
+// repeated key with the job already enqueued is reported back without
+// publishing a second job.
+// @Summary Bulk delete articles
+// @Description Deletes articles matching an ID list or filter, cascading to chunks, Redis cache, and vectors via a background job
+// @Tags articles
+// @Accept json
+// @Produce json
+// @Param X-Idempotency-Key header string false "Idempotency key for safe retries"
+// @Param dry_run query bool false "Report match counts without deleting anything"
+// @Success 200 {object} models.DeleteArticlesBulkResponse
+// @Router /articles [delete]
+func DeleteArticlesBulk(reaper *services.ArticleReaper) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req models.DeleteArticlesBulkRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, "Invalid request format")
+			return
+		}
+
+		ctx := c.Request.Context()
+		dryRun := c.Query("dry_run") == "true"
+		idempotencyKey := c.GetHeader(idempotencyKeyHeader)
+
+		articleIDs, err := reaper.ResolveTargets(ctx, &req)
+		if err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+
+		chunksMatched, err := reaper.CountChunks(ctx, articleIDs)
+		if err != nil {
+			response.InternalServerError(c, "Failed to count affected chunks")
+			return
+		}
+
+		if dryRun {
+			response.Success(c, models.DeleteArticlesBulkResponse{
+				DryRun:          true,
+				ArticlesMatched: len(articleIDs),
+				ChunksMatched:   chunksMatched,
+			})
+			return
+		}
+
+		alreadySeen, err := reaper.CheckIdempotency(ctx, idempotencyKey)
+		if err != nil {
+			response.InternalServerError(c, "Failed to check idempotency key")
+			return
+		}
+		if alreadySeen {
+			response.Success(c, models.DeleteArticlesBulkResponse{
+				ArticlesMatched: len(articleIDs),
+				ChunksMatched:   chunksMatched,
+				Idempotent:      true,
+			})
+			return
+		}
+
+		jobID, err := reaper.Enqueue(articleIDs, idempotencyKey)
+		if err != nil {
+			response.InternalServerError(c, "Failed to enqueue article deletion")
+			return
+		}
+
+		response.Accepted(c, models.DeleteArticlesBulkResponse{
+			JobID:           jobID,
+			ArticlesMatched: len(articleIDs),
+			ChunksMatched:   chunksMatched,
+		})
+	}
+}
+
 func DeleteArticle(repo repository.ArticleRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, err := uuid.Parse(c.Param("id"))
@@ -121,4 +301,23 @@ func DeleteArticle(repo repository.ArticleRepository) gin.HandlerFunc {
 
 		response.SuccessWithMessage(c, nil, "Article deleted successfully")
 	}
+}
+
+// RestoreArticle undoes a soft-delete, making the article visible again to
+// every default (IncludeDeleted-unset) read.
+func RestoreArticle(repo repository.ArticleRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			response.BadRequest(c, "Invalid article ID")
+			return
+		}
+
+		if err := repo.Restore(c.Request.Context(), id); err != nil {
+			response.NotFound(c, "Article not found or not deleted")
+			return
+		}
+
+		response.SuccessWithMessage(c, nil, "Article restored successfully")
+	}
 }
\ No newline at end of file
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"rec-mind/internal/events"
+	"rec-mind/pkg/response"
+)
+
+// pollInterval bounds how long a single XRead blocks before the handler
+// re-checks whether the client disconnected.
+const pollInterval = 5 * time.Second
+
+// ArticleEvents streams an uploaded article's chunking/embedding progress
+// over Server-Sent Events. A client reconnecting with Last-Event-ID replays
+// the bounded backlog from that point; a late subscriber to an already
+// finished article gets the cached terminal event immediately.
+// @Summary Stream article processing progress
+// @Description Server-Sent Events stream of chunking_started/chunks_created/embedding_batch/vectors_uploaded/completed/failed
+// @Tags articles
+// @Produce text/event-stream
+// @Param id path string true "Article ID"
+// @Router /articles/{id}/events [get]
+func ArticleEvents(publisher *events.Publisher) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		articleID := c.Param("id")
+		if articleID == "" {
+			response.BadRequest(c, "Invalid article ID")
+			return
+		}
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+
+		if terminal, ok := publisher.Terminal(c.Request.Context(), articleID); ok {
+			writeSSEEvent(c, terminal)
+			return
+		}
+
+		lastID := c.GetHeader("Last-Event-ID")
+		if lastID == "" {
+			lastID = "0"
+		}
+
+		ctx := c.Request.Context()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			batch, err := publisher.Read(ctx, articleID, lastID, pollInterval)
+			if err != nil {
+				return
+			}
+
+			for _, event := range batch {
+				writeSSEEvent(c, &event)
+				lastID = event.ID
+				c.Writer.Flush()
+
+				if event.Stage == events.StageCompleted || event.Stage == events.StageFailed {
+					return
+				}
+			}
+		}
+	}
+}
+
+func writeSSEEvent(c *gin.Context, event *events.Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(c.Writer, "id: %s\n", event.ID)
+	fmt.Fprintf(c.Writer, "event: %s\n", event.Stage)
+	fmt.Fprintf(c.Writer, "data: %s\n\n", payload)
+	c.Writer.Flush()
+}
@@ -0,0 +1,279 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"rec-mind/internal/services"
+	"rec-mind/internal/storage"
+	"rec-mind/internal/uploads"
+	"rec-mind/models"
+	"rec-mind/pkg/response"
+)
+
+// UploadController implements the resumable upload protocol for large
+// articles: a session is created up front, bytes are appended in
+// Content-Range-addressed chunks, and finalize assembles the object,
+// creates the Article row and queues it for chunking exactly once.
+type UploadController struct {
+	sessions       *uploads.SessionStore
+	objectStore    *storage.ObjectStore
+	articleService *services.ArticleService
+}
+
+// NewUploadController wires the resumable upload endpoints to their
+// dependencies.
+func NewUploadController(sessions *uploads.SessionStore, objectStore *storage.ObjectStore, articleService *services.ArticleService) *UploadController {
+	return &UploadController{
+		sessions:       sessions,
+		objectStore:    objectStore,
+		articleService: articleService,
+	}
+}
+
+// CreateUploadSession starts a resumable upload, opening the backing S3
+// multipart upload and persisting session state in Redis.
+// @Summary Create a resumable article upload session
+// @Description Starts a resumable, chunked upload for large article content
+// @Tags articles
+// @Accept json
+// @Produce json
+// @Param session body models.CreateUploadSessionRequest true "Upload session request"
+// @Success 201 {object} models.CreateUploadSessionResponse
+// @Router /articles/uploads [post]
+func (uc *UploadController) CreateUploadSession(c *gin.Context) {
+	var req models.CreateUploadSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	ctx := c.Request.Context()
+	uploadID := uuid.New().String()
+	objectKey := fmt.Sprintf("article-uploads/%s", uploadID)
+
+	s3UploadID, err := uc.objectStore.CreateMultipartUpload(ctx, objectKey)
+	if err != nil {
+		response.InternalServerError(c, "Failed to start upload session")
+		return
+	}
+
+	session := &models.UploadSession{
+		UploadID:   uploadID,
+		ObjectKey:  objectKey,
+		S3UploadID: s3UploadID,
+		Title:      req.Title,
+		Category:   req.Category,
+		URL:        req.URL,
+		TotalSize:  req.TotalSize,
+		Status:     models.UploadSessionActive,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := uc.sessions.Create(ctx, session); err != nil {
+		response.InternalServerError(c, "Failed to persist upload session")
+		return
+	}
+
+	response.Created(c, models.CreateUploadSessionResponse{
+		UploadID:  uploadID,
+		CreatedAt: session.CreatedAt.Format(time.RFC3339),
+	})
+}
+
+// AppendUploadChunk appends a Content-Range-addressed byte range to an
+// in-progress upload. A retried range whose start matches the already
+// committed offset resumes safely; anything else is rejected so the client
+// re-fetches the committed offset and retries from there.
+// @Summary Append a byte range to a resumable upload
+// @Description Appends one Content-Range chunk of article bytes to an in-progress upload
+// @Tags articles
+// @Accept octet-stream
+// @Produce json
+// @Param id path string true "Upload ID"
+// @Success 200 {object} models.AppendUploadChunkResponse
+// @Router /articles/uploads/{id} [patch]
+func (uc *UploadController) AppendUploadChunk(c *gin.Context) {
+	uploadID := c.Param("id")
+	ctx := c.Request.Context()
+
+	session, err := uc.sessions.Get(ctx, uploadID)
+	if err != nil {
+		response.NotFound(c, "Upload session not found")
+		return
+	}
+	if session.Status != models.UploadSessionActive {
+		response.BadRequest(c, "Upload session is not active")
+		return
+	}
+
+	start, end, total, err := parseContentRange(c.GetHeader("Content-Range"))
+	if err != nil {
+		response.BadRequest(c, "Invalid or missing Content-Range header")
+		return
+	}
+	if start != session.CommittedOffset {
+		response.BadRequest(c, fmt.Sprintf("Expected chunk starting at offset %d, got %d", session.CommittedOffset, start))
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil || int64(len(body)) != end-start+1 {
+		response.BadRequest(c, "Chunk body does not match Content-Range length")
+		return
+	}
+
+	partNumber := int32(len(session.Parts)) + 1
+	etag, err := uc.objectStore.UploadPart(ctx, session.ObjectKey, session.S3UploadID, partNumber, body)
+	if err != nil {
+		response.InternalServerError(c, "Failed to upload chunk")
+		return
+	}
+
+	session.Parts = append(session.Parts, models.UploadPart{
+		PartNumber: partNumber,
+		ETag:       etag,
+		Size:       int64(len(body)),
+	})
+	session.CommittedOffset = end + 1
+	if total > 0 {
+		session.TotalSize = total
+	}
+
+	if err := uc.sessions.Update(ctx, session); err != nil {
+		response.InternalServerError(c, "Failed to persist upload progress")
+		return
+	}
+
+	response.Success(c, models.AppendUploadChunkResponse{
+		UploadID:        uploadID,
+		CommittedOffset: session.CommittedOffset,
+		TotalSize:       session.TotalSize,
+	})
+}
+
+// FinalizeUpload completes the multipart upload, creates the Article row
+// from the assembled content and publishes exactly one chunking/embedding
+// job — never one per chunk appended during the upload itself.
+// @Summary Finalize a resumable upload
+// @Description Completes the multipart upload and queues the assembled article for processing
+// @Tags articles
+// @Produce json
+// @Param id path string true "Upload ID"
+// @Success 201 {object} models.FinalizeUploadResponse
+// @Router /articles/uploads/{id}/finalize [post]
+func (uc *UploadController) FinalizeUpload(c *gin.Context) {
+	uploadID := c.Param("id")
+	ctx := c.Request.Context()
+
+	session, err := uc.sessions.Get(ctx, uploadID)
+	if err != nil {
+		response.NotFound(c, "Upload session not found")
+		return
+	}
+	if session.Status != models.UploadSessionActive {
+		response.BadRequest(c, "Upload session is not active")
+		return
+	}
+	if session.CommittedOffset != session.TotalSize {
+		response.BadRequest(c, fmt.Sprintf("Upload incomplete: committed %d of %d bytes", session.CommittedOffset, session.TotalSize))
+		return
+	}
+
+	completedParts := make([]storage.CompletedPart, len(session.Parts))
+	for i, p := range session.Parts {
+		completedParts[i] = storage.CompletedPart{PartNumber: p.PartNumber, ETag: p.ETag}
+	}
+
+	if err := uc.objectStore.CompleteMultipartUpload(ctx, session.ObjectKey, session.S3UploadID, completedParts); err != nil {
+		response.InternalServerError(c, "Failed to finalize upload")
+		return
+	}
+
+	content, err := uc.objectStore.GetObject(ctx, session.ObjectKey)
+	if err != nil {
+		response.InternalServerError(c, "Failed to read assembled upload")
+		return
+	}
+
+	article, duplicate, err := uc.articleService.CreateArticleWithAsyncEmbedding(ctx, &models.CreateArticleRequest{
+		Title:    session.Title,
+		Content:  string(content),
+		URL:      session.URL,
+		Category: session.Category,
+	})
+	if err != nil {
+		response.InternalServerError(c, "Failed to create article from upload")
+		return
+	}
+
+	session.Status = models.UploadSessionFinalized
+	if err := uc.sessions.Update(ctx, session); err != nil {
+		response.InternalServerError(c, "Upload finalized but failed to update session state")
+		return
+	}
+	_ = uc.sessions.Delete(ctx, uploadID)
+
+	status := "queued"
+	if duplicate {
+		status = "duplicate"
+	}
+
+	finalizeResponse := models.FinalizeUploadResponse{
+		UploadID:  uploadID,
+		ArticleID: article.ID.String(),
+		Status:    status,
+	}
+
+	if duplicate {
+		response.Success(c, finalizeResponse)
+		return
+	}
+
+	response.CreatedWithMessage(c, finalizeResponse, "Upload finalized; article queued for chunking and embedding")
+}
+
+// parseContentRange parses a "bytes start-end/total" header value. total may
+// be "*" when the client doesn't yet know the final size, in which case 0 is
+// returned and the caller falls back to the session's declared TotalSize.
+func parseContentRange(header string) (start, end, total int64, err error) {
+	const prefix = "bytes "
+	if !strings.HasPrefix(header, prefix) {
+		return 0, 0, 0, fmt.Errorf("missing bytes prefix")
+	}
+
+	rangeAndSize := strings.SplitN(strings.TrimPrefix(header, prefix), "/", 2)
+	if len(rangeAndSize) != 2 {
+		return 0, 0, 0, fmt.Errorf("malformed Content-Range")
+	}
+
+	startEnd := strings.SplitN(rangeAndSize[0], "-", 2)
+	if len(startEnd) != 2 {
+		return 0, 0, 0, fmt.Errorf("malformed byte range")
+	}
+
+	start, err = strconv.ParseInt(startEnd[0], 10, 64)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	end, err = strconv.ParseInt(startEnd[1], 10, 64)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	if rangeAndSize[1] != "*" {
+		total, err = strconv.ParseInt(rangeAndSize[1], 10, 64)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	return start, end, total, nil
+}
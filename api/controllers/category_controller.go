@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"rec-mind/internal/repository"
+	"rec-mind/models"
+	"rec-mind/pkg/response"
+)
+
+func CreateCategory(repo repository.CategoryRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req models.CreateCategoryRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, "Invalid request format")
+			return
+		}
+
+		category, err := repo.Create(c.Request.Context(), &req)
+		if err != nil {
+			response.InternalServerError(c, "Failed to create category")
+			return
+		}
+
+		response.Created(c, category.ToResponse())
+	}
+}
+
+func ListCategories(repo repository.CategoryRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var filter models.CategoryFilter
+		if err := c.ShouldBindQuery(&filter); err != nil {
+			response.BadRequest(c, "Invalid query parameters")
+			return
+		}
+
+		filter.SetDefaults()
+		categories, err := repo.List(c.Request.Context(), &filter)
+		if err != nil {
+			response.InternalServerError(c, "Failed to fetch categories")
+			return
+		}
+
+		categoryResponses := make([]map[string]interface{}, len(categories))
+		for i, category := range categories {
+			categoryResponses[i] = category.ToResponse()
+		}
+
+		page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+		response.Paginated(c, categoryResponses, len(categoryResponses), page, filter.Limit)
+	}
+}
+
+func GetCategory(repo repository.CategoryRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		category, err := repo.GetBySlug(c.Request.Context(), c.Param("slug"))
+		if err != nil {
+			response.NotFound(c, "Category not found")
+			return
+		}
+
+		response.Success(c, category.ToResponse())
+	}
+}
+
+// GetArticlesByCategory lists articles belonging to the category identified
+// by :slug, paginated via ?limit=&offset=.
+func GetArticlesByCategory(articleRepo repository.ArticleRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		slug := c.Param("slug")
+
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+		if err != nil || limit <= 0 {
+			limit = 20
+		}
+		offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+
+		articles, err := articleRepo.GetByCategorySlug(c.Request.Context(), slug, limit, offset)
+		if err != nil {
+			response.InternalServerError(c, "Failed to fetch articles")
+			return
+		}
+
+		total, err := articleRepo.CountByCategory(c.Request.Context(), slug)
+		if err != nil {
+			response.InternalServerError(c, "Failed to count articles")
+			return
+		}
+
+		articleResponses := make([]map[string]interface{}, len(articles))
+		for i, article := range articles {
+			articleResponses[i] = article.ToResponse()
+		}
+
+		page := offset/limit + 1
+		response.Paginated(c, articleResponses, int(total), page, limit)
+	}
+}
+
+func UpdateCategory(repo repository.CategoryRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			response.BadRequest(c, "Invalid category ID")
+			return
+		}
+
+		var req models.UpdateCategoryRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, "Invalid request format")
+			return
+		}
+
+		category, err := repo.Update(c.Request.Context(), id, &req)
+		if err != nil {
+			response.InternalServerError(c, "Failed to update category")
+			return
+		}
+
+		response.Success(c, category.ToResponse())
+	}
+}
+
+func DeleteCategory(repo repository.CategoryRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			response.BadRequest(c, "Invalid category ID")
+			return
+		}
+
+		if err := repo.Delete(c.Request.Context(), id); err != nil {
+			response.InternalServerError(c, "Failed to delete category")
+			return
+		}
+
+		response.SuccessWithMessage(c, nil, "Category deleted successfully")
+	}
+}
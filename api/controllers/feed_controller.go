@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"rec-mind/internal/feeds"
+	"rec-mind/models"
+	"rec-mind/pkg/response"
+)
+
+// CreateFeed registers a new RSS feed. GlobalScraperManager picks it up on
+// its next reconcile tick, no redeploy required.
+func CreateFeed(store *feeds.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req models.CreateFeedRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, "Invalid request format")
+			return
+		}
+
+		slug := req.Slug
+		if slug == "" {
+			slug = models.Slugify(req.Name)
+		}
+
+		enabled := true
+		if req.Enabled != nil {
+			enabled = *req.Enabled
+		}
+
+		feed := &models.FeedConfig{
+			Slug:         slug,
+			Name:         req.Name,
+			URL:          req.URL,
+			Category:     req.Category,
+			Enabled:      enabled,
+			PollInterval: req.PollInterval,
+			MaxArticles:  req.MaxArticles,
+		}
+
+		if err := store.Create(c.Request.Context(), feed); err != nil {
+			response.InternalServerError(c, "Failed to create feed")
+			return
+		}
+
+		response.Created(c, feed)
+	}
+}
+
+// ListFeeds returns every registered feed.
+func ListFeeds(store *feeds.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		feedConfigs, err := store.List(c.Request.Context())
+		if err != nil {
+			response.InternalServerError(c, "Failed to fetch feeds")
+			return
+		}
+
+		response.Success(c, feedConfigs)
+	}
+}
+
+// GetFeed fetches a single feed by slug.
+func GetFeed(store *feeds.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		feed, err := store.Get(c.Request.Context(), c.Param("slug"))
+		if err != nil {
+			response.InternalServerError(c, "Failed to fetch feed")
+			return
+		}
+		if feed == nil {
+			response.NotFound(c, "Feed not found")
+			return
+		}
+
+		response.Success(c, feed)
+	}
+}
+
+// UpdateFeed applies a partial update to a feed's config. GlobalScraperManager
+// restarts or stops the feed's scheduler on its next reconcile tick if URL,
+// PollInterval, or Enabled changed.
+func UpdateFeed(store *feeds.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		slug := c.Param("slug")
+
+		feed, err := store.Get(c.Request.Context(), slug)
+		if err != nil {
+			response.InternalServerError(c, "Failed to fetch feed")
+			return
+		}
+		if feed == nil {
+			response.NotFound(c, "Feed not found")
+			return
+		}
+
+		var req models.UpdateFeedRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, "Invalid request format")
+			return
+		}
+
+		if req.Name != nil {
+			feed.Name = *req.Name
+		}
+		if req.URL != nil {
+			feed.URL = *req.URL
+		}
+		if req.Category != nil {
+			feed.Category = *req.Category
+		}
+		if req.Enabled != nil {
+			feed.Enabled = *req.Enabled
+		}
+		if req.PollInterval != nil {
+			feed.PollInterval = *req.PollInterval
+		}
+		if req.MaxArticles != nil {
+			feed.MaxArticles = *req.MaxArticles
+		}
+
+		if err := store.Update(c.Request.Context(), feed); err != nil {
+			response.InternalServerError(c, "Failed to update feed")
+			return
+		}
+
+		response.Success(c, feed)
+	}
+}
+
+// DeleteFeed removes a feed. GlobalScraperManager stops its scheduler on
+// its next reconcile tick.
+func DeleteFeed(store *feeds.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		slug := c.Param("slug")
+
+		if err := store.Delete(c.Request.Context(), slug); err != nil {
+			response.InternalServerError(c, "Failed to delete feed")
+			return
+		}
+
+		response.SuccessWithMessage(c, nil, "Feed deleted successfully")
+	}
+}
+
+// EnableFeed turns a feed back on without requiring the caller to resend
+// its full config.
+func EnableFeed(store *feeds.Store) gin.HandlerFunc {
+	return setFeedEnabled(store, true)
+}
+
+// DisableFeed turns a feed off without deleting its config, so it can be
+// re-enabled later with its schedule and history intact.
+func DisableFeed(store *feeds.Store) gin.HandlerFunc {
+	return setFeedEnabled(store, false)
+}
+
+func setFeedEnabled(store *feeds.Store, enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		slug := c.Param("slug")
+
+		feed, err := store.Get(c.Request.Context(), slug)
+		if err != nil {
+			response.InternalServerError(c, "Failed to fetch feed")
+			return
+		}
+		if feed == nil {
+			response.NotFound(c, "Feed not found")
+			return
+		}
+
+		feed.Enabled = enabled
+		if err := store.Update(c.Request.Context(), feed); err != nil {
+			response.InternalServerError(c, "Failed to update feed")
+			return
+		}
+
+		response.Success(c, feed)
+	}
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"rec-mind/internal/search"
+	"rec-mind/pkg/response"
+)
+
+// FullTextSearch queries the Bleve search index across both article and
+// chunk documents.
+// @Summary Full-text search over articles and chunks
+// @Description Keyword search backed by the local Bleve index, returning ranked article and chunk hits
+// @Tags search
+// @Produce json
+// @Param q query string true "Search query"
+// @Param category query string false "Restrict to articles in this category"
+// @Param limit query int false "Max hits to return (default 10)"
+// @Router /search [get]
+func FullTextSearch(index search.SearchIndex) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		q := c.Query("q")
+		if q == "" {
+			response.BadRequest(c, "Query parameter 'q' is required")
+			return
+		}
+
+		if index == nil {
+			response.InternalServerError(c, "Search index is not available")
+			return
+		}
+
+		limit, _ := strconv.Atoi(c.Query("limit"))
+
+		hits, err := index.Query(q, search.SearchOptions{
+			Category: c.Query("category"),
+			Limit:    limit,
+		})
+		if err != nil {
+			response.InternalServerError(c, "Search query failed")
+			return
+		}
+
+		response.OK(c, gin.H{
+			"query": q,
+			"hits":  hits,
+			"count": len(hits),
+		})
+	}
+}
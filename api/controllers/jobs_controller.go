@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"rec-mind/internal/jobs"
+	"rec-mind/pkg/response"
+)
+
+// GetJobStatus polls a durable background job from the internal/jobs
+// queue (currently just embedding jobs enqueued by
+// ArticleService.CreateArticleWithAsyncEmbedding). A job on record gets 200
+// with its jobs.StatusView whatever its status; an unknown job_id gets the
+// same 404 "processing" body as SearchController.GetQuerySearchJobStatus.
+// The 200 body differs from the search endpoint's, which returns the
+// finished result rather than a status view. queue is nil when the server wasn't
+// started with Redis configured, in which case every job_id reports
+// unavailable.
+func GetJobStatus(queue *jobs.Queue) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		jobID := c.Param("job_id")
+		if jobID == "" {
+			response.BadRequest(c, "job_id parameter is required")
+			return
+		}
+
+		if queue == nil {
+			response.InternalServerError(c, "Job queue is not available")
+			return
+		}
+
+		job, err := queue.Get(c.Request.Context(), jobID)
+		if err != nil {
+			response.InternalServerError(c, "Failed to look up job status")
+			return
+		}
+		if job == nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"job_id":  jobID,
+				"status":  "processing",
+				"message": "Job is still being processed or does not exist",
+			})
+			return
+		}
+
+		response.Success(c, job.View())
+	}
+}
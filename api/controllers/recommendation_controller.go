@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -11,10 +12,14 @@ import (
 	"github.com/google/uuid"
 
 	"rec-mind/internal/database"
+	"rec-mind/internal/logging"
+	"rec-mind/internal/recostream"
 	"rec-mind/internal/redis"
 	"rec-mind/mq"
 )
 
+var recommendationLog = logging.New("recommendation-controller")
+
 type RecommendationController struct{}
 
 func NewRecommendationController() *RecommendationController {
@@ -34,6 +39,17 @@ type RecommendationJobResponse struct {
 	CreatedAt string `json:"created_at"`
 }
 
+// publishStageQueued broadcasts the queued lifecycle stage for a newly
+// created job so a client already streaming /jobs/{job_id}/stream sees it
+// immediately instead of waiting on the worker to pick it up. Best-effort:
+// a streaming client can infer "queued" from the 202 response anyway.
+func publishStageQueued(ctx context.Context, logger *slog.Logger, jobID string) {
+	publisher := recostream.NewRedisResultPublisher(redis.RedisClient)
+	if err := publisher.PublishStage(ctx, jobID, recostream.StageQueued, ""); err != nil {
+		logger.Warn("failed to publish queued stage", "job_id", jobID, "error", err)
+	}
+}
+
 // CreateRecommendationJob creates a new recommendation job
 // @Summary Create recommendation job
 // @Description Create an async recommendation job for article similarity search
@@ -58,17 +74,24 @@ func (rc *RecommendationController) CreateRecommendationJob(c *gin.Context) {
 	// Generate job ID
 	jobID := uuid.New().String()
 
+	correlationID := req.CorrelationID
+	if correlationID == "" {
+		correlationID = c.GetString(logging.CorrelationIDKey)
+	}
+	logger := logging.FromContext(c.Request.Context(), recommendationLog)
+
 	// Create recommendation job
 	job := database.RecommendationJob{
 		JobID:         jobID,
 		ArticleID:     req.ArticleID,
 		SessionID:     req.SessionID,
 		CreatedAt:     time.Now(),
-		CorrelationID: req.CorrelationID,
+		CorrelationID: correlationID,
 	}
 
 	// Publish job to queue
 	if err := mq.PublishRecommendationJob(job); err != nil {
+		logger.Error("failed to queue recommendation job", "job_id", jobID, "article_id", req.ArticleID, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to queue recommendation job",
 			"details": err.Error(),
@@ -76,6 +99,9 @@ func (rc *RecommendationController) CreateRecommendationJob(c *gin.Context) {
 		return
 	}
 
+	logger.Info("queued recommendation job", "job_id", jobID, "article_id", req.ArticleID)
+	publishStageQueued(c.Request.Context(), logger, jobID)
+
 	response := RecommendationJobResponse{
 		JobID:     jobID,
 		Status:    "queued",
@@ -157,6 +183,8 @@ func (rc *RecommendationController) GetRecommendations(c *gin.Context) {
 		return
 	}
 
+	logger := logging.FromContext(c.Request.Context(), recommendationLog)
+
 	// Create and submit job
 	jobID := uuid.New().String()
 	job := database.RecommendationJob{
@@ -164,10 +192,11 @@ func (rc *RecommendationController) GetRecommendations(c *gin.Context) {
 		ArticleID:     articleID,
 		SessionID:     c.GetString("session_id"),
 		CreatedAt:     time.Now(),
-		CorrelationID: c.GetHeader("X-Correlation-ID"),
+		CorrelationID: c.GetString(logging.CorrelationIDKey),
 	}
 
 	if err := mq.PublishRecommendationJob(job); err != nil {
+		logger.Error("failed to queue recommendation job", "job_id", jobID, "article_id", articleID, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to queue recommendation job",
 			"details": err.Error(),
@@ -175,6 +204,9 @@ func (rc *RecommendationController) GetRecommendations(c *gin.Context) {
 		return
 	}
 
+	logger.Info("queued recommendation job", "job_id", jobID, "article_id", articleID)
+	publishStageQueued(c.Request.Context(), logger, jobID)
+
 	// Wait for a short time to see if we get quick results
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -205,6 +237,144 @@ func (rc *RecommendationController) GetRecommendations(c *gin.Context) {
 	})
 }
 
+const (
+	// streamHeartbeatInterval is how often a comment-only SSE frame is sent
+	// to keep idle connections from being dropped by proxies/load balancers.
+	streamHeartbeatInterval = 15 * time.Second
+	// defaultStreamIdleTimeout closes the connection if no real stage or
+	// snapshot event (heartbeats don't count) arrives within this window,
+	// since a silent job is almost certainly stuck rather than slow.
+	defaultStreamIdleTimeout = 2 * time.Minute
+)
+
+// StreamRecommendationJob streams a recommendation job's lifecycle stages
+// and ranking over Server-Sent Events as it refines: the worker publishes a
+// stage transition as it moves through embedding/searching/ranking, and a
+// partial snapshot every time a batch of chunk searches completes, so a
+// client can render progress and top recommendations within a second
+// instead of waiting on the full job.
+// @Summary Stream recommendation job results
+// @Description Server-Sent Events stream of lifecycle stages and partial/final recommendation snapshots for a job
+// @Tags recommendations
+// @Produce text/event-stream
+// @Param job_id path string true "Job ID"
+// @Param idle_timeout_seconds query int false "Close the stream after this many seconds with no job activity (default 120)"
+// @Router /api/v1/jobs/{job_id}/stream [get]
+func (rc *RecommendationController) StreamRecommendationJob(c *gin.Context) {
+	jobID := c.Param("job_id")
+	if jobID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "job_id parameter is required",
+		})
+		return
+	}
+
+	idleTimeout := defaultStreamIdleTimeout
+	if raw := c.Query("idle_timeout_seconds"); raw != "" {
+		if seconds, err := time.ParseDuration(raw + "s"); err == nil && seconds > 0 {
+			idleTimeout = seconds
+		}
+	}
+
+	ctx := c.Request.Context()
+	resultSub := recostream.Subscribe(ctx, redis.RedisClient, jobID)
+	defer resultSub.Close()
+	stageSub := recostream.SubscribeStages(ctx, redis.RedisClient, jobID)
+	defer stageSub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	// A job that already finished before the client subscribed won't
+	// publish again, so check for a cached final result up front.
+	if resultJSON, err := redis.RedisClient.Get(ctx, fmt.Sprintf("recommendation_result:%s", jobID)).Result(); err == nil {
+		var result database.RecommendationResult
+		if err := json.Unmarshal([]byte(resultJSON), &result); err == nil {
+			writeRecommendationSnapshot(c, recostream.Snapshot{RecommendationResult: result, Partial: false})
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+	idle := time.NewTimer(idleTimeout)
+	defer idle.Stop()
+
+	resultCh := resultSub.Channel()
+	stageCh := stageSub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-idle.C:
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		case msg, ok := <-stageCh:
+			if !ok {
+				return
+			}
+			var event recostream.StageEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				continue
+			}
+			resetTimer(idle, idleTimeout)
+			writeStageEvent(c, event)
+			if event.Stage == recostream.StageCompleted || event.Stage == recostream.StageFailed {
+				return
+			}
+		case msg, ok := <-resultCh:
+			if !ok {
+				return
+			}
+			var snapshot recostream.Snapshot
+			if err := json.Unmarshal([]byte(msg.Payload), &snapshot); err != nil {
+				continue
+			}
+			resetTimer(idle, idleTimeout)
+			writeRecommendationSnapshot(c, snapshot)
+			if !snapshot.Partial {
+				return
+			}
+		}
+	}
+}
+
+// resetTimer drains a fired timer before resetting it, matching the pattern
+// required by time.Timer.Reset's documentation for timers read via a select.
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	t.Reset(d)
+}
+
+func writeStageEvent(c *gin.Context, event recostream.StageEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprint(c.Writer, "event: progress\n")
+	fmt.Fprintf(c.Writer, "data: %s\n\n", payload)
+	c.Writer.Flush()
+}
+
+func writeRecommendationSnapshot(c *gin.Context, snapshot recostream.Snapshot) {
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return
+	}
+	fmt.Fprint(c.Writer, "event: result\n")
+	fmt.Fprintf(c.Writer, "id: %d\n", snapshot.Version)
+	fmt.Fprintf(c.Writer, "data: %s\n\n", payload)
+	c.Writer.Flush()
+}
+
 // HealthCheck for recommendation service
 // @Summary Recommendation service health
 // @Description Check the health of recommendation service dependencies
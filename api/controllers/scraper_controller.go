@@ -1,30 +1,18 @@
 package controllers
 
 import (
-	"context"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"rec-mind/internal/services"
 )
 
-// ScrapeArticles triggers RSS feed scraping
-func ScrapeArticles(scraperService *services.ScraperService) gin.HandlerFunc {
+// ScrapeArticles reports the most recent scrape outcome across every feed
+// GlobalScraperManager currently schedules from internal/feeds.Store, rather
+// than triggering a fresh synchronous scrape of every feed on every call.
+func ScrapeArticles(scraperManager *services.GlobalScraperManager) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Set timeout for scraping operation
-		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Minute)
-		defer cancel()
-
-		// Run scraper
-		result, err := scraperService.ScrapeAllFeeds(ctx)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to scrape articles",
-				"details": err.Error(),
-			})
-			return
-		}
+		result := scraperManager.LatestResult()
 
 		// Return comprehensive results
 		response := gin.H{
@@ -5,21 +5,136 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"rec-mind/config"
+	"rec-mind/internal/events"
+	"rec-mind/internal/logging"
+	"rec-mind/internal/metrics"
 	"rec-mind/internal/redis"
+	"rec-mind/internal/repository"
 	"rec-mind/models"
 	"rec-mind/mq"
 	"rec-mind/pkg/response"
 )
 
-type SearchController struct{}
+var searchControllerLog = logging.New("search-controller")
+
+// searchJobCancelKeyPrefix must match QueryRAGWorker.isCancelled's key
+// format - duplicated here rather than imported the same way
+// "query_search_result:%s" is duplicated between this file and
+// internal/worker/query_rag_worker.go.
+const searchJobCancelKeyPrefix = "search_job_cancel:"
+
+// searchJobCancelTTL bounds how long a cancel request's Redis flag survives,
+// generously beyond collectQuerySearchResult's 30s wait so a job can't miss
+// a cancel that arrived just before it reached the check in
+// QueryRAGWorker.ProcessQuerySearchJob.
+const searchJobCancelTTL = 5 * time.Minute
+
+// SearchController serves query search job submission/status/streaming.
+// eventPublisher may be nil (e.g. if Redis isn't configured for a given
+// deployment), in which case SearchStream reports unavailable and the
+// queued-stage publish in SearchByQuery/SearchWithImmediateResponse is a
+// no-op, same optional-dependency shape as QueryRAGWorker.eventPublisher.
+// jobRepo may be nil (e.g. --archive=false), in which case the history/logs
+// endpoints report unavailable and cancel/retry fall back to Redis-only
+// bookkeeping.
+type SearchController struct {
+	eventPublisher *events.Publisher
+	jobRepo        repository.JobRepository
+	idempotencyTTL time.Duration
+}
+
+func NewSearchController(eventPublisher *events.Publisher, jobRepo repository.JobRepository) *SearchController {
+	return &SearchController{
+		eventPublisher: eventPublisher,
+		jobRepo:        jobRepo,
+		idempotencyTTL: config.LoadSearchConfig().IdempotencyTTL,
+	}
+}
+
+// idempotencyKey pairs tenantID and correlationID into the Redis key
+// SearchByQuery/SearchWithImmediateResponse use to deduplicate a
+// resubmission, per the (tenant, correlation_id) idempotency contract.
+func idempotencyKey(tenantID, correlationID string) string {
+	return fmt.Sprintf("idem:%s:%s", tenantID, correlationID)
+}
+
+// claimIdempotencyKey SETNXs idempotencyKey(tenantID, correlationID) to
+// jobID. If the key is already claimed (a resubmission of the same
+// correlation_id), it returns the job_id already on record instead, so the
+// caller can return that job's status rather than enqueueing a duplicate.
+func (sc *SearchController) claimIdempotencyKey(ctx context.Context, tenantID, correlationID, jobID string) (existingJobID string, duplicate bool, err error) {
+	key := idempotencyKey(tenantID, correlationID)
+
+	claimed, err := redis.RedisClient.SetNX(ctx, key, jobID, sc.idempotencyTTL).Result()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+	if claimed {
+		return jobID, false, nil
+	}
+
+	existing, err := redis.RedisClient.Get(ctx, key).Result()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read existing idempotency key: %w", err)
+	}
+	return existing, true, nil
+}
+
+// respondWithExistingJob answers a deduplicated submission with jobID's
+// current result if it's already finished, or the same Accepted shape a
+// fresh submission gets otherwise - the caller can't tell a duplicate from
+// a first-time submission by the response shape alone.
+func (sc *SearchController) respondWithExistingJob(c *gin.Context, jobID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := fmt.Sprintf("query_search_result:%s", jobID)
+	if resultJSON, err := redis.RedisClient.Get(ctx, key).Result(); err == nil {
+		var result models.QueryRecommendationResult
+		if err := json.Unmarshal([]byte(resultJSON), &result); err == nil {
+			response.Success(c, result)
+			return
+		}
+	}
+
+	data := QuerySearchJobResponse{
+		JobID:     jobID,
+		Status:    "queued",
+		Message:   "Duplicate submission for this correlation_id - returning the original job instead of enqueueing a new one",
+		PollURL:   fmt.Sprintf("/api/v1/search/jobs/%s", jobID),
+		StreamURL: fmt.Sprintf("/api/v1/search/jobs/%s/stream", jobID),
+		CreatedAt: time.Now().Format(time.RFC3339),
+	}
+	response.Accepted(c, data)
+}
 
-func NewSearchController() *SearchController {
-	return &SearchController{}
+// tenantIDOrSession falls back to SessionID when the caller doesn't set
+// TenantID explicitly, so existing clients still get a fair-scheduling
+// identity without needing to change their request body.
+func tenantIDOrSession(req QuerySearchRequest) string {
+	if req.TenantID != "" {
+		return req.TenantID
+	}
+	return req.SessionID
+}
+
+// publishStage best-effort publishes a search job progress event; failures
+// are logged, not returned, since they're a side channel the HTTP response
+// doesn't depend on.
+func (sc *SearchController) publishStage(jobID, stage string) {
+	if sc.eventPublisher == nil {
+		return
+	}
+	if err := sc.eventPublisher.Publish(context.Background(), jobID, stage, nil); err != nil {
+		searchControllerLog.Error("failed to publish search job event", "job_id", jobID, "stage", stage, "error", err)
+	}
 }
 
 type QuerySearchRequest struct {
@@ -28,6 +143,11 @@ type QuerySearchRequest struct {
 	MaxResults     int     `json:"max_results,omitempty"`
 	ScoreThreshold float64 `json:"score_threshold,omitempty"`
 	CorrelationID  string  `json:"correlation_id,omitempty"`
+	// TenantID scopes this job's fair-scheduling cap (internal/worker's
+	// TenantSemaphore); defaults to SessionID when left unset.
+	TenantID string `json:"tenant_id,omitempty"`
+	// Priority is 0 (lowest) to mq.QuerySearchJobsMaxPriority (highest).
+	Priority int `json:"priority,omitempty" binding:"omitempty,min=0,max=9"`
 }
 
 type QuerySearchJobResponse struct {
@@ -35,6 +155,7 @@ type QuerySearchJobResponse struct {
 	Status    string `json:"status"`
 	Message   string `json:"message"`
 	PollURL   string `json:"poll_url"`
+	StreamURL string `json:"stream_url"`
 	CreatedAt string `json:"created_at"`
 }
 
@@ -58,6 +179,21 @@ func (sc *SearchController) SearchByQuery(c *gin.Context) {
 
 	// Generate job ID
 	jobID := uuid.New().String()
+	tenantID := tenantIDOrSession(req)
+
+	if req.CorrelationID != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		existingJobID, duplicate, err := sc.claimIdempotencyKey(ctx, tenantID, req.CorrelationID, jobID)
+		cancel()
+		if err != nil {
+			response.InternalServerError(c, "Failed to check idempotency")
+			return
+		}
+		if duplicate {
+			sc.respondWithExistingJob(c, existingJobID)
+			return
+		}
+	}
 
 	// Create query search job
 	job := models.QuerySearchJob{
@@ -68,18 +204,23 @@ func (sc *SearchController) SearchByQuery(c *gin.Context) {
 		ScoreThreshold: req.ScoreThreshold,
 		CreatedAt:      time.Now(),
 		CorrelationID:  req.CorrelationID,
+		TenantID:       tenantID,
+		Priority:       req.Priority,
 	}
 
 	if err := mq.PublishQuerySearchJob(job); err != nil {
 		response.InternalServerError(c, "Failed to queue search job")
 		return
 	}
+	metrics.SearchJobsEnqueuedTotal.WithLabelValues(tenantID, strconv.Itoa(job.Priority)).Inc()
+	sc.publishStage(jobID, events.StageQueued)
 
 	data := QuerySearchJobResponse{
 		JobID:     jobID,
 		Status:    "queued",
 		Message:   "Search job has been queued for processing",
 		PollURL:   fmt.Sprintf("/api/v1/search/jobs/%s", jobID),
+		StreamURL: fmt.Sprintf("/api/v1/search/jobs/%s/stream", jobID),
 		CreatedAt: job.CreatedAt.Format(time.RFC3339),
 	}
 
@@ -99,8 +240,9 @@ func (sc *SearchController) GetQuerySearchJobStatus(c *gin.Context) {
 	// Check Redis for result
 	key := fmt.Sprintf("query_search_result:%s", jobID)
 	resultJSON, err := redis.RedisClient.Get(ctx, key).Result()
-	
+
 	if err != nil {
+		metrics.RedisResultCacheHitsTotal.WithLabelValues("miss").Inc()
 		data := gin.H{
 			"job_id": jobID,
 			"status": "processing",
@@ -109,6 +251,7 @@ func (sc *SearchController) GetQuerySearchJobStatus(c *gin.Context) {
 		c.JSON(http.StatusNotFound, data)
 		return
 	}
+	metrics.RedisResultCacheHitsTotal.WithLabelValues("hit").Inc()
 
 	var result models.QueryRecommendationResult
 	if err := json.Unmarshal([]byte(resultJSON), &result); err != nil {
@@ -139,6 +282,21 @@ func (sc *SearchController) SearchWithImmediateResponse(c *gin.Context) {
 
 	// Generate job ID
 	jobID := uuid.New().String()
+	tenantID := tenantIDOrSession(req)
+
+	if req.CorrelationID != "" {
+		idemCtx, idemCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		existingJobID, duplicate, err := sc.claimIdempotencyKey(idemCtx, tenantID, req.CorrelationID, jobID)
+		idemCancel()
+		if err != nil {
+			response.InternalServerError(c, "Failed to check idempotency")
+			return
+		}
+		if duplicate {
+			sc.respondWithExistingJob(c, existingJobID)
+			return
+		}
+	}
 
 	// Create query search job
 	job := models.QuerySearchJob{
@@ -149,42 +307,386 @@ func (sc *SearchController) SearchWithImmediateResponse(c *gin.Context) {
 		ScoreThreshold: req.ScoreThreshold,
 		CreatedAt:      time.Now(),
 		CorrelationID:  req.CorrelationID,
+		TenantID:       tenantID,
+		Priority:       req.Priority,
 	}
 
 	if err := mq.PublishQuerySearchJob(job); err != nil {
 		response.InternalServerError(c, "Failed to queue search job")
 		return
 	}
+	metrics.SearchJobsEnqueuedTotal.WithLabelValues(tenantID, strconv.Itoa(job.Priority)).Inc()
+	sc.publishStage(jobID, events.StageQueued)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	key := fmt.Sprintf("query_search_result:%s", jobID)
-	
-	for i := 0; i < 20; i++ {
-		resultJSON, err := redis.RedisClient.Get(ctx, key).Result()
-		if err == nil {
-			var result models.QueryRecommendationResult
-			if err := json.Unmarshal([]byte(resultJSON), &result); err == nil {
-				response.Success(c, result)
-				return
+	// Block once on the job's event stream for a terminal stage instead of
+	// polling Redis on a fixed interval; SearchStream is the preferred way
+	// for a frontend to get real-time progress, this is a bounded-wait
+	// convenience for callers that just want a single response.
+	if sc.eventPublisher != nil {
+		lastID := "0"
+	waitForTerminal:
+		for ctx.Err() == nil {
+			batch, err := sc.eventPublisher.Read(ctx, jobID, lastID, 10*time.Second)
+			if err != nil {
+				break
+			}
+			for _, event := range batch {
+				lastID = event.ID
+				if event.Stage == events.StageCompleted || event.Stage == events.StageFailed {
+					break waitForTerminal
+				}
 			}
 		}
-		
-		time.Sleep(500 * time.Millisecond)
+	}
+
+	key := fmt.Sprintf("query_search_result:%s", jobID)
+	if resultJSON, err := redis.RedisClient.Get(ctx, key).Result(); err == nil {
+		var result models.QueryRecommendationResult
+		if err := json.Unmarshal([]byte(resultJSON), &result); err == nil {
+			response.Success(c, result)
+			return
+		}
 	}
 
 	data := QuerySearchJobResponse{
 		JobID:     jobID,
 		Status:    "processing",
-		Message:   "Search job is being processed. Use the poll_url to check status",
+		Message:   "Search job is being processed. Use the poll_url or stream_url to check status",
 		PollURL:   fmt.Sprintf("/api/v1/search/jobs/%s", jobID),
+		StreamURL: fmt.Sprintf("/api/v1/search/jobs/%s/stream", jobID),
 		CreatedAt: job.CreatedAt.Format(time.RFC3339),
 	}
 
 	response.Accepted(c, data)
 }
 
+// SearchStream streams a query search job's progress (queued, retrieving,
+// completed/failed) over Server-Sent Events, mirroring ArticleEvents. A
+// client reconnecting with Last-Event-ID replays the bounded backlog from
+// that point; a late subscriber to an already finished job gets the cached
+// terminal event immediately.
+// @Summary Stream search job progress
+// @Description Server-Sent Events stream of queued/retrieving/completed/failed
+// @Tags search
+// @Produce text/event-stream
+// @Param job_id path string true "Search job ID"
+// @Router /search/jobs/{job_id}/stream [get]
+func (sc *SearchController) SearchStream(c *gin.Context) {
+	jobID := c.Param("job_id")
+	if jobID == "" {
+		response.BadRequest(c, "job_id parameter is required")
+		return
+	}
+	if sc.eventPublisher == nil {
+		response.InternalServerError(c, "Search job streaming is not configured")
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	if terminal, ok := sc.eventPublisher.Terminal(c.Request.Context(), jobID); ok {
+		writeSSEEvent(c, terminal)
+		return
+	}
+
+	lastID := c.GetHeader("Last-Event-ID")
+	if lastID == "" {
+		lastID = "0"
+	}
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		batch, err := sc.eventPublisher.Read(ctx, jobID, lastID, pollInterval)
+		if err != nil {
+			return
+		}
+
+		for _, event := range batch {
+			writeSSEEvent(c, &event)
+			lastID = event.ID
+			c.Writer.Flush()
+
+			if event.Stage == events.StageCompleted || event.Stage == events.StageFailed {
+				return
+			}
+		}
+	}
+}
+
+// CancelSearchJob requests that an in-flight search job stop early, by
+// setting the search_job_cancel:<id> Redis flag QueryRAGWorker checks
+// between retrieval and enrichment. A job already in a terminal state can't
+// be cancelled.
+// @Summary Cancel a search job
+// @Tags search
+// @Produce json
+// @Param job_id path string true "Search job ID"
+// @Router /search/jobs/{job_id} [delete]
+func (sc *SearchController) CancelSearchJob(c *gin.Context) {
+	jobID := c.Param("job_id")
+	if jobID == "" {
+		response.BadRequest(c, "job_id parameter is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if sc.jobRepo != nil {
+		record, err := sc.jobRepo.Get(ctx, jobID)
+		if err != nil {
+			response.InternalServerError(c, "Failed to look up search job")
+			return
+		}
+		if record == nil {
+			response.NotFound(c, "Search job not found")
+			return
+		}
+		if isTerminalJobStatus(record.Status) {
+			response.BadRequest(c, fmt.Sprintf("Search job already %s", record.Status))
+			return
+		}
+	}
+
+	key := searchJobCancelKeyPrefix + jobID
+	if err := redis.RedisClient.Set(ctx, key, "1", searchJobCancelTTL).Err(); err != nil {
+		response.InternalServerError(c, "Failed to request cancellation")
+		return
+	}
+
+	response.Accepted(c, gin.H{"job_id": jobID, "status": "cancel_requested"})
+}
+
+// isTerminalJobStatus reports whether status ends a search_jobs row's
+// lifecycle, matching the models.JobStatus* constants QueryRAGWorker writes.
+func isTerminalJobStatus(status string) bool {
+	switch status {
+	case models.JobStatusCompleted, models.JobStatusFailed, models.JobStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// RetrySearchJob resubmits a finished job's query as a brand new job (fresh
+// JobID, same query/session/tenant), rather than reusing the original
+// JobID, since search_jobs.job_id is unique and the original row's history
+// (attempts, error, timings) should stay intact as its own record.
+// @Summary Retry a finished search job
+// @Tags search
+// @Produce json
+// @Param job_id path string true "Search job ID"
+// @Router /search/jobs/{job_id}/retry [post]
+func (sc *SearchController) RetrySearchJob(c *gin.Context) {
+	jobID := c.Param("job_id")
+	if jobID == "" {
+		response.BadRequest(c, "job_id parameter is required")
+		return
+	}
+	if sc.jobRepo == nil {
+		response.InternalServerError(c, "Search job history is not configured")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	record, err := sc.jobRepo.Get(ctx, jobID)
+	if err != nil {
+		response.InternalServerError(c, "Failed to look up search job")
+		return
+	}
+	if record == nil {
+		response.NotFound(c, "Search job not found")
+		return
+	}
+	if !isTerminalJobStatus(record.Status) {
+		response.BadRequest(c, fmt.Sprintf("Search job is still %s", record.Status))
+		return
+	}
+
+	newJobID := uuid.New().String()
+	job := models.QuerySearchJob{
+		JobID:         newJobID,
+		Query:         record.Query,
+		SessionID:     record.SessionID,
+		CreatedAt:     time.Now(),
+		CorrelationID: record.CorrelationID,
+		TenantID:      record.TenantID,
+	}
+
+	if err := mq.PublishQuerySearchJob(job); err != nil {
+		response.InternalServerError(c, "Failed to queue retried search job")
+		return
+	}
+	sc.publishStage(newJobID, events.StageQueued)
+
+	data := QuerySearchJobResponse{
+		JobID:     newJobID,
+		Status:    "queued",
+		Message:   fmt.Sprintf("Retry of %s has been queued for processing", jobID),
+		PollURL:   fmt.Sprintf("/api/v1/search/jobs/%s", newJobID),
+		StreamURL: fmt.Sprintf("/api/v1/search/jobs/%s/stream", newJobID),
+		CreatedAt: job.CreatedAt.Format(time.RFC3339),
+	}
+	response.Accepted(c, data)
+}
+
+// ListSearchJobs returns a paginated, filtered view of search_jobs history.
+// @Summary List search job history
+// @Tags search
+// @Produce json
+// @Param status query string false "Filter by status"
+// @Param tenant query string false "Filter by tenant_id"
+// @Param since query string false "Only jobs created at or after this date (YYYY-MM-DD)"
+// @Router /search/jobs [get]
+func (sc *SearchController) ListSearchJobs(c *gin.Context) {
+	if sc.jobRepo == nil {
+		response.InternalServerError(c, "Search job history is not configured")
+		return
+	}
+
+	var filter models.SearchJobFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		response.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	filter.SetDefaults()
+
+	jobs, err := sc.jobRepo.List(c.Request.Context(), &filter)
+	if err != nil {
+		response.InternalServerError(c, "Failed to list search jobs")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	response.Paginated(c, jobs, len(jobs), page, filter.Limit)
+}
+
+// GetSearchJobLogs returns a job's durable lifecycle record together with
+// its event stream backlog, serving as this job's "logs" since
+// ProcessQuerySearchJob doesn't keep a separate free-text log per job.
+// @Summary Get a search job's lifecycle record and event history
+// @Tags search
+// @Produce json
+// @Param job_id path string true "Search job ID"
+// @Router /search/jobs/{job_id}/logs [get]
+func (sc *SearchController) GetSearchJobLogs(c *gin.Context) {
+	jobID := c.Param("job_id")
+	if jobID == "" {
+		response.BadRequest(c, "job_id parameter is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var record *models.SearchJobRecord
+	if sc.jobRepo != nil {
+		var err error
+		record, err = sc.jobRepo.Get(ctx, jobID)
+		if err != nil {
+			response.InternalServerError(c, "Failed to look up search job")
+			return
+		}
+	}
+	if record == nil {
+		response.NotFound(c, "Search job not found")
+		return
+	}
+
+	var streamEvents []events.Event
+	if sc.eventPublisher != nil {
+		if batch, err := sc.eventPublisher.Read(ctx, jobID, "0", time.Second); err == nil {
+			streamEvents = batch
+		}
+	}
+
+	response.Success(c, gin.H{"job": record, "events": streamEvents})
+}
+
+// defaultDeadLetterScanLimit bounds how many query_search_jobs.dead
+// messages ListDeadSearchJobs/ReplaySearchJob will peek at in one call.
+const defaultDeadLetterScanLimit = 500
+
+// ListDeadSearchJobs returns query search jobs sitting in
+// query_search_jobs.dead after exhausting mq.DefaultRetryTiers, decoding
+// each message's body so a caller sees job_id/query instead of a raw AMQP
+// payload.
+// @Summary List dead-lettered search jobs
+// @Tags search
+// @Produce json
+// @Param limit query int false "Max messages to peek at (default 50)"
+// @Router /search/jobs/dead [get]
+func (sc *SearchController) ListDeadSearchJobs(c *gin.Context) {
+	limit := 50
+	if raw := c.Query("limit"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	messages, err := mq.ListDeadLetters(mq.MQChannel, "query_search_jobs.dead", limit)
+	if err != nil {
+		response.InternalServerError(c, "Failed to list dead search jobs")
+		return
+	}
+
+	jobs := make([]gin.H, 0, len(messages))
+	for _, m := range messages {
+		var job models.QuerySearchJob
+		_ = json.Unmarshal([]byte(m.Body), &job)
+		jobs = append(jobs, gin.H{
+			"job_id":      job.JobID,
+			"query":       job.Query,
+			"retry_count": m.RetryCount,
+			"last_error":  m.LastError,
+		})
+	}
+
+	response.Success(c, jobs)
+}
+
+// ReplaySearchJob finds jobID in query_search_jobs.dead and republishes it
+// onto query_search_jobs with a fresh retry count, the per-job counterpart
+// to the generic admin.POST("/dead-letters/:queue/requeue") endpoint (which
+// just pops whatever message is next).
+// @Summary Replay a dead-lettered search job
+// @Tags search
+// @Produce json
+// @Param id path string true "Search job ID"
+// @Router /search/jobs/dead/{id}/replay [post]
+func (sc *SearchController) ReplaySearchJob(c *gin.Context) {
+	jobID := c.Param("id")
+	if jobID == "" {
+		response.BadRequest(c, "id parameter is required")
+		return
+	}
+
+	replayed, err := mq.ReplayDeadLetterByJobID(mq.MQChannel, "query_search_jobs.dead", "query_search_jobs", jobID, defaultDeadLetterScanLimit)
+	if err != nil {
+		response.InternalServerError(c, "Failed to replay search job")
+		return
+	}
+	if !replayed {
+		response.NotFound(c, "Search job not found in the dead-letter queue")
+		return
+	}
+
+	response.Accepted(c, gin.H{"job_id": jobID, "status": "replayed"})
+}
+
 func (sc *SearchController) HealthCheck(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -195,19 +697,23 @@ func (sc *SearchController) HealthCheck(c *gin.Context) {
 		"timestamp": time.Now().Format(time.RFC3339),
 	}
 
+	redisStart := time.Now()
 	if err := redis.HealthCheck(ctx); err != nil {
 		health["redis_status"] = "unhealthy"
 		health["redis_error"] = err.Error()
 		health["status"] = "degraded"
 	} else {
 		health["redis_status"] = "healthy"
+		health["redis_latency_ms"] = time.Since(redisStart).Milliseconds()
 	}
 
+	rabbitmqStart := time.Now()
 	if mq.MQChannel == nil || mq.MQChannel.IsClosed() {
 		health["rabbitmq_status"] = "unhealthy"
 		health["status"] = "degraded"
 	} else {
 		health["rabbitmq_status"] = "healthy"
+		health["rabbitmq_latency_ms"] = time.Since(rabbitmqStart).Milliseconds()
 	}
 
 	statusCode := http.StatusOK
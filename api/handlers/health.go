@@ -10,10 +10,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"rec-mind/internal/database"
+	"rec-mind/internal/health"
 	"rec-mind/internal/redis"
 	"rec-mind/mq"
+	"rec-mind/pkg/pythonclient"
 )
 
+// defaultCheckCacheTTL bounds how often k8s hitting /readyz or /startupz
+// every second can actually reach Postgres/Redis/RabbitMQ.
+const defaultCheckCacheTTL = 2 * time.Second
+
 type HealthResponse struct {
 	Service      string                 `json:"service"`
 	Status       string                 `json:"status"`
@@ -93,18 +99,28 @@ func SystemHealth(db *database.DB, startTime time.Time) gin.HandlerFunc {
 		// Check Python ML Service
 		mlStart := time.Now()
 		pythonHealth := checkPythonHealthInternal()
+		breakerStats := pythonclient.AggregateStats()
+		breakerByEndpoint := pythonclient.StatsByEndpoint()
 		if !pythonHealth.PythonServiceReachable {
-			dependencies["python_ml_service"] = DependencyStatus{
-				Status: "unhealthy",
-				Error:  stringPtr(pythonHealth.Error),
+			dependencies["python_ml_service"] = map[string]interface{}{
+				"status":               "unhealthy",
+				"error":                pythonHealth.Error,
+				"breaker_state":        breakerStats.State,
+				"consecutive_failures": breakerStats.ConsecutiveFailures,
+				"last_transition":      breakerStats.LastTransition.Format(time.RFC3339),
+				"breaker_by_endpoint":  breakerByEndpoint,
 			}
 			overallHealthy = false
 		} else {
 			mlTime := time.Since(mlStart)
 			dependencies["python_ml_service"] = map[string]interface{}{
-				"status":        "healthy",
-				"response_time": mlTime.String(),
-				"response":      pythonHealth.PythonResponse,
+				"status":               "healthy",
+				"response_time":        mlTime.String(),
+				"response":             pythonHealth.PythonResponse,
+				"breaker_state":        breakerStats.State,
+				"consecutive_failures": breakerStats.ConsecutiveFailures,
+				"last_transition":      breakerStats.LastTransition.Format(time.RFC3339),
+				"breaker_by_endpoint":  breakerByEndpoint,
 			}
 		}
 
@@ -228,4 +244,138 @@ func checkQueryRAGWorkerHealth() RAGWorkerHealthResponse {
 // stringPtr returns a pointer to a string
 func stringPtr(s string) *string {
 	return &s
+}
+
+// NewDependencyRegistry builds the HealthRegistry backing /healthz, /readyz
+// and /startupz. Postgres, Redis and RabbitMQ are registered as critical
+// (a failure fails readiness); the Python ML service is non-critical so the
+// pod stays in-rotation in a "degraded" state while it recovers.
+func NewDependencyRegistry(db *database.DB) *health.Registry {
+	registry := health.NewRegistry(defaultCheckCacheTTL)
+
+	registry.Register(health.Check{
+		Name:     "database",
+		Critical: true,
+		Timeout:  3 * time.Second,
+		Check: func(ctx context.Context) error {
+			return db.Pool.Ping(ctx)
+		},
+	})
+
+	registry.Register(health.Check{
+		Name:     "redis",
+		Critical: true,
+		Timeout:  3 * time.Second,
+		Check: func(ctx context.Context) error {
+			return redis.HealthCheck(ctx)
+		},
+	})
+
+	registry.Register(health.Check{
+		Name:     "rabbitmq",
+		Critical: true,
+		Timeout:  2 * time.Second,
+		Check: func(ctx context.Context) error {
+			if mq.MQChannel == nil || mq.MQChannel.IsClosed() {
+				return fmt.Errorf("connection closed or not initialized")
+			}
+			return nil
+		},
+	})
+
+	registry.Register(health.Check{
+		Name:     "python_ml_service",
+		Critical: false,
+		Timeout:  5 * time.Second,
+		Check: func(ctx context.Context) error {
+			result := checkPythonHealthInternal()
+			if !result.PythonServiceReachable {
+				return fmt.Errorf("%s", result.Error)
+			}
+			return nil
+		},
+	})
+
+	return registry
+}
+
+// Livez is the liveness probe: it never touches downstreams, only confirms
+// the process itself is up and able to serve HTTP.
+// @Summary Liveness probe
+// @Description Cheap check that the process is alive; never touches downstream dependencies
+// @Tags health
+// @Produce json
+// @Success 200 {object} object{status=string}
+// @Router /healthz [get]
+func Livez() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "alive"})
+	}
+}
+
+// Readyz is the readiness probe: it gates traffic on the registry's critical
+// checks while surfacing non-critical failures as "degraded" without taking
+// the pod out of rotation.
+// @Summary Readiness probe
+// @Description Returns 503 only if a critical dependency (Postgres, Redis, RabbitMQ) is unhealthy
+// @Tags health
+// @Produce json
+// @Success 200 {object} object{status=string,checks=[]health.CheckResult}
+// @Success 503 {object} object{status=string,checks=[]health.CheckResult}
+// @Router /readyz [get]
+func Readyz(registry *health.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		results := registry.Run(ctx)
+		criticalFailure, degraded := health.Summarize(results)
+
+		status := "ready"
+		statusCode := http.StatusOK
+		switch {
+		case criticalFailure:
+			status = "not_ready"
+			statusCode = http.StatusServiceUnavailable
+		case degraded:
+			status = "degraded"
+		}
+
+		c.JSON(statusCode, gin.H{
+			"status": status,
+			"checks": results,
+		})
+	}
+}
+
+// Startupz is the startup probe: it reuses the readiness checks but exists
+// as a distinct route so the orchestrator can allow a longer grace period
+// during warm-up before liveness checks start counting failures.
+// @Summary Startup probe
+// @Description Allows slow warm-up before liveness checks begin counting failures
+// @Tags health
+// @Produce json
+// @Success 200 {object} object{status=string,checks=[]health.CheckResult}
+// @Success 503 {object} object{status=string,checks=[]health.CheckResult}
+// @Router /startupz [get]
+func Startupz(registry *health.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		results := registry.Run(ctx)
+		criticalFailure, _ := health.Summarize(results)
+
+		status := "started"
+		statusCode := http.StatusOK
+		if criticalFailure {
+			status = "starting"
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		c.JSON(statusCode, gin.H{
+			"status": status,
+			"checks": results,
+		})
+	}
 }
\ No newline at end of file
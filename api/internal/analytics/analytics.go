@@ -0,0 +1,57 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"rec-mind/config"
+	"rec-mind/internal/logging"
+)
+
+var analyticsLog = logging.New("analytics")
+
+// QueryEventFilter selects a slice of query_events for GET
+// /api/analytics/queries. Status is ignored when empty.
+type QueryEventFilter struct {
+	Since  time.Time
+	Status string
+	Limit  int
+}
+
+// Analytics records query/feed events and serves them back for aggregate
+// stats. Index is fire-and-forget: a dropped or failed write is logged,
+// not returned, so a slow or unreachable analytics store never blocks the
+// request path that's indexing it (the same tradeoff articleRepository
+// makes for its best-effort search index updates).
+type Analytics interface {
+	Index(event Event)
+	SearchQueryEvents(ctx context.Context, filter QueryEventFilter) ([]QueryEvent, error)
+	Close()
+}
+
+// New selects an Analytics implementation for cfg.Backend. "timescale" and
+// "postgres" share the same pgAnalytics writer - the backend only changes
+// whether migration 0007 made query_events/feed_events hypertables, which
+// is invisible to this process.
+func New(cfg config.AnalyticsConfig, db *pgxpool.Pool) Analytics {
+	switch cfg.Backend {
+	case config.AnalyticsBackendTimescale, config.AnalyticsBackendPostgres:
+		return newPGAnalytics(db)
+	default:
+		return noopAnalytics{}
+	}
+}
+
+// noopAnalytics backs config.AnalyticsBackendNone so callers can always
+// hold an Analytics without a nil check.
+type noopAnalytics struct{}
+
+func (noopAnalytics) Index(Event) {}
+
+func (noopAnalytics) SearchQueryEvents(context.Context, QueryEventFilter) ([]QueryEvent, error) {
+	return []QueryEvent{}, nil
+}
+
+func (noopAnalytics) Close() {}
@@ -0,0 +1,118 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rec-mind/config"
+	"rec-mind/internal/database"
+)
+
+func setupTestDB(t *testing.T) *database.DB {
+	cfg := &config.DatabaseConfig{
+		Host:           "localhost",
+		Port:           5431,
+		Name:           "postgres",
+		User:           "postgres",
+		Password:       "secret",
+		SSLMode:        "disable",
+		MaxConnections: 5,
+		MaxIdleTime:    15 * time.Minute,
+	}
+
+	db, err := database.NewConnection(cfg)
+	if err != nil {
+		t.Skipf("Skipping test: PostgreSQL not available (%v)", err)
+		return nil
+	}
+
+	ctx := context.Background()
+	_, err = db.Pool.Exec(ctx, `
+		DROP TABLE IF EXISTS query_events CASCADE;
+		CREATE TABLE query_events (
+			job_id VARCHAR(255) NOT NULL,
+			query TEXT NOT NULL,
+			status VARCHAR(50) NOT NULL,
+			matched_chunks INT NOT NULL DEFAULT 0,
+			max_hybrid_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+			avg_hybrid_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+			latency_ms BIGINT NOT NULL DEFAULT 0,
+			error_code TEXT,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		);
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create test table: %v", err)
+	}
+
+	return db
+}
+
+func cleanupTestDB(t *testing.T, db *database.DB) {
+	if db == nil {
+		return
+	}
+	ctx := context.Background()
+	if _, err := db.Pool.Exec(ctx, "DROP TABLE IF EXISTS query_events CASCADE"); err != nil {
+		t.Logf("Warning: Failed to cleanup test data: %v", err)
+	}
+	db.Close()
+}
+
+func TestPGAnalytics_IndexAndSearchQueryEvents(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer cleanupTestDB(t, db)
+
+	a := newPGAnalytics(db.Pool)
+
+	a.Index(QueryEvent{
+		JobID:          "job-1",
+		Query:          "golang concurrency",
+		Status:         "completed",
+		MatchedChunks:  3,
+		MaxHybridScore: 0.9,
+		AvgHybridScore: 0.7,
+		LatencyMS:      120,
+		CreatedAt:      time.Now(),
+	})
+
+	// Force a flush rather than waiting for flushInterval.
+	a.Close()
+
+	events, err := newPGAnalytics(db.Pool).SearchQueryEvents(context.Background(), QueryEventFilter{
+		Since: time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "job-1", events[0].JobID)
+	assert.Equal(t, "completed", events[0].Status)
+	assert.Equal(t, 3, events[0].MatchedChunks)
+}
+
+func TestPGAnalytics_SearchQueryEvents_FiltersByStatus(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer cleanupTestDB(t, db)
+
+	a := newPGAnalytics(db.Pool)
+	a.Index(QueryEvent{JobID: "job-ok", Query: "q", Status: "completed", CreatedAt: time.Now()})
+	a.Index(QueryEvent{JobID: "job-err", Query: "q", Status: "failed", CreatedAt: time.Now()})
+	a.Close()
+
+	events, err := newPGAnalytics(db.Pool).SearchQueryEvents(context.Background(), QueryEventFilter{
+		Since:  time.Now().Add(-time.Hour),
+		Status: "failed",
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "job-err", events[0].JobID)
+}
@@ -0,0 +1,69 @@
+package analytics
+
+import "time"
+
+// Event is implemented by every event type the writer can batch, so
+// Analytics.Index can accept either one through a single entry point
+// (the shape metal-stack's TimescaleDB audit backend uses) while each
+// event still controls its own destination table and column order.
+type Event interface {
+	tableName() string
+	columns() []string
+	row() []interface{}
+}
+
+// QueryEvent records one QuerySearchJob outcome for the query_events
+// hypertable.
+type QueryEvent struct {
+	JobID          string
+	Query          string
+	Status         string
+	MatchedChunks  int
+	MaxHybridScore float64
+	AvgHybridScore float64
+	LatencyMS      int64
+	ErrorCode      string
+	CreatedAt      time.Time
+}
+
+func (e QueryEvent) tableName() string { return "query_events" }
+
+func (e QueryEvent) columns() []string {
+	return []string{"job_id", "query", "status", "matched_chunks", "max_hybrid_score", "avg_hybrid_score", "latency_ms", "error_code", "created_at"}
+}
+
+func (e QueryEvent) row() []interface{} {
+	return []interface{}{e.JobID, e.Query, e.Status, e.MatchedChunks, e.MaxHybridScore, e.AvgHybridScore, e.LatencyMS, nullableString(e.ErrorCode), e.CreatedAt}
+}
+
+// FeedEvent records one scraper FeedScrapingResult for the feed_events
+// hypertable.
+type FeedEvent struct {
+	FeedName         string
+	Category         string
+	ArticlesFound    int
+	ArticlesSaved    int
+	ArticlesSkipped  int
+	ErrorCount       int
+	ProcessingTimeMS int64
+	CreatedAt        time.Time
+}
+
+func (e FeedEvent) tableName() string { return "feed_events" }
+
+func (e FeedEvent) columns() []string {
+	return []string{"feed_name", "category", "articles_found", "articles_saved", "articles_skipped", "error_count", "processing_time_ms", "created_at"}
+}
+
+func (e FeedEvent) row() []interface{} {
+	return []interface{}{e.FeedName, e.Category, e.ArticlesFound, e.ArticlesSaved, e.ArticlesSkipped, e.ErrorCount, e.ProcessingTimeMS, e.CreatedAt}
+}
+
+// nullableString turns an empty string into a nil parameter, so an unset
+// ErrorCode lands in Postgres as NULL rather than "".
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,173 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// flushInterval and flushBatchSize bound how long an event waits in
+// pgAnalytics.events before it's COPY-batched into Postgres: whichever
+// condition hits first triggers a flush.
+const (
+	flushInterval  = 2 * time.Second
+	flushBatchSize = 500
+
+	// eventQueueSize is events's buffer; Index drops and logs rather than
+	// blocking its caller once it's full, so a Postgres slowdown can't
+	// stall query processing.
+	eventQueueSize = flushBatchSize * 2
+)
+
+type pgAnalytics struct {
+	db     *pgxpool.Pool
+	events chan Event
+	wg     sync.WaitGroup
+}
+
+func newPGAnalytics(db *pgxpool.Pool) *pgAnalytics {
+	a := &pgAnalytics{
+		db:     db,
+		events: make(chan Event, eventQueueSize),
+	}
+	a.wg.Add(1)
+	go a.run()
+	return a
+}
+
+func (a *pgAnalytics) Index(event Event) {
+	select {
+	case a.events <- event:
+	default:
+		analyticsLog.Warn("dropping analytics event: writer queue full", "table", event.tableName())
+	}
+}
+
+// run drains events into a batch, flushing on flushBatchSize or
+// flushInterval, and does one final flush after events is closed so
+// Close doesn't lose whatever was queued.
+func (a *pgAnalytics) run() {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, flushBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		a.writeBatch(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case event, ok := <-a.events:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= flushBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// writeBatch groups batch by destination table and CopyFrom's each group
+// in one round-trip, since a single batch can hold both QueryEvents and
+// FeedEvents.
+func (a *pgAnalytics) writeBatch(batch []Event) {
+	byTable := make(map[string][]Event)
+	for _, event := range batch {
+		byTable[event.tableName()] = append(byTable[event.tableName()], event)
+	}
+
+	ctx := context.Background()
+	for table, events := range byTable {
+		rows := make([][]interface{}, len(events))
+		for i, event := range events {
+			rows[i] = event.row()
+		}
+
+		_, err := a.db.CopyFrom(ctx, pgx.Identifier{table}, events[0].columns(), pgx.CopyFromRows(rows))
+		if err != nil {
+			analyticsLog.Error("failed to write analytics batch", "table", table, "count", len(events), "error", err)
+		}
+	}
+}
+
+func (a *pgAnalytics) Close() {
+	close(a.events)
+	a.wg.Wait()
+}
+
+func (a *pgAnalytics) SearchQueryEvents(ctx context.Context, filter QueryEventFilter) ([]QueryEvent, error) {
+	query := `
+		SELECT job_id, query, status, matched_chunks, max_hybrid_score, avg_hybrid_score, latency_ms, error_code, created_at
+		FROM query_events`
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argIndex))
+		args = append(args, filter.Since)
+		argIndex++
+	}
+
+	if filter.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, filter.Status)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query += fmt.Sprintf(" LIMIT $%d", argIndex)
+	args = append(args, limit)
+
+	rows, err := a.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search query events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []QueryEvent
+	for rows.Next() {
+		var event QueryEvent
+		var errorCode *string
+		if err := rows.Scan(&event.JobID, &event.Query, &event.Status, &event.MatchedChunks,
+			&event.MaxHybridScore, &event.AvgHybridScore, &event.LatencyMS, &errorCode, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan query event: %w", err)
+		}
+		if errorCode != nil {
+			event.ErrorCode = *errorCode
+		}
+		events = append(events, event)
+	}
+
+	if events == nil {
+		events = []QueryEvent{}
+	}
+
+	return events, nil
+}
@@ -6,12 +6,19 @@ import (
 	"log"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/owenHochwald/rec-mind-api/config"
+
+	"rec-mind/config"
 )
 
 type DB struct {
 	Pool *pgxpool.Pool
+
+	// StmtCache amortizes prepare cost for callers that run the same query
+	// repeatedly against one connection, e.g. BulkArticleIngestor's
+	// per-article fallback upsert path.
+	StmtCache *StmtCache
 }
 
 func NewConnection(cfg *config.DatabaseConfig) (*DB, error) {
@@ -24,6 +31,14 @@ func NewConnection(cfg *config.DatabaseConfig) (*DB, error) {
 	poolConfig.MaxConnIdleTime = cfg.MaxIdleTime
 	poolConfig.HealthCheckPeriod = 1 * time.Minute
 
+	// ArticleRepository's List/Count build their WHERE clause with squirrel,
+	// so the same filter combination produces identical SQL
+	// text across calls. QueryExecModeCacheStatement (pgx's default, set
+	// explicitly here so it isn't lost if someone changes it upstream) has
+	// each connection prepare and cache a statement per distinct SQL text
+	// it sees, so repeated List/Count calls stop re-parsing their query.
+	poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -39,7 +54,7 @@ func NewConnection(cfg *config.DatabaseConfig) (*DB, error) {
 
 	log.Printf("✅ Database connection established (Max Connections: %d)", cfg.MaxConnections)
 
-	return &DB{Pool: pool}, nil
+	return &DB{Pool: pool, StmtCache: NewStmtCache()}, nil
 }
 
 func (db *DB) Close() {
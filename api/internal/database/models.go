@@ -15,7 +15,24 @@ type UpdateArticleRequest = models.UpdateArticleRequest
 type CreateArticleChunkRequest = models.CreateArticleChunkRequest
 type UpdateArticleChunkRequest = models.UpdateArticleChunkRequest
 type ArticleFilter = models.ArticleFilter
+type ArticleSortBy = models.ArticleSortBy
+type ArticleSortDir = models.ArticleSortDir
+
+const (
+	SortByPublishedAt = models.SortByPublishedAt
+	SortByCreatedAt   = models.SortByCreatedAt
+	SortByTitle       = models.SortByTitle
+	SortAsc           = models.SortAsc
+	SortDesc          = models.SortDesc
+)
 type ArticleChunkFilter = models.ArticleChunkFilter
+type ArticleChunkSearchFilter = models.ArticleChunkSearchFilter
+type ArticleChunkMatch = models.ArticleChunkMatch
+
+const (
+	DefaultSearchTopK        = models.DefaultSearchTopK
+	DefaultHybridSearchAlpha = models.DefaultHybridSearchAlpha
+)
 
 // Search models
 type QuerySearchJob = models.QuerySearchJob
@@ -27,10 +44,23 @@ type QueryRecommendationResult = models.QueryRecommendationResult
 
 // Recommendation models
 type RecommendationJob = models.RecommendationJob
+type AggregationMode = models.AggregationMode
+
+const (
+	AggregationHybrid = models.AggregationHybrid
+	AggregationRRF    = models.AggregationRRF
+
+	DefaultMMRLambda      = models.DefaultMMRLambda
+	DefaultMMRResultCount = models.DefaultMMRResultCount
+
+	DefaultRerankWeight = models.DefaultRerankWeight
+)
+
 type ChunkSearchMessage = models.ChunkSearchMessage
 type ChunkSearchResult = models.ChunkSearchResult
 type ChunkSearchResponse = models.ChunkSearchResponse
 type ChunkSearchError = models.ChunkSearchError
+type SearchCancelMessage = models.SearchCancelMessage
 type ChunkMatch = models.ChunkMatch
 type ArticleRecommendation = models.ArticleRecommendation
 type RecommendationResult = models.RecommendationResult
\ No newline at end of file
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// StmtCache maps SQL text to a prepared-statement name, so repeated calls
+// with the same query reuse pgx's parsed/planned statement instead of
+// re-preparing it every time — the same amortization ClusterCockpit's
+// JobRepository gets from squirrel.StmtCache, done by hand here over a
+// raw *pgx.Conn rather than through squirrel. Prepared statements are scoped to a
+// single connection, so callers must prepare and execute on the same
+// *pgx.Conn (e.g. one acquired from a pgxpool.Pool for a batch of calls).
+type StmtCache struct {
+	mu    sync.Mutex
+	names map[string]string
+}
+
+// NewStmtCache returns an empty StmtCache.
+func NewStmtCache() *StmtCache {
+	return &StmtCache{names: make(map[string]string)}
+}
+
+// Prepare returns the prepared-statement name for sql on conn, preparing
+// it the first time this sql text is seen on conn.
+func (c *StmtCache) Prepare(ctx context.Context, conn *pgx.Conn, sql string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name, ok := c.names[sql]
+	if !ok {
+		name = fmt.Sprintf("stmt_%x", sha1.Sum([]byte(sql)))
+	}
+
+	// pgx's Prepare returns at once when name is already prepared with this
+	// sql on conn, so only a connection's first call reaches the server.
+	if _, err := conn.Prepare(ctx, name, sql); err != nil {
+		return "", fmt.Errorf("failed to prepare statement: %w", err)
+	}
+
+	c.names[sql] = name
+	return name, nil
+}
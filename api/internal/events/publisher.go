@@ -0,0 +1,171 @@
+// Package events publishes and replays per-entity progress (article
+// chunking/embedding/upload, search job retrieval/generation) over a Redis
+// Stream so HTTP clients can subscribe via SSE and resume from any point
+// using Last-Event-ID. Callers get their own namespace via NewPublisher so
+// two unrelated event streams (e.g. "article_events" and "search_jobs")
+// never collide on the same Redis keys.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Stage names published over the course of processing an uploaded article.
+const (
+	StageChunkingStarted = "chunking_started"
+	StageChunksCreated   = "chunks_created"
+	StageEmbeddingBatch  = "embedding_batch"
+	StageVectorsUploaded = "vectors_uploaded"
+	StageCompleted       = "completed"
+	StageFailed          = "failed"
+)
+
+// Stage names published over the course of processing a query search job;
+// shares StageCompleted/StageFailed above since both lifecycles end the
+// same way.
+const (
+	StageQueued     = "queued"
+	StageRetrieving = "retrieving"
+	// StageCancelled marks a query search job that was stopped early via
+	// SearchController.CancelSearchJob's Redis cancel flag.
+	StageCancelled = "cancelled"
+)
+
+// terminalStages mark the end of an article's processing lifecycle; once
+// one is published it's cached so late subscribers get the outcome
+// immediately instead of waiting on a stream that will never advance again.
+var terminalStages = map[string]bool{
+	StageCompleted: true,
+	StageFailed:    true,
+	StageCancelled: true,
+}
+
+// backlogLimit bounds how many events the stream retains per article so a
+// reconnecting client can replay history without the stream growing forever.
+const backlogLimit = 200
+
+// terminalCacheTTL is how long a terminal event stays available to late
+// subscribers after processing finishes.
+const terminalCacheTTL = 24 * time.Hour
+
+func (p *Publisher) streamKey(id string) string {
+	return fmt.Sprintf("%s:%s", p.namespace, id)
+}
+
+func (p *Publisher) terminalKey(id string) string {
+	return fmt.Sprintf("%s:%s:terminal", p.namespace, id)
+}
+
+// Event is a single processing progress update.
+type Event struct {
+	ID        string                 `json:"id,omitempty"`
+	Stage     string                 `json:"stage"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// Publisher appends Events to an entity's stream (and caches terminal ones),
+// scoped under namespace so different kinds of progress (article processing,
+// search job retrieval) don't share Redis keys.
+type Publisher struct {
+	redis     *goredis.Client
+	namespace string
+}
+
+// NewPublisher creates a Publisher backed by the given Redis client, with
+// every key it touches prefixed by namespace (e.g. "article_events",
+// "search_jobs").
+func NewPublisher(redisClient *goredis.Client, namespace string) *Publisher {
+	return &Publisher{redis: redisClient, namespace: namespace}
+}
+
+// Publish appends an event to the article's stream, trimming old entries
+// beyond backlogLimit, and caches it as the terminal state if the stage is
+// completed/failed/cancelled.
+func (p *Publisher) Publish(ctx context.Context, articleID, stage string, data map[string]interface{}) error {
+	event := Event{
+		Stage:     stage,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	id, err := p.redis.XAdd(ctx, &goredis.XAddArgs{
+		Stream: p.streamKey(articleID),
+		MaxLen: backlogLimit,
+		Approx: true,
+		Values: map[string]interface{}{"payload": payload},
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	if terminalStages[stage] {
+		event.ID = id
+		terminalPayload, _ := json.Marshal(event)
+		if err := p.redis.Set(ctx, p.terminalKey(articleID), terminalPayload, terminalCacheTTL).Err(); err != nil {
+			return fmt.Errorf("failed to cache terminal event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Terminal returns the cached terminal event for articleID, if processing
+// has already finished.
+func (p *Publisher) Terminal(ctx context.Context, articleID string) (*Event, bool) {
+	raw, err := p.redis.Get(ctx, p.terminalKey(articleID)).Result()
+	if err != nil {
+		return nil, false
+	}
+
+	var event Event
+	if err := json.Unmarshal([]byte(raw), &event); err != nil {
+		return nil, false
+	}
+	return &event, true
+}
+
+// Read blocks for up to timeout waiting for events after lastID ("0" for
+// full backlog, "$" for only new events) and returns whatever arrived.
+func (p *Publisher) Read(ctx context.Context, articleID, lastID string, timeout time.Duration) ([]Event, error) {
+	streams, err := p.redis.XRead(ctx, &goredis.XReadArgs{
+		Streams: []string{p.streamKey(articleID), lastID},
+		Block:   timeout,
+		Count:   50,
+	}).Result()
+
+	if err != nil {
+		if err == goredis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+
+	var events []Event
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			payload, ok := msg.Values["payload"].(string)
+			if !ok {
+				continue
+			}
+			var event Event
+			if err := json.Unmarshal([]byte(payload), &event); err != nil {
+				continue
+			}
+			event.ID = msg.ID
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
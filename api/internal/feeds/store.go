@@ -0,0 +1,125 @@
+// Package feeds persists dynamic RSS feed configuration in Redis so feeds
+// can be added, disabled, or re-scheduled without a redeploy, mirroring how
+// internal/uploads persists resumable upload sessions.
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"encoding/json"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"rec-mind/models"
+)
+
+// indexKey names the Redis set tracking every known feed slug, since Redis
+// offers no efficient "list keys matching feeds:*" primitive safe to run
+// against a large keyspace.
+const indexKey = "feeds:index"
+
+func feedKey(slug string) string {
+	return fmt.Sprintf("feeds:%s", slug)
+}
+
+// Store reads and writes FeedConfig state in Redis.
+type Store struct {
+	redis *goredis.Client
+}
+
+// NewStore creates a Store backed by the given Redis client.
+func NewStore(redisClient *goredis.Client) *Store {
+	return &Store{redis: redisClient}
+}
+
+// Create persists a brand-new feed config. Returns an error if slug is
+// already registered.
+func (s *Store) Create(ctx context.Context, feed *models.FeedConfig) error {
+	exists, err := s.redis.SIsMember(ctx, indexKey, feed.Slug).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check feed index: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("feed %q already exists", feed.Slug)
+	}
+
+	now := time.Now()
+	feed.CreatedAt = now
+	feed.UpdatedAt = now
+
+	if err := s.save(ctx, feed); err != nil {
+		return err
+	}
+	if err := s.redis.SAdd(ctx, indexKey, feed.Slug).Err(); err != nil {
+		return fmt.Errorf("failed to index feed %q: %w", feed.Slug, err)
+	}
+	return nil
+}
+
+// Get fetches a feed config by slug. Returns nil, nil if it doesn't exist.
+func (s *Store) Get(ctx context.Context, slug string) (*models.FeedConfig, error) {
+	raw, err := s.redis.Get(ctx, feedKey(slug)).Result()
+	if err != nil {
+		if err == goredis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch feed %q: %w", slug, err)
+	}
+
+	var feed models.FeedConfig
+	if err := json.Unmarshal([]byte(raw), &feed); err != nil {
+		return nil, fmt.Errorf("failed to parse feed %q: %w", slug, err)
+	}
+	return &feed, nil
+}
+
+// List returns every registered feed config, in no particular order.
+func (s *Store) List(ctx context.Context) ([]*models.FeedConfig, error) {
+	slugs, err := s.redis.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feed index: %w", err)
+	}
+
+	feeds := make([]*models.FeedConfig, 0, len(slugs))
+	for _, slug := range slugs {
+		feed, err := s.Get(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+		if feed == nil {
+			// Index and key drifted apart (e.g. the key expired or was
+			// deleted out of band); drop the stale slug rather than fail
+			// the whole listing.
+			s.redis.SRem(ctx, indexKey, slug)
+			continue
+		}
+		feeds = append(feeds, feed)
+	}
+	return feeds, nil
+}
+
+// Update overwrites a feed's stored config, bumping UpdatedAt.
+func (s *Store) Update(ctx context.Context, feed *models.FeedConfig) error {
+	feed.UpdatedAt = time.Now()
+	return s.save(ctx, feed)
+}
+
+// Delete removes a feed config and its index entry.
+func (s *Store) Delete(ctx context.Context, slug string) error {
+	if err := s.redis.Del(ctx, feedKey(slug)).Err(); err != nil {
+		return fmt.Errorf("failed to delete feed %q: %w", slug, err)
+	}
+	return s.redis.SRem(ctx, indexKey, slug).Err()
+}
+
+func (s *Store) save(ctx context.Context, feed *models.FeedConfig) error {
+	body, err := json.Marshal(feed)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feed %q: %w", feed.Slug, err)
+	}
+	if err := s.redis.Set(ctx, feedKey(feed.Slug), body, 0).Err(); err != nil {
+		return fmt.Errorf("failed to store feed %q: %w", feed.Slug, err)
+	}
+	return nil
+}
@@ -15,6 +15,18 @@ type DatabaseHealth struct {
 	Error       string                 `json:"error,omitempty"`
 }
 
+// articleCacheStatsProvider, when set via RegisterArticleCacheStatsProvider,
+// is merged into DatabaseHealth.Stats under "article_cache" on every
+// CheckDatabase call.
+var articleCacheStatsProvider func() interface{}
+
+// RegisterArticleCacheStatsProvider wires articleRepository's cache stats
+// into /health's database payload. Called once from routes.SetupRoutes,
+// since health has no other way to reach a repository's internal cache.
+func RegisterArticleCacheStatsProvider(provider func() interface{}) {
+	articleCacheStatsProvider = provider
+}
+
 type TableHealth struct {
 	Articles      *TableInfo `json:"articles"`
 	ArticleChunks *TableInfo `json:"article_chunks"`
@@ -34,6 +46,10 @@ func CheckDatabase(db *database.DB) *DatabaseHealth {
 		Stats:  db.GetStats(),
 	}
 
+	if articleCacheStatsProvider != nil {
+		health.Stats["article_cache"] = articleCacheStatsProvider()
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
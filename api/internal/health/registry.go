@@ -0,0 +1,144 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	checksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rec_mind_health_check_total",
+		Help: "Total number of health checks run, labeled by check name and result",
+	}, []string{"check", "result"})
+)
+
+// CheckFunc performs a single dependency check and returns an error if unhealthy.
+type CheckFunc func(ctx context.Context) error
+
+// Check describes a single registered dependency probe.
+type Check struct {
+	Name     string
+	Critical bool
+	Timeout  time.Duration
+	Check    CheckFunc
+}
+
+// CheckResult is the outcome of running (or reusing the cached result of) a Check.
+type CheckResult struct {
+	Name      string    `json:"name"`
+	Healthy   bool      `json:"healthy"`
+	Critical  bool      `json:"critical"`
+	Error     string    `json:"error,omitempty"`
+	Cached    bool      `json:"cached"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type cacheEntry struct {
+	result    CheckResult
+	expiresAt time.Time
+}
+
+// Registry holds the set of registered dependency checks and caches their
+// results for cacheTTL so that probe endpoints hit by the orchestrator every
+// few seconds don't hammer the checked dependencies.
+type Registry struct {
+	mu       sync.RWMutex
+	checks   []Check
+	cache    map[string]cacheEntry
+	cacheTTL time.Duration
+}
+
+// NewRegistry creates a Registry that caches each check's result for cacheTTL.
+func NewRegistry(cacheTTL time.Duration) *Registry {
+	return &Registry{
+		cache:    make(map[string]cacheEntry),
+		cacheTTL: cacheTTL,
+	}
+}
+
+// Register adds a check to the registry. Not safe to call concurrently with Run.
+func (r *Registry) Register(c Check) {
+	if c.Timeout == 0 {
+		c.Timeout = 5 * time.Second
+	}
+	r.checks = append(r.checks, c)
+}
+
+// Run executes every registered check (using cached results where still
+// fresh) and returns one CheckResult per check, in registration order.
+func (r *Registry) Run(ctx context.Context) []CheckResult {
+	results := make([]CheckResult, len(r.checks))
+	for i, c := range r.checks {
+		results[i] = r.runOne(ctx, c)
+	}
+	return results
+}
+
+func (r *Registry) runOne(ctx context.Context, c Check) CheckResult {
+	if cached, ok := r.cached(c.Name); ok {
+		cached.Cached = true
+		return cached
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	result := CheckResult{
+		Name:      c.Name,
+		Critical:  c.Critical,
+		CheckedAt: time.Now(),
+	}
+
+	if err := c.Check(checkCtx); err != nil {
+		result.Healthy = false
+		result.Error = err.Error()
+		checksTotal.WithLabelValues(c.Name, "fail").Inc()
+	} else {
+		result.Healthy = true
+		checksTotal.WithLabelValues(c.Name, "pass").Inc()
+	}
+
+	r.store(c.Name, result)
+	return result
+}
+
+func (r *Registry) cached(name string) (CheckResult, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.cache[name]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return CheckResult{}, false
+	}
+	return entry.result, true
+}
+
+func (r *Registry) store(name string, result CheckResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cache[name] = cacheEntry{
+		result:    result,
+		expiresAt: time.Now().Add(r.cacheTTL),
+	}
+}
+
+// Summarize reports whether any critical check failed (not ready) and
+// whether any non-critical check failed (degraded but still in-rotation).
+func Summarize(results []CheckResult) (criticalFailure bool, degraded bool) {
+	for _, result := range results {
+		if result.Healthy {
+			continue
+		}
+		if result.Critical {
+			criticalFailure = true
+		} else {
+			degraded = true
+		}
+	}
+	return criticalFailure, degraded
+}
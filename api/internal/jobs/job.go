@@ -0,0 +1,112 @@
+// Package jobs is a Redis-backed durable job queue for background work that
+// must survive a process restart, replacing the fire-and-forget goroutines
+// previously used for embedding generation. A job's payload and status live
+// in a Redis hash; a sorted set scores pending/retrying jobs by their
+// next-ready time, and a ready/in-flight list pair hands work to a Pool via
+// BRPOPLPUSH the same way RabbitMQ hands deliveries to a consumer, but
+// durable in Redis instead of the broker.
+package jobs
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Status is one stage in a Job's lifecycle.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusDead      Status = "dead"
+)
+
+// Job is one unit of durable background work: Type selects the Handler a
+// Pool runs it with, Payload is the opaque JSON that Handler needs, and the
+// rest tracks retry history for /api/v1/jobs/:job_id polling.
+type Job struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      Status          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	LastError   string          `json:"last_error,omitempty"`
+	// Progress is "<done>/<total>" chunks completed so far, set by
+	// Queue.SetProgress for handlers that do their own internal chunking
+	// (e.g. ArticleService.ProcessBatchArticlesChunked) instead of
+	// succeeding or failing atomically. Empty for jobs that don't report it.
+	Progress  string    `json:"progress,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// toHash flattens a Job into the field map stored by HSet, since go-redis
+// has no direct struct-of-JSON support for hash fields.
+func (j *Job) toHash() map[string]interface{} {
+	return map[string]interface{}{
+		"id":           j.ID,
+		"type":         j.Type,
+		"payload":      string(j.Payload),
+		"status":       string(j.Status),
+		"attempts":     j.Attempts,
+		"max_attempts": j.MaxAttempts,
+		"last_error":   j.LastError,
+		"progress":     j.Progress,
+		"created_at":   j.CreatedAt.Format(time.RFC3339Nano),
+		"updated_at":   j.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+// jobFromHash rebuilds a Job from the field map returned by HGetAll. A nil
+// result (empty map) means the hash doesn't exist.
+func jobFromHash(fields map[string]string) *Job {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	attempts, _ := strconv.Atoi(fields["attempts"])
+	maxAttempts, _ := strconv.Atoi(fields["max_attempts"])
+	createdAt, _ := time.Parse(time.RFC3339Nano, fields["created_at"])
+	updatedAt, _ := time.Parse(time.RFC3339Nano, fields["updated_at"])
+
+	return &Job{
+		ID:          fields["id"],
+		Type:        fields["type"],
+		Payload:     json.RawMessage(fields["payload"]),
+		Status:      Status(fields["status"]),
+		Attempts:    attempts,
+		MaxAttempts: maxAttempts,
+		LastError:   fields["last_error"],
+		Progress:    fields["progress"],
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+	}
+}
+
+// StatusView is the shape GetJobStatus returns over HTTP, trimmed to what a
+// polling client needs. The search job status endpoint does not use it.
+type StatusView struct {
+	JobID     string `json:"job_id"`
+	Status    string `json:"status"`
+	Attempts  int    `json:"attempts"`
+	Error     string `json:"error,omitempty"`
+	Progress  string `json:"progress,omitempty"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func (j *Job) View() StatusView {
+	return StatusView{
+		JobID:     j.ID,
+		Status:    string(j.Status),
+		Attempts:  j.Attempts,
+		Error:     j.LastError,
+		Progress:  j.Progress,
+		CreatedAt: j.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: j.UpdatedAt.Format(time.RFC3339),
+	}
+}
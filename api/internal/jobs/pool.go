@@ -0,0 +1,184 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"rec-mind/internal/logging"
+)
+
+var poolLog = logging.New("jobs-pool")
+
+// Handler runs one job's payload and returns an error to trigger a retry
+// (or dead-letter once MaxAttempts is exhausted).
+type Handler func(ctx context.Context, payload []byte) error
+
+// contextKey namespaces values Pool injects into a handler's ctx, so they
+// never collide with a caller's own context.WithValue keys.
+type contextKey string
+
+const jobIDContextKey contextKey = "jobID"
+
+// JobIDFromContext returns the ID of the job currently being processed, for
+// a Handler that wants to report its own incremental progress via
+// Queue.SetProgress (e.g. a handler that chunks its own work internally)
+// instead of just succeeding or failing atomically.
+func JobIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(jobIDContextKey).(string)
+	return id, ok
+}
+
+// brpoplpushTimeout bounds how long a Pool worker blocks waiting on the
+// ready list before checking ctx again, so Stop doesn't hang on an empty
+// queue.
+const brpoplpushTimeout = 5 * time.Second
+
+// heartbeatTTL bounds how long a job may run before the Reaper considers
+// its worker dead and reclaims it; heartbeatInterval should be well under
+// this so a slow-but-alive worker keeps renewing it in time.
+const heartbeatTTL = 2 * time.Minute
+
+// heartbeatInterval is how often a Pool worker renews its in-flight job's
+// heartbeat while Handler is still running.
+const heartbeatInterval = 30 * time.Second
+
+func heartbeatKey(jobID string) string {
+	return "jobs:heartbeat:" + jobID
+}
+
+// Pool drains queue's ready list with a fixed number of goroutines, each
+// running the Handler registered for a job's Type via BRPOPLPUSH into the
+// in-flight list, heartbeating while the handler runs, and reporting the
+// outcome back to queue.
+type Pool struct {
+	queue       *Queue
+	redis       *goredis.Client
+	handlers    map[string]Handler
+	concurrency int
+}
+
+// NewPool creates a Pool over queue with concurrency workers; concurrency
+// <= 0 falls back to 1.
+func NewPool(queue *Queue, redisClient *goredis.Client, concurrency int) *Pool {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &Pool{
+		queue:       queue,
+		redis:       redisClient,
+		handlers:    make(map[string]Handler),
+		concurrency: concurrency,
+	}
+}
+
+// RegisterHandler maps jobType to handler; Start must be called after every
+// type a caller plans to enqueue has been registered.
+func (p *Pool) RegisterHandler(jobType string, handler Handler) {
+	p.handlers[jobType] = handler
+}
+
+// Start launches p.concurrency worker goroutines that run until ctx is
+// cancelled.
+func (p *Pool) Start(ctx context.Context) {
+	for i := 0; i < p.concurrency; i++ {
+		go p.runWorker(ctx)
+	}
+}
+
+func (p *Pool) runWorker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		jobID, err := p.redis.BRPopLPush(ctx, p.queue.ReadyKey(), p.queue.InflightKey(), brpoplpushTimeout).Result()
+		if err != nil {
+			if err != goredis.Nil && ctx.Err() == nil {
+				poolLog.Error("failed to pop job off ready list", "error", err)
+			}
+			continue
+		}
+
+		p.process(ctx, jobID)
+	}
+}
+
+// process runs one job end-to-end: loads it, marks it running, heartbeats
+// while Handler executes, then removes it from the in-flight list and
+// records success/retry/dead-letter.
+func (p *Pool) process(ctx context.Context, jobID string) {
+	job, err := p.queue.Get(ctx, jobID)
+	if err != nil || job == nil {
+		poolLog.Error("failed to load in-flight job, removing from list", "job_id", jobID, "error", err)
+		p.redis.LRem(ctx, p.queue.InflightKey(), 1, jobID)
+		return
+	}
+
+	if err := p.queue.markRunning(ctx, jobID); err != nil {
+		poolLog.Error("failed to mark job running", "job_id", jobID, "error", err)
+	}
+	job.Attempts++
+
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	go p.heartbeat(heartbeatCtx, jobID)
+
+	handler, ok := p.handlers[job.Type]
+	if !ok {
+		stopHeartbeat()
+		p.finish(ctx, job, fmt.Errorf("no handler registered for job type %q", job.Type))
+		return
+	}
+
+	runErr := handler(context.WithValue(ctx, jobIDContextKey, job.ID), job.Payload)
+	stopHeartbeat()
+	p.redis.Del(context.Background(), heartbeatKey(jobID))
+
+	if runErr != nil {
+		p.finish(ctx, job, runErr)
+		return
+	}
+
+	if err := p.queue.complete(ctx, jobID); err != nil {
+		poolLog.Error("failed to record job completion", "job_id", jobID, "error", err)
+	}
+	if err := p.redis.LRem(ctx, p.queue.InflightKey(), 1, jobID).Err(); err != nil {
+		poolLog.Error("failed to remove completed job from in-flight list", "job_id", jobID, "error", err)
+	}
+}
+
+// finish removes job from the in-flight list and hands it to
+// retryOrDeadLetter, called whenever a handler errors or a job can't be
+// dispatched at all (e.g. an unregistered type).
+func (p *Pool) finish(ctx context.Context, job *Job, cause error) {
+	if _, err := p.queue.retryOrDeadLetter(ctx, job, cause); err != nil {
+		poolLog.Error("failed to retry/dead-letter job", "job_id", job.ID, "error", err)
+	}
+	if err := p.redis.LRem(ctx, p.queue.InflightKey(), 1, job.ID).Err(); err != nil {
+		poolLog.Error("failed to remove failed job from in-flight list", "job_id", job.ID, "error", err)
+	}
+}
+
+// heartbeat renews jobID's heartbeat key every heartbeatInterval until ctx
+// is cancelled (handler finished or Pool is shutting down), so the Reaper
+// can tell a still-running job apart from one whose worker died mid-run.
+func (p *Pool) heartbeat(ctx context.Context, jobID string) {
+	key := heartbeatKey(jobID)
+	p.redis.Set(ctx, key, "1", heartbeatTTL)
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.redis.Set(ctx, key, "1", heartbeatTTL)
+		}
+	}
+}
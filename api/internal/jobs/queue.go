@@ -0,0 +1,250 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	"rec-mind/internal/logging"
+)
+
+var queueLog = logging.New("jobs-queue")
+
+// DefaultMaxAttempts bounds how many attempts a job gets, its first run
+// included, before it's moved to the dead-letter list, for queues that
+// don't override it.
+const DefaultMaxAttempts = 5
+
+// Redis key prefixes, all namespaced so two Queues (e.g. "embeddings" and a
+// future job type) never collide on the same keys.
+const (
+	jobHashPrefix      = "jobs:job:"
+	readyListPrefix    = "jobs:ready:"
+	inflightListPrefix = "jobs:inflight:"
+	scheduledSetPrefix = "jobs:scheduled:"
+	deadListPrefix     = "jobs:dead:"
+)
+
+// Queue is a namespaced Redis-backed durable job queue: Enqueue persists a
+// job's hash and pushes it onto the ready list; a Pool drains the ready
+// list via BRPOPLPUSH and reports success/failure back through complete/
+// retryOrDeadLetter.
+type Queue struct {
+	redis       *goredis.Client
+	namespace   string
+	maxAttempts int
+}
+
+// NewQueue creates a Queue in namespace (e.g. "embeddings"); maxAttempts
+// <= 0 falls back to DefaultMaxAttempts.
+func NewQueue(redisClient *goredis.Client, namespace string, maxAttempts int) *Queue {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	return &Queue{redis: redisClient, namespace: namespace, maxAttempts: maxAttempts}
+}
+
+func (q *Queue) jobKey(id string) string { return jobHashPrefix + q.namespace + ":" + id }
+func (q *Queue) ReadyKey() string        { return readyListPrefix + q.namespace }
+func (q *Queue) InflightKey() string     { return inflightListPrefix + q.namespace }
+func (q *Queue) scheduledKey() string    { return scheduledSetPrefix + q.namespace }
+func (q *Queue) DeadKey() string         { return deadListPrefix + q.namespace }
+
+// Enqueue persists a new job of jobType with payload marshaled to JSON and
+// pushes it onto the ready list for immediate pickup. Returns the generated
+// job ID.
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}) (string, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal job payload: %w", err)
+	}
+
+	now := time.Now()
+	job := &Job{
+		ID:          uuid.New().String(),
+		Type:        jobType,
+		Payload:     raw,
+		Status:      StatusPending,
+		MaxAttempts: q.maxAttempts,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := q.save(ctx, job); err != nil {
+		return "", err
+	}
+
+	if err := q.redis.LPush(ctx, q.ReadyKey(), job.ID).Err(); err != nil {
+		return "", fmt.Errorf("failed to push job %s onto ready list: %w", job.ID, err)
+	}
+
+	return job.ID, nil
+}
+
+func (q *Queue) save(ctx context.Context, job *Job) error {
+	if err := q.redis.HSet(ctx, q.jobKey(job.ID), job.toHash()).Err(); err != nil {
+		return fmt.Errorf("failed to save job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// Get returns jobID's current state, or nil if it doesn't exist (expired,
+// never existed, or an ID from a different namespace).
+func (q *Queue) Get(ctx context.Context, jobID string) (*Job, error) {
+	fields, err := q.redis.HGetAll(ctx, q.jobKey(jobID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %s: %w", jobID, err)
+	}
+	return jobFromHash(fields), nil
+}
+
+// markRunning bumps attempts and flips the hash to running, called once a
+// Pool worker has BRPOPLPUSHed jobID off the ready list.
+func (q *Queue) markRunning(ctx context.Context, jobID string) error {
+	return q.redis.HSet(ctx, q.jobKey(jobID), map[string]interface{}{
+		"status":     string(StatusRunning),
+		"attempts":   q.incrAttempts(ctx, jobID),
+		"updated_at": time.Now().Format(time.RFC3339Nano),
+	}).Err()
+}
+
+// incrAttempts increments and returns jobID's attempts field. Errors are
+// swallowed to a 0 delta since HIncrBy is a best-effort counter here -
+// markRunning's own HSet failure (if any) is what the caller actually
+// checks.
+func (q *Queue) incrAttempts(ctx context.Context, jobID string) int64 {
+	n, err := q.redis.HIncrBy(ctx, q.jobKey(jobID), "attempts", 1).Result()
+	if err != nil {
+		queueLog.Error("failed to increment job attempts", "job_id", jobID, "error", err)
+		return 0
+	}
+	return n
+}
+
+// SetProgress records "<done>/<total>" on jobID's hash for a handler that
+// chunks its own work internally (e.g. ArticleService.ProcessBatchArticlesChunked),
+// so a poller hitting /api/v1/jobs/:job_id sees incremental status instead
+// of just "running" until the whole job finishes. Best-effort: a failure
+// here is logged but never aborts the job itself.
+func (q *Queue) SetProgress(ctx context.Context, jobID string, done, total int) {
+	if err := q.redis.HSet(ctx, q.jobKey(jobID), map[string]interface{}{
+		"progress":   fmt.Sprintf("%d/%d", done, total),
+		"updated_at": time.Now().Format(time.RFC3339Nano),
+	}).Err(); err != nil {
+		queueLog.Error("failed to record job progress", "job_id", jobID, "error", err)
+	}
+}
+
+// complete marks jobID succeeded.
+func (q *Queue) complete(ctx context.Context, jobID string) error {
+	return q.redis.HSet(ctx, q.jobKey(jobID), map[string]interface{}{
+		"status":     string(StatusSucceeded),
+		"updated_at": time.Now().Format(time.RFC3339Nano),
+	}).Err()
+}
+
+// retryOrDeadLetter requeues jobID with exponential backoff (2^attempt
+// seconds, same doubling shape as pythonclient's jittered backoff) if it
+// hasn't exhausted maxAttempts, or moves it to the dead-letter list
+// otherwise. Returns true if it was dead-lettered.
+func (q *Queue) retryOrDeadLetter(ctx context.Context, job *Job, causeErr error) (deadLettered bool, err error) {
+	if err := q.redis.HSet(ctx, q.jobKey(job.ID), map[string]interface{}{
+		"status":     string(StatusFailed),
+		"last_error": causeErr.Error(),
+		"updated_at": time.Now().Format(time.RFC3339Nano),
+	}).Err(); err != nil {
+		return false, fmt.Errorf("failed to record failure for job %s: %w", job.ID, err)
+	}
+
+	if job.Attempts >= job.MaxAttempts {
+		if err := q.redis.HSet(ctx, q.jobKey(job.ID), "status", string(StatusDead)).Err(); err != nil {
+			return false, fmt.Errorf("failed to mark job %s dead: %w", job.ID, err)
+		}
+		if err := q.redis.LPush(ctx, q.DeadKey(), job.ID).Err(); err != nil {
+			return false, fmt.Errorf("failed to dead-letter job %s: %w", job.ID, err)
+		}
+		queueLog.Error("job exceeded max attempts, moved to dead-letter list", "job_id", job.ID, "attempts", job.Attempts, "error", causeErr)
+		return true, nil
+	}
+
+	delay := backoff(job.Attempts)
+	readyAt := float64(time.Now().Add(delay).Unix())
+	if err := q.redis.ZAdd(ctx, q.scheduledKey(), goredis.Z{Score: readyAt, Member: job.ID}).Err(); err != nil {
+		return false, fmt.Errorf("failed to schedule retry for job %s: %w", job.ID, err)
+	}
+
+	queueLog.Info("job failed, scheduled for retry", "job_id", job.ID, "attempt", job.Attempts, "retry_in", delay, "error", causeErr)
+	return false, nil
+}
+
+// backoff returns 2^attempt seconds, capped at 5 minutes - the same
+// exponential shape as DefaultRetryTiers' widening AMQP delays.
+func backoff(attempt int) time.Duration {
+	d := time.Duration(1<<uint(attempt)) * time.Second
+	const maxBackoff = 5 * time.Minute
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// PromoteDue moves every job in the scheduled set whose retry time has
+// passed onto the ready list, so a Pool worker picks it back up. Intended
+// to be called on a short ticker (see StartScheduler).
+func (q *Queue) PromoteDue(ctx context.Context) error {
+	now := float64(time.Now().Unix())
+	due, err := q.redis.ZRangeByScore(ctx, q.scheduledKey(), &goredis.ZRangeBy{
+		Min: "-inf",
+		Max: fmt.Sprintf("%f", now),
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read due jobs: %w", err)
+	}
+
+	for _, jobID := range due {
+		if err := q.redis.ZRem(ctx, q.scheduledKey(), jobID).Err(); err != nil {
+			queueLog.Error("failed to remove promoted job from scheduled set", "job_id", jobID, "error", err)
+			continue
+		}
+		if err := q.redis.LPush(ctx, q.ReadyKey(), jobID).Err(); err != nil {
+			queueLog.Error("failed to promote due job onto ready list", "job_id", jobID, "error", err)
+		}
+	}
+	return nil
+}
+
+// StartScheduler polls PromoteDue every interval until ctx is cancelled,
+// the same ticker-goroutine shape as metrics.StartQueueDepthPoller.
+func (q *Queue) StartScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := q.PromoteDue(ctx); err != nil {
+					queueLog.Error("failed to promote due jobs", "namespace", q.namespace, "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Requeue moves jobID from the in-flight list back onto the ready list
+// as-is, used by the reaper to reclaim work abandoned by a dead worker.
+func (q *Queue) Requeue(ctx context.Context, jobID string) error {
+	if err := q.redis.LRem(ctx, q.InflightKey(), 1, jobID).Err(); err != nil {
+		return fmt.Errorf("failed to remove job %s from in-flight list: %w", jobID, err)
+	}
+	if err := q.redis.LPush(ctx, q.ReadyKey(), jobID).Err(); err != nil {
+		return fmt.Errorf("failed to requeue job %s: %w", jobID, err)
+	}
+	return nil
+}
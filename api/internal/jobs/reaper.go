@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"rec-mind/internal/logging"
+)
+
+var reaperLog = logging.New("jobs-reaper")
+
+// defaultReapInterval is how often Reaper scans the in-flight list for
+// abandoned jobs when the caller doesn't override it.
+const defaultReapInterval = 30 * time.Second
+
+// Reaper periodically scans queue's in-flight list for jobs whose worker
+// heartbeat key has expired - meaning the Pool worker processing it died
+// (pod eviction, OOM kill, panic) without ever reaching complete or
+// retryOrDeadLetter - and requeues them so no work is silently lost.
+type Reaper struct {
+	queue *Queue
+	redis *goredis.Client
+}
+
+// NewReaper creates a Reaper for queue.
+func NewReaper(queue *Queue, redisClient *goredis.Client) *Reaper {
+	return &Reaper{queue: queue, redis: redisClient}
+}
+
+// Start runs Sweep every interval until ctx is cancelled; interval <= 0
+// falls back to defaultReapInterval.
+func (r *Reaper) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultReapInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.Sweep(ctx)
+			}
+		}
+	}()
+}
+
+// Sweep requeues every in-flight job whose heartbeat key is missing. A
+// LRange snapshot is used rather than tailing the list live, so a job that
+// completes normally between the snapshot and the heartbeat check is
+// simply skipped (its heartbeat key is deleted on completion, same as an
+// abandoned one - but by then it's already been LRem'd off the in-flight
+// list, so Requeue's LRem is a no-op and this is harmless).
+func (r *Reaper) Sweep(ctx context.Context) {
+	jobIDs, err := r.redis.LRange(ctx, r.queue.InflightKey(), 0, -1).Result()
+	if err != nil {
+		reaperLog.Error("failed to list in-flight jobs", "error", err)
+		return
+	}
+
+	for _, jobID := range jobIDs {
+		exists, err := r.redis.Exists(ctx, heartbeatKey(jobID)).Result()
+		if err != nil {
+			reaperLog.Error("failed to check job heartbeat", "job_id", jobID, "error", err)
+			continue
+		}
+		if exists > 0 {
+			continue
+		}
+
+		if err := r.queue.Requeue(ctx, jobID); err != nil {
+			reaperLog.Error("failed to reclaim abandoned job", "job_id", jobID, "error", err)
+			continue
+		}
+		reaperLog.Info("reclaimed abandoned job with expired heartbeat", "job_id", jobID)
+	}
+}
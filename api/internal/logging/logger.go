@@ -0,0 +1,45 @@
+// Package logging provides a shared structured logger so a correlation ID
+// generated at the HTTP edge can be threaded through Gin handlers, RabbitMQ
+// message headers, and worker log lines, making it possible to trace one
+// request end-to-end instead of grepping disjoint log.Printf output.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+type contextKey string
+
+const correlationIDKey contextKey = "correlation_id"
+
+var base = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+// New returns a JSON slog.Logger tagged with service, the fixed field every
+// log line emitted by that binary carries (e.g. "api-server", "query-rag-worker").
+func New(service string) *slog.Logger {
+	return base.With("service", service)
+}
+
+// WithCorrelationID returns a context carrying id, so a later FromContext
+// call can attach it to a log line without threading it through every
+// function signature in between.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, id)
+}
+
+// CorrelationID returns the correlation ID stored on ctx, or "" if none was set.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey).(string)
+	return id
+}
+
+// FromContext returns logger with a correlation_id field attached when ctx
+// carries one, and logger unchanged otherwise.
+func FromContext(ctx context.Context, logger *slog.Logger) *slog.Logger {
+	if id := CorrelationID(ctx); id != "" {
+		return logger.With("correlation_id", id)
+	}
+	return logger
+}
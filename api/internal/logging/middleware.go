@@ -0,0 +1,34 @@
+package logging
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CorrelationIDHeader is the request/response header a client can set to
+// thread its own correlation ID through the request; one is generated when
+// it's absent.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// CorrelationIDKey is the Gin context key CorrelationMiddleware stores the
+// ID under, for handlers that prefer c.GetString over reading the header.
+const CorrelationIDKey = "correlation_id"
+
+// CorrelationMiddleware reads X-Correlation-ID from the incoming request,
+// generating one if absent, echoes it back on the response, and stores it
+// on both the Gin context and the request context so downstream handlers
+// (and anything they publish to RabbitMQ) can propagate the same ID.
+func CorrelationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(CorrelationIDHeader)
+		if id == "" {
+			id = uuid.New().String()
+		}
+
+		c.Set(CorrelationIDKey, id)
+		c.Request = c.Request.WithContext(WithCorrelationID(c.Request.Context(), id))
+		c.Header(CorrelationIDHeader, id)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,132 @@
+// Package metrics registers the Prometheus collectors shared across the API
+// server and the query worker: HTTP request stats, RabbitMQ publish/consume
+// counters, per-stage recommendation job latency, and build info. Each
+// binary serves these on its existing /metrics endpoint alongside any
+// package-local collectors (see internal/worker/metrics.go and
+// internal/services/scraper_metrics.go).
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// HTTPRequestDuration is recorded by the Gin middleware in
+	// internal/metrics/middleware.go for every request the API server
+	// handles.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rec_mind_http_request_duration_seconds",
+		Help:    "HTTP request duration in seconds, labeled by method, route, and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// RabbitMQPublishTotal and RabbitMQConsumeTotal give queue-level
+	// throughput and error-rate visibility across mq.Publish* and the
+	// consumer loops in internal/worker.
+	RabbitMQPublishTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rec_mind_rabbitmq_publish_total",
+		Help: "Total number of RabbitMQ publish attempts, labeled by queue and outcome (success/error).",
+	}, []string{"queue", "outcome"})
+
+	RabbitMQConsumeTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rec_mind_rabbitmq_consume_total",
+		Help: "Total number of RabbitMQ deliveries consumed, labeled by queue and outcome (ack/nack/dead_letter).",
+	}, []string{"queue", "outcome"})
+
+	// The per-stage histograms below break a recommendation job's total
+	// latency down by phase, so a regression in one ML call or rerank step
+	// doesn't just show up as "job_total_seconds got slower" with no lead
+	// on where to look.
+	JobQueueWaitSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "rec_mind_job_queue_wait_seconds",
+		Help:    "Time a recommendation or query search job spent queued before a worker picked it up.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	EmbeddingSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "rec_mind_embedding_seconds",
+		Help:    "Time spent fetching/generating embeddings for a job's source chunks.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	PineconeSearchSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "rec_mind_pinecone_search_seconds",
+		Help:    "Time spent on vector-index chunk searches for a job.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	RerankSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "rec_mind_rerank_seconds",
+		Help:    "Time spent on MMR/cross-encoder re-ranking for a job.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	JobTotalSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rec_mind_job_total_seconds",
+		Help:    "End-to-end job processing time, labeled by job type (recommendation/query_search) and outcome.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"job_type", "outcome"})
+
+	// QuerySearchPhaseSeconds breaks down QueryRAGWorker.ProcessQuerySearchJob
+	// by phase (publish/wait/enrich/store), so ProcessBatch callers can see
+	// whether a slowdown is the ML service, Postgres enrichment, or Redis.
+	QuerySearchPhaseSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rec_mind_query_search_phase_seconds",
+		Help:    "Time spent in each phase of processing a query search job, labeled by phase (publish/wait/enrich/store).",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"phase"})
+
+	// MigrationDurationSeconds is recorded by internal/migrations.MigrationRunner
+	// after each migration file it applies or reverts.
+	MigrationDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rec_mind_migration_duration_seconds",
+		Help:    "Time to apply or revert a single migration, labeled by version and direction (up/down).",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"version", "direction"})
+
+	// SearchJobsEnqueuedTotal is incremented by SearchController.SearchByQuery/
+	// SearchWithImmediateResponse right after a successful
+	// mq.PublishQuerySearchJob, labeled by tenant and priority so a spike
+	// from one noisy tenant or priority band is visible without grepping
+	// logs.
+	SearchJobsEnqueuedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rec_mind_search_jobs_enqueued_total",
+		Help: "Total number of query search jobs enqueued, labeled by tenant and priority.",
+	}, []string{"tenant", "priority"})
+
+	// SearchJobsProcessedTotal is incremented by QueryRAGWorker.storeQueryResult
+	// once a job reaches a terminal status, labeled by that status
+	// (completed/cancelled/error) - the Prometheus counterpart to
+	// SearchJobsEnqueuedTotal for measuring queue drain rate vs intake rate.
+	SearchJobsProcessedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rec_mind_search_jobs_processed_total",
+		Help: "Total number of query search jobs reaching a terminal status, labeled by status.",
+	}, []string{"status"})
+
+	// RedisResultCacheHitsTotal is incremented by
+	// SearchController.GetQuerySearchJobStatus for each lookup of
+	// query_search_result:<job_id>, labeled by outcome (hit/miss), so cache
+	// effectiveness for job-status polling is visible alongside the Redis
+	// pool stats in pool_stats.go.
+	RedisResultCacheHitsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rec_mind_redis_result_cache_hits_total",
+		Help: "Total number of query_search_result:<job_id> Redis lookups, labeled by outcome (hit/miss).",
+	}, []string{"outcome"})
+
+	// buildInfo is a constant gauge of value 1, labeled with git_sha and
+	// version; the standard Prometheus "info" pattern for exposing static
+	// build metadata as queryable labels (SetBuildInfo sets them once at
+	// startup).
+	buildInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rec_mind_build_info",
+		Help: "Static build metadata; always 1, carrying git_sha and version as labels.",
+	}, []string{"git_sha", "version"})
+)
+
+// SetBuildInfo records the running binary's git SHA and version on the
+// rec_mind_build_info gauge. Call once at startup with values baked in via
+// -ldflags, or "unknown"/"dev" defaults if none were set.
+func SetBuildInfo(gitSHA, version string) {
+	buildInfo.WithLabelValues(gitSHA, version).Set(1)
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+var (
+	dbPoolStatsOnce    sync.Once
+	redisPoolStatsOnce sync.Once
+)
+
+// RegisterDBPoolStats exposes pool's connection counts as gauges. Only the
+// first call in a process takes effect, since promauto panics on duplicate
+// registration and a process only ever has one pgx pool to watch.
+func RegisterDBPoolStats(pool *pgxpool.Pool) {
+	dbPoolStatsOnce.Do(func() {
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "rec_mind_db_pool_total_conns",
+			Help: "Total connections (idle + in use) in the Postgres connection pool.",
+		}, func() float64 { return float64(pool.Stat().TotalConns()) })
+
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "rec_mind_db_pool_idle_conns",
+			Help: "Idle connections in the Postgres connection pool.",
+		}, func() float64 { return float64(pool.Stat().IdleConns()) })
+
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "rec_mind_db_pool_acquired_conns",
+			Help: "Connections currently acquired from the Postgres connection pool.",
+		}, func() float64 { return float64(pool.Stat().AcquiredConns()) })
+	})
+}
+
+// RegisterRedisPoolStats exposes client's connection pool stats as gauges.
+// Only the first call in a process takes effect; see RegisterDBPoolStats.
+func RegisterRedisPoolStats(client *goredis.Client) {
+	redisPoolStatsOnce.Do(func() {
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "rec_mind_redis_pool_total_conns",
+			Help: "Total connections (idle + in use) in the Redis connection pool.",
+		}, func() float64 { return float64(client.PoolStats().TotalConns) })
+
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "rec_mind_redis_pool_idle_conns",
+			Help: "Idle connections in the Redis connection pool.",
+		}, func() float64 { return float64(client.PoolStats().IdleConns) })
+
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "rec_mind_redis_pool_timeouts_total",
+			Help: "Total number of times a Redis connection pool wait timed out.",
+		}, func() float64 { return float64(client.PoolStats().Timeouts) })
+	})
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// defaultQueueDepthPollInterval bounds how often StartQueueDepthPoller
+// re-inspects its queues; frequent enough to catch a backlog building up,
+// infrequent enough not to hammer the broker's management plane.
+const defaultQueueDepthPollInterval = 15 * time.Second
+
+// queueDepth is the ready-message count for each polled queue, labeled by
+// queue name so search_queue_depth and any other watched queue share one
+// series family instead of one gauge per queue.
+var queueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "rec_mind_queue_depth",
+	Help: "Number of ready messages in a RabbitMQ queue, labeled by queue name.",
+}, []string{"queue"})
+
+// StartQueueDepthPoller spawns a goroutine that inspects each of queues on
+// ch every defaultQueueDepthPollInterval and records its ready-message count
+// on queueDepth, until ctx is done. ch.QueueInspect is passive (no
+// declare), so a queue that doesn't exist yet just reports an error for
+// that tick rather than creating it.
+func StartQueueDepthPoller(ctx context.Context, ch *amqp.Channel, queues ...string) {
+	go func() {
+		ticker := time.NewTicker(defaultQueueDepthPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for _, queue := range queues {
+					if info, err := ch.QueueInspect(queue); err == nil {
+						queueDepth.WithLabelValues(queue).Set(float64(info.Messages))
+					}
+				}
+			}
+		}
+	}()
+}
@@ -2,16 +2,60 @@ package migrations
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
-	"strings"
+	"strconv"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"rec-mind/internal/logging"
+	"rec-mind/internal/metrics"
 )
 
+var migrationLog = logging.New("migrations")
+
+// migrationFilePattern matches both the legacy one-way "NNNN_name.sql"
+// files and the versioned "NNNN_name.up.sql" / "NNNN_name.down.sql" pairs.
+// Group 3 is empty for the legacy form.
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-zA-Z0-9_]+?)(\.up|\.down)?\.sql$`)
+
+// Migration is one versioned schema change. DownSQL is empty for legacy
+// migrations written before the up/down split existed, which therefore
+// can't be rolled back.
+type Migration struct {
+	Version  int
+	Name     string
+	UpSQL    string
+	DownSQL  string
+	Checksum string // SHA-256 of UpSQL, hex-encoded
+}
+
+// AppliedMigration is one row already recorded in schema_migrations.
+type AppliedMigration struct {
+	Version     int
+	Name        string
+	Checksum    string
+	AppliedAt   time.Time
+	ExecutionMs int64
+}
+
+// MigrationStatus describes one migration's on-disk and applied state, as
+// reported by MigrationRunner.Status.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	Drifted   bool
+}
+
 type MigrationRunner struct {
 	db *pgxpool.Pool
 }
@@ -20,113 +64,334 @@ func NewMigrationRunner(db *pgxpool.Pool) *MigrationRunner {
 	return &MigrationRunner{db: db}
 }
 
-// RunMigrations executes all SQL files in the migrations directory
+// RunMigrations applies every pending migration in migrationsDir, in
+// version order. Kept as the simple one-way entry point for callers that
+// don't need MigrateDown/Status/Force.
 func (mr *MigrationRunner) RunMigrations(ctx context.Context, migrationsDir string) error {
-	// Create migrations tracking table if it doesn't exist
-	err := mr.createMigrationsTable(ctx)
+	return mr.MigrateUp(ctx, migrationsDir, 0)
+}
+
+// MigrateUp applies pending migrations in version order. steps limits how
+// many are applied in this call; 0 means "all pending". It refuses to run
+// past a migration whose on-disk checksum no longer matches what was
+// recorded at apply time, since that means history was rewritten after the
+// fact rather than forward via a new migration.
+func (mr *MigrationRunner) MigrateUp(ctx context.Context, migrationsDir string, steps int) error {
+	if err := mr.createMigrationsTable(ctx); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	all, err := mr.loadMigrations(migrationsDir)
 	if err != nil {
+		return fmt.Errorf("failed to load migration files: %w", err)
+	}
+
+	applied, err := mr.appliedMigrations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	if err := detectChecksumDrift(all, applied); err != nil {
+		return err
+	}
+
+	applyCount := 0
+	for _, m := range all {
+		if steps > 0 && applyCount >= steps {
+			break
+		}
+		if _, ok := applied[m.Version]; ok {
+			migrationLog.Info("migration already applied, skipping", "version", m.Version, "name", m.Name)
+			continue
+		}
+
+		migrationLog.Info("running migration", "version", m.Version, "name", m.Name)
+		start := time.Now()
+		if err := mr.applyInTx(ctx, m.UpSQL, func(tx pgx.Tx) error {
+			return recordApplied(ctx, tx, m, time.Since(start))
+		}); err != nil {
+			return fmt.Errorf("failed to apply migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		duration := time.Since(start)
+		metrics.MigrationDurationSeconds.WithLabelValues(strconv.Itoa(m.Version), "up").Observe(duration.Seconds())
+		migrationLog.Info("migration completed", "version", m.Version, "name", m.Name, "duration_ms", duration.Milliseconds())
+		applyCount++
+	}
+
+	migrationLog.Info("migrations up to date")
+	return nil
+}
+
+// MigrateDown rolls back the steps most recently applied migrations, in
+// reverse version order, each inside its own transaction. It refuses to
+// roll back a migration that has no down file.
+func (mr *MigrationRunner) MigrateDown(ctx context.Context, migrationsDir string, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive")
+	}
+
+	if err := mr.createMigrationsTable(ctx); err != nil {
 		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
-	// Get all migration files
-	migrationFiles, err := mr.getMigrationFiles(migrationsDir)
+	all, err := mr.loadMigrations(migrationsDir)
 	if err != nil {
-		return fmt.Errorf("failed to get migration files: %w", err)
+		return fmt.Errorf("failed to load migration files: %w", err)
+	}
+	byVersion := make(map[int]Migration, len(all))
+	for _, m := range all {
+		byVersion[m.Version] = m
 	}
 
-	// Execute each migration
-	for _, filename := range migrationFiles {
-		executed, err := mr.isMigrationExecuted(ctx, filename)
-		if err != nil {
-			return fmt.Errorf("failed to check migration status for %s: %w", filename, err)
+	applied, err := mr.appliedMigrations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	versions := make([]int, 0, len(applied))
+	for v := range applied {
+		versions = append(versions, v)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	if steps > len(versions) {
+		steps = len(versions)
+	}
+
+	for i := 0; i < steps; i++ {
+		version := versions[i]
+		m, ok := byVersion[version]
+		if !ok {
+			return fmt.Errorf("migration file for applied version %d not found, cannot roll back", version)
+		}
+		if m.DownSQL == "" {
+			return fmt.Errorf("migration %04d_%s has no down file, cannot roll back", m.Version, m.Name)
 		}
 
-		if executed {
-			fmt.Printf("⏭️  Migration %s already executed, skipping\n", filename)
-			continue
+		migrationLog.Info("reverting migration", "version", m.Version, "name", m.Name)
+		start := time.Now()
+		if err := mr.applyInTx(ctx, m.DownSQL, func(tx pgx.Tx) error {
+			return recordReverted(ctx, tx, m.Version)
+		}); err != nil {
+			return fmt.Errorf("failed to revert migration %04d_%s: %w", m.Version, m.Name, err)
 		}
+		metrics.MigrationDurationSeconds.WithLabelValues(strconv.Itoa(m.Version), "down").Observe(time.Since(start).Seconds())
+		migrationLog.Info("reverted migration", "version", m.Version, "name", m.Name)
+	}
 
-		fmt.Printf("🔄 Running migration: %s\n", filename)
-		err = mr.executeMigration(ctx, migrationsDir, filename)
-		if err != nil {
-			return fmt.Errorf("failed to execute migration %s: %w", filename, err)
+	return nil
+}
+
+// Status reports every on-disk migration's applied/pending state, flagging
+// any whose recorded checksum no longer matches the file on disk.
+func (mr *MigrationRunner) Status(ctx context.Context, migrationsDir string) ([]MigrationStatus, error) {
+	if err := mr.createMigrationsTable(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	all, err := mr.loadMigrations(migrationsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migration files: %w", err)
+	}
+
+	applied, err := mr.appliedMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	statuses := make([]MigrationStatus, 0, len(all))
+	for _, m := range all {
+		status := MigrationStatus{Version: m.Version, Name: m.Name}
+		if a, ok := applied[m.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = a.AppliedAt
+			status.Drifted = a.Checksum != m.Checksum
 		}
+		statuses = append(statuses, status)
+	}
 
-		err = mr.markMigrationExecuted(ctx, filename)
-		if err != nil {
-			return fmt.Errorf("failed to mark migration as executed %s: %w", filename, err)
+	return statuses, nil
+}
+
+// Force accepts the on-disk checksum for version as the new source of
+// truth, clearing the drift MigrateUp would otherwise refuse to run past.
+// It does not re-execute the migration's SQL — operators are expected to
+// have already reconciled the schema by hand before forcing.
+func (mr *MigrationRunner) Force(ctx context.Context, migrationsDir string, version int) error {
+	if err := mr.createMigrationsTable(ctx); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	all, err := mr.loadMigrations(migrationsDir)
+	if err != nil {
+		return fmt.Errorf("failed to load migration files: %w", err)
+	}
+
+	var target *Migration
+	for i := range all {
+		if all[i].Version == version {
+			target = &all[i]
+			break
 		}
+	}
+	if target == nil {
+		return fmt.Errorf("no migration file found for version %d", version)
+	}
 
-		fmt.Printf("✅ Migration %s completed successfully\n", filename)
+	_, err = mr.db.Exec(ctx, `
+		INSERT INTO schema_migrations (version, name, checksum, applied_at, execution_ms)
+		VALUES ($1, $2, $3, NOW(), 0)
+		ON CONFLICT (version) DO UPDATE SET checksum = EXCLUDED.checksum`,
+		target.Version, target.Name, target.Checksum)
+	if err != nil {
+		return fmt.Errorf("failed to force version %d: %w", version, err)
 	}
 
-	fmt.Println("🎉 All migrations completed successfully!")
 	return nil
 }
 
 func (mr *MigrationRunner) createMigrationsTable(ctx context.Context) error {
 	query := `
 		CREATE TABLE IF NOT EXISTS schema_migrations (
-			filename VARCHAR(255) PRIMARY KEY,
-			executed_at TIMESTAMP DEFAULT NOW()
+			version      INTEGER PRIMARY KEY,
+			name         TEXT NOT NULL,
+			checksum     TEXT NOT NULL,
+			applied_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			execution_ms BIGINT NOT NULL DEFAULT 0
 		);
 	`
 	_, err := mr.db.Exec(ctx, query)
 	return err
 }
 
-func (mr *MigrationRunner) getMigrationFiles(migrationsDir string) ([]string, error) {
-	var files []string
+// loadMigrations reads migrationsDir and groups files by version, pairing
+// up/down siblings and treating a lone "NNNN_name.sql" as up-only.
+func (mr *MigrationRunner) loadMigrations(migrationsDir string) ([]Migration, error) {
+	entries, err := os.ReadDir(migrationsDir)
+	if err != nil {
+		return nil, err
+	}
 
-	err := filepath.WalkDir(migrationsDir, func(path string, d fs.DirEntry, err error) error {
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(matches[1])
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("invalid version in filename %s: %w", entry.Name(), err)
 		}
+		name := matches[2]
+		direction := matches[3]
 
-		if !d.IsDir() && strings.HasSuffix(d.Name(), ".sql") {
-			files = append(files, d.Name())
+		content, err := os.ReadFile(filepath.Join(migrationsDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
 		}
 
-		return nil
-	})
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+
+		if direction == ".down" {
+			m.DownSQL = string(content)
+			continue
+		}
 
+		// Both the legacy "NNNN_name.sql" and the versioned
+		// "NNNN_name.up.sql" are the up migration.
+		m.UpSQL = string(content)
+		sum := sha256.Sum256(content)
+		m.Checksum = hex.EncodeToString(sum[:])
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.UpSQL == "" {
+			return nil, fmt.Errorf("migration %04d_%s has a down file but no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+func (mr *MigrationRunner) appliedMigrations(ctx context.Context) (map[int]AppliedMigration, error) {
+	rows, err := mr.db.Query(ctx, `SELECT version, name, checksum, applied_at, execution_ms FROM schema_migrations`)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	// Sort files to ensure they run in order
-	sort.Strings(files)
-	return files, nil
+	applied := make(map[int]AppliedMigration)
+	for rows.Next() {
+		var a AppliedMigration
+		if err := rows.Scan(&a.Version, &a.Name, &a.Checksum, &a.AppliedAt, &a.ExecutionMs); err != nil {
+			return nil, err
+		}
+		applied[a.Version] = a
+	}
+	return applied, rows.Err()
 }
 
-func (mr *MigrationRunner) isMigrationExecuted(ctx context.Context, filename string) (bool, error) {
-	var count int
-	query := "SELECT COUNT(*) FROM schema_migrations WHERE filename = $1"
-	err := mr.db.QueryRow(ctx, query, filename).Scan(&count)
-	if err != nil {
-		return false, err
+// detectChecksumDrift refuses to run when an already-applied migration's
+// file no longer matches what was recorded, since that usually means
+// history was rewritten in place instead of forward via a new migration.
+func detectChecksumDrift(migrations []Migration, applied map[int]AppliedMigration) error {
+	for _, m := range migrations {
+		a, ok := applied[m.Version]
+		if !ok {
+			continue
+		}
+		if a.Checksum != m.Checksum {
+			return fmt.Errorf(
+				"checksum drift on migration %04d_%s: applied checksum %s, file checksum %s — run `force %d` to accept the new file",
+				m.Version, m.Name, a.Checksum, m.Checksum, m.Version,
+			)
+		}
 	}
-	return count > 0, nil
+	return nil
 }
 
-func (mr *MigrationRunner) executeMigration(ctx context.Context, migrationsDir, filename string) error {
-	filePath := filepath.Join(migrationsDir, filename)
-	content, err := os.ReadFile(filePath)
+// applyInTx runs sql and record inside one transaction, rolling back if
+// either fails so a mid-migration error never leaves a partial schema
+// change with no schema_migrations row (or vice versa).
+func (mr *MigrationRunner) applyInTx(ctx context.Context, sql string, record func(tx pgx.Tx) error) error {
+	tx, err := mr.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to read migration file: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback(ctx)
 
-	// Execute the SQL content
-	_, err = mr.db.Exec(ctx, string(content))
-	if err != nil {
+	if _, err := tx.Exec(ctx, sql); err != nil {
 		return fmt.Errorf("failed to execute SQL: %w", err)
 	}
 
-	return nil
+	if err := record(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func recordApplied(ctx context.Context, tx pgx.Tx, m Migration, execution time.Duration) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO schema_migrations (version, name, checksum, applied_at, execution_ms)
+		VALUES ($1, $2, $3, NOW(), $4)`,
+		m.Version, m.Name, m.Checksum, execution.Milliseconds())
+	return err
 }
 
-func (mr *MigrationRunner) markMigrationExecuted(ctx context.Context, filename string) error {
-	query := "INSERT INTO schema_migrations (filename) VALUES ($1)"
-	_, err := mr.db.Exec(ctx, query, filename)
+func recordReverted(ctx context.Context, tx pgx.Tx, version int) error {
+	_, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, version)
 	return err
-}
\ No newline at end of file
+}
@@ -1,7 +1,6 @@
 package mlclient
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -11,12 +10,14 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"rec-mind/pkg/pythonclient"
 )
 
 // MLClient handles communication with the Python ML service
 type MLClient struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL string
+	client  *pythonclient.Client
 }
 
 // EmbeddingRequest represents a single embedding request
@@ -88,20 +89,13 @@ func NewMLClient() *MLClient {
 
 	return &MLClient{
 		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 60 * time.Second, // Longer timeout for ML operations
-		},
+		client:  pythonclient.NewClient(60 * time.Second), // Longer timeout for ML operations
 	}
 }
 
 // Health checks the health of the ML service
 func (c *MLClient) Health(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/health", nil)
-	if err != nil {
-		return fmt.Errorf("failed to create health check request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.client.Do(ctx, "GET", c.baseURL+"/health", nil, nil)
 	if err != nil {
 		return fmt.Errorf("health check request failed: %w", err)
 	}
@@ -114,6 +108,18 @@ func (c *MLClient) Health(ctx context.Context) error {
 	return nil
 }
 
+// BreakerStats reports the shared circuit breaker's current state for
+// health reporting.
+func (c *MLClient) BreakerStats() pythonclient.Stats {
+	return pythonclient.AggregateStats()
+}
+
+// BreakerOpen reports whether new embedding work should be deferred rather
+// than attempted right now.
+func (c *MLClient) BreakerOpen() bool {
+	return pythonclient.BreakerOpen()
+}
+
 // GenerateBatchEmbeddingsAndUpload sends articles to the Python service for embedding generation and Pinecone upload
 func (c *MLClient) GenerateBatchEmbeddingsAndUpload(ctx context.Context, articles []EmbeddingRequest) (*BatchAndUploadResponse, error) {
 	if len(articles) == 0 {
@@ -131,17 +137,12 @@ func (c *MLClient) GenerateBatchEmbeddingsAndUpload(ctx context.Context, article
 		return nil, fmt.Errorf("failed to marshal batch request: %w", err)
 	}
 
-	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/embeddings/batch-and-upload", bytes.NewBuffer(requestBody))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create batch request: %w", err)
+	// Send request through the shared breaker/retry-wrapped client
+	headers := map[string]string{
+		"Content-Type": "application/json",
+		"Accept":       "application/json",
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	// Send request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.client.Do(ctx, "POST", c.baseURL+"/embeddings/batch-and-upload", requestBody, headers)
 	if err != nil {
 		return nil, fmt.Errorf("batch embedding request failed: %w", err)
 	}
@@ -189,6 +190,87 @@ func (c *MLClient) GenerateSingleEmbeddingAndUpload(ctx context.Context, article
 	})
 }
 
+// DeleteVectors asks the Python service to remove the vectors associated
+// with the given article IDs from the vector store.
+func (c *MLClient) DeleteVectors(ctx context.Context, articleIDs []uuid.UUID) error {
+	if len(articleIDs) == 0 {
+		return nil
+	}
+
+	requestBody, err := json.Marshal(map[string]interface{}{"article_ids": articleIDs})
+	if err != nil {
+		return fmt.Errorf("failed to marshal vector delete request: %w", err)
+	}
+
+	resp, err := c.client.Do(ctx, "POST", c.baseURL+"/embeddings/delete", requestBody, map[string]string{"Content-Type": "application/json"})
+	if err != nil {
+		return fmt.Errorf("vector delete request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &MLServiceError{
+			Message:    fmt.Sprintf("vector delete failed: %s", string(body)),
+			StatusCode: resp.StatusCode,
+		}
+	}
+
+	return nil
+}
+
+// FetchCentroidEmbeddings asks the Python service for each article's
+// centroid embedding (the mean of its chunk vectors in the vector store),
+// used by recommendation re-ranking to measure similarity between
+// candidate articles. Articles the service has no vectors for are simply
+// absent from the returned map rather than causing an error.
+func (c *MLClient) FetchCentroidEmbeddings(ctx context.Context, articleIDs []uuid.UUID) (map[uuid.UUID][]float64, error) {
+	if len(articleIDs) == 0 {
+		return map[uuid.UUID][]float64{}, nil
+	}
+
+	requestBody, err := json.Marshal(map[string]interface{}{"article_ids": articleIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal centroid embedding request: %w", err)
+	}
+
+	resp, err := c.client.Do(ctx, "POST", c.baseURL+"/embeddings/centroids", requestBody, map[string]string{"Content-Type": "application/json"})
+	if err != nil {
+		return nil, fmt.Errorf("centroid embedding request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &MLServiceError{
+			Message:    fmt.Sprintf("centroid embedding fetch failed: %s", string(responseBody)),
+			StatusCode: resp.StatusCode,
+		}
+	}
+
+	var result struct {
+		Centroids map[string][]float64 `json:"centroids"`
+	}
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse centroid embedding response: %w", err)
+	}
+
+	centroids := make(map[uuid.UUID][]float64, len(result.Centroids))
+	for idStr, vector := range result.Centroids {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		centroids[id] = vector
+	}
+
+	return centroids, nil
+}
+
 // CreateEmbeddingText combines article title and content for embedding generation
 func CreateEmbeddingText(title, content string) string {
 	// Combine title and content with clear separation
@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Consumer is a minimal manual-ack RabbitMQ consumer scaffold: set
+// Prefetch and Handle, then call Run. A handler panic is recovered and
+// nacked with requeue instead of crashing the process.
+type Consumer struct {
+	Channel  *amqp.Channel
+	Queue    string
+	Prefetch int
+	Handle   func(ctx context.Context, delivery amqp.Delivery) error
+}
+
+// Run declares QoS, starts consuming Queue, and dispatches each delivery
+// to Handle until ctx is cancelled or the delivery channel closes.
+func (c *Consumer) Run(ctx context.Context) error {
+	prefetch := c.Prefetch
+	if prefetch <= 0 {
+		prefetch = 1
+	}
+
+	if err := c.Channel.Qos(prefetch, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	deliveries, err := c.Channel.Consume(c.Queue, "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming %s: %w", c.Queue, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return nil
+			}
+			c.dispatch(ctx, delivery)
+		}
+	}
+}
+
+// dispatch runs Handle for one delivery, recovering from a panic so one
+// bad message can't take down the consumer: it nacks with requeue so
+// another attempt (this worker after restart, or another worker) gets a
+// chance to process it.
+func (c *Consumer) dispatch(ctx context.Context, delivery amqp.Delivery) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("❌ Consumer handler panicked on %s: %v", c.Queue, r)
+			_ = delivery.Nack(false, true)
+		}
+	}()
+
+	if err := c.Handle(ctx, delivery); err != nil {
+		log.Printf("❌ Consumer handler failed on %s: %v", c.Queue, err)
+		_ = delivery.Nack(false, true)
+		return
+	}
+
+	_ = delivery.Ack(false)
+}
@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// outboxDrainBatchSize caps how many due messages one drain pass retries,
+// so a large backlog can't monopolize the ticker interval.
+const outboxDrainBatchSize = 20
+
+// StartDrainer periodically re-publishes due outbox messages, deleting
+// them on success and pushing back next_retry_at on failure. It runs until
+// ctx is cancelled.
+func (p *Publisher) StartDrainer(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.drainOnce(ctx)
+			}
+		}
+	}()
+}
+
+func (p *Publisher) drainOnce(ctx context.Context) {
+	if p.store == nil {
+		return
+	}
+
+	messages, err := p.store.DueForRetry(ctx, outboxDrainBatchSize)
+	if err != nil {
+		log.Printf("❌ Failed to load due outbox messages: %v", err)
+		return
+	}
+
+	for _, msg := range messages {
+		headers := amqp.Table{}
+		for k, v := range msg.Headers {
+			headers[k] = v
+		}
+
+		if err := p.publishOnce(msg.Queue, msg.Payload, headers); err != nil {
+			nextRetry := time.Now().Add(backoff(msg.Attempts + 1))
+			if mErr := p.store.MarkAttempt(ctx, msg.ID, nextRetry); mErr != nil {
+				log.Printf("❌ Failed to mark outbox attempt for %s: %v", msg.ID, mErr)
+			}
+			continue
+		}
+
+		if err := p.store.Delete(ctx, msg.ID); err != nil {
+			log.Printf("❌ Failed to delete drained outbox message %s: %v", msg.ID, err)
+			continue
+		}
+		log.Printf("✅ Drained outbox message %s to %s", msg.ID, msg.Queue)
+	}
+}
@@ -0,0 +1,153 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// confirmTimeout bounds how long Publish waits for a publisher confirm
+// before treating the attempt as failed.
+const confirmTimeout = 5 * time.Second
+
+// maxPublishAttempts bounds in-process retries before a message is parked
+// in the outbox for the background drainer to keep retrying.
+const maxPublishAttempts = 3
+
+// baseBackoff is the delay before the first retry; it doubles every
+// attempt after that (1x, 2x, 4x, ...).
+const baseBackoff = 200 * time.Millisecond
+
+// Publisher wraps an amqp.Channel with publisher confirms, exponential
+// backoff retries, and a durable outbox fallback so a publish failure
+// never silently drops a message.
+type Publisher struct {
+	channel  *amqp.Channel
+	store    Store
+	confirms <-chan amqp.Confirmation
+}
+
+// NewPublisher puts channel into confirm mode and wires up a Publisher
+// backed by store for messages that exhaust their in-process retries.
+func NewPublisher(channel *amqp.Channel, store Store) (*Publisher, error) {
+	if err := channel.Confirm(false); err != nil {
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	confirms := channel.NotifyPublish(make(chan amqp.Confirmation, 1))
+
+	return &Publisher{channel: channel, store: store, confirms: confirms}, nil
+}
+
+// DeclareDurableQueue declares queue with a dead-letter exchange pointing
+// at dlq (typically a sibling "<queue>.dlq" queue) and a message TTL, then
+// declares dlq itself as a plain durable queue.
+func (p *Publisher) DeclareDurableQueue(queue, dlq string, ttl time.Duration) error {
+	_, err := p.channel.QueueDeclare(
+		queue,
+		true,  // durable
+		false, // auto-delete
+		false, // exclusive
+		false, // no-wait
+		amqp.Table{
+			"x-message-ttl":             int32(ttl.Milliseconds()),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": dlq,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare %s queue: %w", queue, err)
+	}
+
+	if _, err := p.channel.QueueDeclare(dlq, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare %s queue: %w", dlq, err)
+	}
+
+	return nil
+}
+
+// Publish retries publishing body to queue with exponential backoff on a
+// nack or confirm timeout; if every attempt fails, it parks the message in
+// the outbox store instead of dropping it.
+func (p *Publisher) Publish(ctx context.Context, queue string, body []byte, headers amqp.Table) error {
+	var lastErr error
+
+	for attempt := 0; attempt < maxPublishAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff(attempt)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if err := p.publishOnce(queue, body, headers); err != nil {
+			lastErr = err
+			log.Printf("⚠️ Publish attempt %d/%d to %s failed: %v", attempt+1, maxPublishAttempts, queue, err)
+			continue
+		}
+
+		return nil
+	}
+
+	log.Printf("🪦 Parking undeliverable message to %s in outbox after %d attempts: %v", queue, maxPublishAttempts, lastErr)
+	return p.park(ctx, queue, body, headers)
+}
+
+// publishOnce sends one publish and blocks for its confirm.
+func (p *Publisher) publishOnce(queue string, body []byte, headers amqp.Table) error {
+	err := p.channel.Publish(
+		"",    // exchange
+		queue, // routing key (queue name)
+		false, // mandatory
+		false, // immediate
+		amqp.Publishing{
+			ContentType: "application/json",
+			Body:        body,
+			Headers:     headers,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to publish: %w", err)
+	}
+
+	select {
+	case confirm := <-p.confirms:
+		if !confirm.Ack {
+			return fmt.Errorf("broker nacked publish")
+		}
+		return nil
+	case <-time.After(confirmTimeout):
+		return fmt.Errorf("timed out waiting for publisher confirm")
+	}
+}
+
+func (p *Publisher) park(ctx context.Context, queue string, body []byte, headers amqp.Table) error {
+	if p.store == nil {
+		return fmt.Errorf("no outbox store configured to park undeliverable message")
+	}
+
+	h := make(map[string]interface{}, len(headers))
+	for k, v := range headers {
+		h[k] = v
+	}
+
+	return p.store.Enqueue(ctx, &Message{
+		Queue:       queue,
+		Payload:     body,
+		Headers:     h,
+		NextRetryAt: time.Now().Add(baseBackoff),
+	})
+}
+
+// backoff returns 2^attempt * baseBackoff, the delay before retry attempt.
+func backoff(attempt int) time.Duration {
+	d := baseBackoff
+	for i := 0; i < attempt; i++ {
+		d *= 2
+	}
+	return d
+}
@@ -0,0 +1,110 @@
+// Package outbox makes RabbitMQ publishing durable: Publisher retries a
+// failed publish with exponential backoff and, if every attempt fails,
+// parks the message in a Postgres-backed outbox table instead of dropping
+// it, so a RabbitMQ outage doesn't silently lose a scraped article. A
+// background drainer keeps retrying parked messages until they go through.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Message is one undeliverable publish attempt parked for retry.
+type Message struct {
+	ID          uuid.UUID
+	Queue       string
+	Payload     []byte
+	Headers     map[string]interface{}
+	Attempts    int
+	NextRetryAt time.Time
+	CreatedAt   time.Time
+}
+
+// Store persists outbox messages across process restarts.
+type Store interface {
+	Enqueue(ctx context.Context, msg *Message) error
+	DueForRetry(ctx context.Context, limit int) ([]*Message, error)
+	MarkAttempt(ctx context.Context, id uuid.UUID, nextRetryAt time.Time) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type postgresStore struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresStore backs Store with the outbox_messages table.
+func NewPostgresStore(db *pgxpool.Pool) Store {
+	return &postgresStore{db: db}
+}
+
+func (s *postgresStore) Enqueue(ctx context.Context, msg *Message) error {
+	headers, err := json.Marshal(msg.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox headers: %w", err)
+	}
+
+	if msg.ID == uuid.Nil {
+		msg.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO outbox_messages (id, queue, payload, headers, attempts, next_retry_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	if _, err := s.db.Exec(ctx, query, msg.ID, msg.Queue, msg.Payload, headers, msg.Attempts, msg.NextRetryAt); err != nil {
+		return fmt.Errorf("failed to enqueue outbox message: %w", err)
+	}
+	return nil
+}
+
+func (s *postgresStore) DueForRetry(ctx context.Context, limit int) ([]*Message, error) {
+	query := `
+		SELECT id, queue, payload, headers, attempts, next_retry_at, created_at
+		FROM outbox_messages
+		WHERE next_retry_at <= now()
+		ORDER BY next_retry_at
+		LIMIT $1`
+
+	rows, err := s.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*Message
+	for rows.Next() {
+		var msg Message
+		var headers []byte
+		if err := rows.Scan(&msg.ID, &msg.Queue, &msg.Payload, &headers, &msg.Attempts, &msg.NextRetryAt, &msg.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		if len(headers) > 0 {
+			if err := json.Unmarshal(headers, &msg.Headers); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal outbox headers: %w", err)
+			}
+		}
+		messages = append(messages, &msg)
+	}
+	return messages, rows.Err()
+}
+
+func (s *postgresStore) MarkAttempt(ctx context.Context, id uuid.UUID, nextRetryAt time.Time) error {
+	query := `UPDATE outbox_messages SET attempts = attempts + 1, next_retry_at = $2 WHERE id = $1`
+	if _, err := s.db.Exec(ctx, query, id, nextRetryAt); err != nil {
+		return fmt.Errorf("failed to mark outbox attempt: %w", err)
+	}
+	return nil
+}
+
+func (s *postgresStore) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.db.Exec(ctx, `DELETE FROM outbox_messages WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete outbox message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Package recostream broadcasts incremental recommendation-job snapshots
+// over Redis Pub/Sub so an HTTP client can render top recommendations while
+// stragglers are still refining the ranking, instead of blocking until every
+// chunk search returns.
+package recostream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"rec-mind/internal/database"
+)
+
+func channelKey(jobID string) string {
+	return fmt.Sprintf("recommendation_stream:%s", jobID)
+}
+
+// Snapshot is one frame of a job's recommendation stream: either a partial
+// result taken while chunk searches are still outstanding, or the final one.
+// Version increases by one on every snapshot for a job, so a subscriber that
+// receives frames out of order (Pub/Sub gives no ordering guarantee across
+// reconnects) can tell which is newest.
+type Snapshot struct {
+	database.RecommendationResult
+	Partial bool  `json:"partial"`
+	Version int64 `json:"version"`
+}
+
+// ResultPublisher broadcasts recommendation snapshots and lifecycle stage
+// transitions for a job as they become available.
+type ResultPublisher interface {
+	// Publish broadcasts a snapshot of the job's recommendations so far.
+	// partial is false only for the final, complete result.
+	Publish(ctx context.Context, result database.RecommendationResult, partial bool) error
+	// PublishStage broadcasts a lifecycle transition on the job's progress
+	// channel, separate from the recommendation snapshot channel so a
+	// client can render a status indicator without decoding a full
+	// (possibly empty) result payload. errMsg is only set for StageFailed.
+	PublishStage(ctx context.Context, jobID string, stage Stage, errMsg string) error
+}
+
+// Stage names a recommendation job's lifecycle states, broadcast on
+// job:progress:{job_id} so a streaming client can show status without
+// polling the job's recommendation_result key.
+type Stage string
+
+const (
+	StageQueued    Stage = "queued"
+	StageEmbedding Stage = "embedding"
+	StageSearching Stage = "searching"
+	StageRanking   Stage = "ranking"
+	StageCompleted Stage = "completed"
+	StageFailed    Stage = "failed"
+)
+
+// StageEvent is one lifecycle transition broadcast on a job's progress
+// channel.
+type StageEvent struct {
+	JobID string `json:"job_id"`
+	Stage Stage  `json:"stage"`
+	Error string `json:"error,omitempty"`
+}
+
+func progressChannelKey(jobID string) string {
+	return fmt.Sprintf("job:progress:%s", jobID)
+}
+
+// RedisResultPublisher implements ResultPublisher over Redis Pub/Sub.
+type RedisResultPublisher struct {
+	redis *goredis.Client
+}
+
+// NewRedisResultPublisher creates a RedisResultPublisher backed by the given
+// Redis client.
+func NewRedisResultPublisher(redisClient *goredis.Client) *RedisResultPublisher {
+	return &RedisResultPublisher{redis: redisClient}
+}
+
+func (p *RedisResultPublisher) Publish(ctx context.Context, result database.RecommendationResult, partial bool) error {
+	version, err := p.redis.Incr(ctx, channelKey(result.JobID)+":version").Result()
+	if err != nil {
+		return fmt.Errorf("failed to increment snapshot version for job %s: %w", result.JobID, err)
+	}
+
+	payload, err := json.Marshal(Snapshot{RecommendationResult: result, Partial: partial, Version: version})
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	if err := p.redis.Publish(ctx, channelKey(result.JobID), payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish snapshot for job %s: %w", result.JobID, err)
+	}
+
+	return nil
+}
+
+// Subscribe opens a Pub/Sub subscription to a job's recommendation stream.
+// Callers must Close() the returned PubSub when done.
+func Subscribe(ctx context.Context, redisClient *goredis.Client, jobID string) *goredis.PubSub {
+	return redisClient.Subscribe(ctx, channelKey(jobID))
+}
+
+func (p *RedisResultPublisher) PublishStage(ctx context.Context, jobID string, stage Stage, errMsg string) error {
+	payload, err := json.Marshal(StageEvent{JobID: jobID, Stage: stage, Error: errMsg})
+	if err != nil {
+		return fmt.Errorf("failed to marshal stage event: %w", err)
+	}
+
+	if err := p.redis.Publish(ctx, progressChannelKey(jobID), payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish stage event for job %s: %w", jobID, err)
+	}
+
+	return nil
+}
+
+// SubscribeStages opens a Pub/Sub subscription to a job's progress channel.
+// Callers must Close() the returned PubSub when done.
+func SubscribeStages(ctx context.Context, redisClient *goredis.Client, jobID string) *goredis.PubSub {
+	return redisClient.Subscribe(ctx, progressChannelKey(jobID))
+}
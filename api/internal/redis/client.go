@@ -75,4 +75,39 @@ func HealthCheck(ctx context.Context) error {
 
 	_, err := RedisClient.Ping(ctx).Result()
 	return err
+}
+
+// DeleteByPrefix removes every key matching prefix+"*" using SCAN so a
+// cascade cleanup doesn't block the server the way KEYS would on a large
+// keyspace.
+func DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	if RedisClient == nil {
+		return 0, fmt.Errorf("Redis client not initialized")
+	}
+
+	var deleted int64
+	var cursor uint64
+	pattern := prefix + "*"
+
+	for {
+		keys, nextCursor, err := RedisClient.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return deleted, fmt.Errorf("failed to scan keys matching %q: %w", pattern, err)
+		}
+
+		if len(keys) > 0 {
+			n, err := RedisClient.Del(ctx, keys...).Result()
+			if err != nil {
+				return deleted, fmt.Errorf("failed to delete keys matching %q: %w", pattern, err)
+			}
+			deleted += n
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return deleted, nil
 }
\ No newline at end of file
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"container/list"
+	"sync"
+
+	"rec-mind/internal/database"
+)
+
+// articleCacheElement is the unit articleCache's LRU list holds: the cache
+// key that produced it (so eviction can remove the matching map entry) plus
+// the cached article and its accounted size in bytes.
+type articleCacheElement struct {
+	key     string
+	article database.Article
+	size    int64
+}
+
+// articleCache is a byte-bounded LRU in front of articleRepository's
+// GetByID/GetByURL, modeled on worker.enrichmentCache (itself modeled on
+// ClusterCockpit's lrucache usage in JobRepository) but bounded by
+// cumulative entry size rather than item count, since articles vary widely
+// in content length and a count bound gives no real memory guarantee.
+//
+// An article is cached under two keys, articleCacheKeyByID and
+// articleCacheKeyByURL, so a lookup by either ID or URL can hit without a
+// query. The two entries are accounted and evicted independently.
+type articleCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	curBytes int64
+	items    map[string]*list.Element
+	order    *list.List // front = most recently used
+
+	hits   int64
+	misses int64
+}
+
+const defaultArticleCacheMaxBytes = 16 * 1024 * 1024 // 16 MiB
+
+// newArticleCache builds an articleCache bounded to maxBytes of cached
+// article content, evicting the least recently used entries once over
+// budget. maxBytes <= 0 falls back to defaultArticleCacheMaxBytes.
+func newArticleCache(maxBytes int64) *articleCache {
+	if maxBytes <= 0 {
+		maxBytes = defaultArticleCacheMaxBytes
+	}
+	return &articleCache{
+		maxBytes: maxBytes,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func articleCacheKeyByID(id string) string  { return "article:" + id }
+func articleCacheKeyByURL(url string) string { return "article:url:" + url }
+
+// articleSize estimates article's cached footprint from its variable-length
+// fields; good enough for an eviction budget without reflecting over the
+// struct.
+func articleSize(article *database.Article) int64 {
+	return int64(len(article.Title) + len(article.Content) + len(article.URL) + len(article.Category) + len(article.ContentHash))
+}
+
+func (c *articleCache) get(key string) (database.Article, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return database.Article{}, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(el)
+	return el.Value.(*articleCacheElement).article, true
+}
+
+func (c *articleCache) set(key string, article database.Article) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	size := articleSize(&article)
+
+	if el, ok := c.items[key]; ok {
+		c.curBytes += size - el.Value.(*articleCacheElement).size
+		el.Value.(*articleCacheElement).article = article
+		el.Value.(*articleCacheElement).size = size
+		c.order.MoveToFront(el)
+	} else {
+		el := c.order.PushFront(&articleCacheElement{key: key, article: article, size: size})
+		c.items[key] = el
+		c.curBytes += size
+	}
+
+	for c.curBytes > c.maxBytes {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.evict(oldest)
+	}
+}
+
+// invalidate drops every key passed, if present. Safe to call with keys
+// that aren't cached.
+func (c *articleCache) invalidate(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.items[key]; ok {
+			c.evict(el)
+		}
+	}
+}
+
+// evict removes el from both the LRU list and the lookup map; callers must
+// hold c.mu.
+func (c *articleCache) evict(el *list.Element) {
+	c.order.Remove(el)
+	entry := el.Value.(*articleCacheElement)
+	delete(c.items, entry.key)
+	c.curBytes -= entry.size
+}
+
+// ArticleCacheStats reports an articleCache's hit/miss counts and current
+// size, surfaced on health.DatabaseHealth so operators can see the cache's
+// effect.
+type ArticleCacheStats struct {
+	Hits     int64 `json:"hits"`
+	Misses   int64 `json:"misses"`
+	Bytes    int64 `json:"bytes"`
+	MaxBytes int64 `json:"max_bytes"`
+}
+
+func (c *articleCache) stats() ArticleCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ArticleCacheStats{Hits: c.hits, Misses: c.misses, Bytes: c.curBytes, MaxBytes: c.maxBytes}
+}
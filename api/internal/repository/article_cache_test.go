@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"rec-mind/internal/database"
+)
+
+func TestArticleCache_MissThenHit(t *testing.T) {
+	c := newArticleCache(1024)
+	id := uuid.New()
+	article := database.Article{ID: id, Title: "A", Content: "hello", URL: "http://a"}
+
+	_, ok := c.get(articleCacheKeyByID(id.String()))
+	assert.False(t, ok)
+
+	c.set(articleCacheKeyByID(id.String()), article)
+	c.set(articleCacheKeyByURL(article.URL), article)
+
+	byID, ok := c.get(articleCacheKeyByID(id.String()))
+	assert.True(t, ok)
+	assert.Equal(t, "A", byID.Title)
+
+	byURL, ok := c.get(articleCacheKeyByURL(article.URL))
+	assert.True(t, ok)
+	assert.Equal(t, "A", byURL.Title)
+
+	stats := c.stats()
+	assert.Equal(t, int64(2), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+}
+
+func TestArticleCache_EvictsUnderByteBudget(t *testing.T) {
+	// Each article below costs len(Content)+len(URL) = 18 bytes; budget
+	// fits 2 but not 3.
+	c := newArticleCache(40)
+	a := database.Article{ID: uuid.New(), Content: "aaaaaaaaaa", URL: "http://a"}
+	b := database.Article{ID: uuid.New(), Content: "bbbbbbbbbb", URL: "http://b"}
+	d := database.Article{ID: uuid.New(), Content: "dddddddddd", URL: "http://d"}
+
+	c.set(articleCacheKeyByID(a.ID.String()), a)
+	c.set(articleCacheKeyByID(b.ID.String()), b)
+
+	// Touch a so b becomes the least recently used entry.
+	_, _ = c.get(articleCacheKeyByID(a.ID.String()))
+
+	c.set(articleCacheKeyByID(d.ID.String()), d)
+
+	_, aStillCached := c.get(articleCacheKeyByID(a.ID.String()))
+	_, bStillCached := c.get(articleCacheKeyByID(b.ID.String()))
+	_, dCached := c.get(articleCacheKeyByID(d.ID.String()))
+
+	assert.True(t, aStillCached, "recently touched entry should survive eviction")
+	assert.False(t, bStillCached, "least recently used entry should be evicted")
+	assert.True(t, dCached)
+	assert.LessOrEqual(t, c.stats().Bytes, int64(40))
+}
+
+func TestArticleCache_Invalidate(t *testing.T) {
+	c := newArticleCache(1024)
+	article := database.Article{ID: uuid.New(), Content: "hello", URL: "http://a"}
+
+	c.set(articleCacheKeyByID(article.ID.String()), article)
+	c.set(articleCacheKeyByURL(article.URL), article)
+
+	c.invalidate(articleCacheKeyByID(article.ID.String()), articleCacheKeyByURL(article.URL))
+
+	_, byIDCached := c.get(articleCacheKeyByID(article.ID.String()))
+	_, byURLCached := c.get(articleCacheKeyByURL(article.URL))
+	assert.False(t, byIDCached)
+	assert.False(t, byURLCached)
+}
@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pgvector/pgvector-go"
+	"rec-mind/internal/search"
 	"rec-mind/models"
 )
 
@@ -16,36 +19,105 @@ type ArticleChunkRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*models.ArticleChunk, error)
 	GetByArticleID(ctx context.Context, articleID uuid.UUID) ([]*models.ArticleChunk, error)
 	GetByArticleIDAndIndex(ctx context.Context, articleID uuid.UUID, chunkIndex int) (*models.ArticleChunk, error)
+	GetByContentHash(ctx context.Context, hash string) (*models.ArticleChunk, error)
 	List(ctx context.Context, filter *models.ArticleChunkFilter) ([]*models.ArticleChunk, error)
 	Update(ctx context.Context, id uuid.UUID, req *models.UpdateArticleChunkRequest) (*models.ArticleChunk, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteByArticleID(ctx context.Context, articleID uuid.UUID) error
 	Count(ctx context.Context, filter *models.ArticleChunkFilter) (int64, error)
 	CreateBatch(ctx context.Context, chunks []*models.CreateArticleChunkRequest) ([]*models.ArticleChunk, error)
+
+	// UpsertEmbedding stores the pgvector embedding for an already-created
+	// chunk, used once the ML service has generated it asynchronously.
+	UpsertEmbedding(ctx context.Context, id uuid.UUID, embedding []float32) error
+	// CreateBatchWithEmbeddings is CreateBatch plus each chunk's embedding,
+	// for callers that already have vectors in hand and want to avoid a
+	// separate UpsertEmbedding round trip per chunk.
+	CreateBatchWithEmbeddings(ctx context.Context, chunks []*models.CreateArticleChunkRequest, embeddings [][]float32) ([]*models.ArticleChunk, error)
+	// SearchByEmbedding runs an approximate nearest-neighbor search over
+	// every chunk's embedding using the HNSW index, ordered by cosine
+	// similarity.
+	SearchByEmbedding(ctx context.Context, embedding []float32, filter *models.ArticleChunkSearchFilter) ([]*models.ArticleChunkMatch, error)
+	// SearchByEmbeddingWithinArticles is SearchByEmbedding scoped to a
+	// specific set of articles, used to re-rank a candidate set that was
+	// already narrowed down some other way.
+	SearchByEmbeddingWithinArticles(ctx context.Context, embedding []float32, articleIDs []uuid.UUID, filter *models.ArticleChunkSearchFilter) ([]*models.ArticleChunkMatch, error)
+	// SearchHybrid blends cosine similarity with a Postgres full-text rank
+	// on the query text, weighted by filter.Alpha, so a search benefits
+	// from exact keyword matches the embedding alone might rank lower.
+	SearchHybrid(ctx context.Context, embedding []float32, query string, filter *models.ArticleChunkSearchFilter) ([]*models.ArticleChunkMatch, error)
 }
 
 type articleChunkRepository struct {
-	db *pgxpool.Pool
+	db          *pgxpool.Pool
+	searchIndex search.SearchIndex
+}
+
+// NewArticleChunkRepository constructs an ArticleChunkRepository.
+// searchIndex may be nil, in which case writes skip indexing entirely.
+func NewArticleChunkRepository(db *pgxpool.Pool, searchIndex search.SearchIndex) ArticleChunkRepository {
+	return &articleChunkRepository{db: db, searchIndex: searchIndex}
 }
 
-func NewArticleChunkRepository(db *pgxpool.Pool) ArticleChunkRepository {
-	return &articleChunkRepository{db: db}
+// indexChunk best-effort mirrors chunk into the search index: a failure
+// here is logged, not returned, since losing keyword-search freshness for
+// one chunk shouldn't fail the write that's already committed to Postgres.
+func (r *articleChunkRepository) indexChunk(chunk *models.ArticleChunk) {
+	if r.searchIndex == nil {
+		return
+	}
+	if err := r.searchIndex.IndexChunk(chunk); err != nil {
+		log.Printf("⚠️ Failed to index article chunk %s: %v", chunk.ID, err)
+	}
 }
 
 func (r *articleChunkRepository) Create(ctx context.Context, req *models.CreateArticleChunkRequest) (*models.ArticleChunk, error) {
+	hash := models.ChunkContentHash(req.ArticleID.String(), req.Content)
+
+	if existing, err := r.GetByContentHash(ctx, hash); err == nil {
+		existing.Duplicate = true
+		return existing, nil
+	}
+
 	query := `
-		INSERT INTO article_chunks (article_id, chunk_index, content, token_count, character_count)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO article_chunks (article_id, chunk_index, content, token_count, character_count, content_hash)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id, article_id, chunk_index, content, token_count, character_count, created_at`
 
 	var chunk models.ArticleChunk
-	err := r.db.QueryRow(ctx, query, req.ArticleID, req.ChunkIndex, req.Content, req.TokenCount, req.CharacterCount).
+	err := r.db.QueryRow(ctx, query, req.ArticleID, req.ChunkIndex, req.Content, req.TokenCount, req.CharacterCount, hash).
 		Scan(&chunk.ID, &chunk.ArticleID, &chunk.ChunkIndex, &chunk.Content, &chunk.TokenCount, &chunk.CharacterCount, &chunk.CreatedAt)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create article chunk: %w", err)
 	}
 
+	chunk.ContentHash = hash
+	r.indexChunk(&chunk)
+	return &chunk, nil
+}
+
+// GetByContentHash looks up a chunk by its (article_id, normalized content)
+// hash, used to detect duplicate chunk submissions so callers can reuse an
+// already-embedded vector instead of re-embedding identical text.
+func (r *articleChunkRepository) GetByContentHash(ctx context.Context, hash string) (*models.ArticleChunk, error) {
+	query := `
+		SELECT id, article_id, chunk_index, content, token_count, character_count, pinecone_id, created_at
+		FROM article_chunks
+		WHERE content_hash = $1`
+
+	var chunk models.ArticleChunk
+	err := r.db.QueryRow(ctx, query, hash).
+		Scan(&chunk.ID, &chunk.ArticleID, &chunk.ChunkIndex, &chunk.Content, &chunk.TokenCount, &chunk.CharacterCount, &chunk.PineconeID, &chunk.CreatedAt)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("article chunk not found")
+		}
+		return nil, fmt.Errorf("failed to get article chunk by content hash: %w", err)
+	}
+
+	chunk.ContentHash = hash
 	return &chunk, nil
 }
 
@@ -206,6 +278,7 @@ func (r *articleChunkRepository) Update(ctx context.Context, id uuid.UUID, req *
 		return nil, fmt.Errorf("failed to update article chunk: %w", err)
 	}
 
+	r.indexChunk(&chunk)
 	return &chunk, nil
 }
 
@@ -221,10 +294,18 @@ func (r *articleChunkRepository) Delete(ctx context.Context, id uuid.UUID) error
 		return fmt.Errorf("article chunk not found")
 	}
 
+	if r.searchIndex != nil {
+		if err := r.searchIndex.Remove(id); err != nil {
+			log.Printf("⚠️ Failed to remove article chunk %s from search index: %v", id, err)
+		}
+	}
+
 	return nil
 }
 
 func (r *articleChunkRepository) DeleteByArticleID(ctx context.Context, articleID uuid.UUID) error {
+	chunks, lookupErr := r.GetByArticleID(ctx, articleID)
+
 	query := `DELETE FROM article_chunks WHERE article_id = $1`
 
 	_, err := r.db.Exec(ctx, query, articleID)
@@ -232,6 +313,14 @@ func (r *articleChunkRepository) DeleteByArticleID(ctx context.Context, articleI
 		return fmt.Errorf("failed to delete article chunks by article ID: %w", err)
 	}
 
+	if r.searchIndex != nil && lookupErr == nil {
+		for _, chunk := range chunks {
+			if err := r.searchIndex.Remove(chunk.ID); err != nil {
+				log.Printf("⚠️ Failed to remove article chunk %s from search index: %v", chunk.ID, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -272,21 +361,123 @@ func (r *articleChunkRepository) CreateBatch(ctx context.Context, chunks []*mode
 	}
 	defer tx.Rollback(ctx)
 
-	query := `
-		INSERT INTO article_chunks (article_id, chunk_index, content, token_count, character_count)
-		VALUES ($1, $2, $3, $4, $5)
+	lookupQuery := `
+		SELECT id, article_id, chunk_index, content, token_count, character_count, pinecone_id, created_at
+		FROM article_chunks
+		WHERE content_hash = $1`
+
+	insertQuery := `
+		INSERT INTO article_chunks (article_id, chunk_index, content, token_count, character_count, content_hash)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id, article_id, chunk_index, content, token_count, character_count, created_at`
 
 	var results []*models.ArticleChunk
 	for _, chunk := range chunks {
+		hash := models.ChunkContentHash(chunk.ArticleID.String(), chunk.Content)
+
+		var existing models.ArticleChunk
+		err := tx.QueryRow(ctx, lookupQuery, hash).Scan(&existing.ID, &existing.ArticleID, &existing.ChunkIndex,
+			&existing.Content, &existing.TokenCount, &existing.CharacterCount, &existing.PineconeID, &existing.CreatedAt)
+		if err == nil {
+			existing.ContentHash = hash
+			existing.Duplicate = true
+			results = append(results, &existing)
+			continue
+		}
+		if err != pgx.ErrNoRows {
+			return nil, fmt.Errorf("failed to check chunk content hash in batch: %w", err)
+		}
+
 		var result models.ArticleChunk
-		err := tx.QueryRow(ctx, query, chunk.ArticleID, chunk.ChunkIndex, chunk.Content, chunk.TokenCount, chunk.CharacterCount).
+		err = tx.QueryRow(ctx, insertQuery, chunk.ArticleID, chunk.ChunkIndex, chunk.Content, chunk.TokenCount, chunk.CharacterCount, hash).
 			Scan(&result.ID, &result.ArticleID, &result.ChunkIndex, &result.Content, &result.TokenCount, &result.CharacterCount, &result.CreatedAt)
 
 		if err != nil {
 			return nil, fmt.Errorf("failed to create article chunk in batch: %w", err)
 		}
 
+		result.ContentHash = hash
+		results = append(results, &result)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for _, result := range results {
+		if !result.Duplicate {
+			r.indexChunk(result)
+		}
+	}
+
+	return results, nil
+}
+
+func (r *articleChunkRepository) UpsertEmbedding(ctx context.Context, id uuid.UUID, embedding []float32) error {
+	query := `UPDATE article_chunks SET embedding = $1 WHERE id = $2`
+
+	result, err := r.db.Exec(ctx, query, pgvector.NewVector(embedding), id)
+	if err != nil {
+		return fmt.Errorf("failed to upsert article chunk embedding: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("article chunk not found")
+	}
+
+	return nil
+}
+
+func (r *articleChunkRepository) CreateBatchWithEmbeddings(ctx context.Context, chunks []*models.CreateArticleChunkRequest, embeddings [][]float32) ([]*models.ArticleChunk, error) {
+	if len(chunks) != len(embeddings) {
+		return nil, fmt.Errorf("chunks and embeddings must be the same length, got %d and %d", len(chunks), len(embeddings))
+	}
+	if len(chunks) == 0 {
+		return []*models.ArticleChunk{}, nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	lookupQuery := `
+		SELECT id, article_id, chunk_index, content, token_count, character_count, pinecone_id, created_at
+		FROM article_chunks
+		WHERE content_hash = $1`
+
+	insertQuery := `
+		INSERT INTO article_chunks (article_id, chunk_index, content, token_count, character_count, content_hash, embedding)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, article_id, chunk_index, content, token_count, character_count, created_at`
+
+	var results []*models.ArticleChunk
+	for i, chunk := range chunks {
+		hash := models.ChunkContentHash(chunk.ArticleID.String(), chunk.Content)
+
+		var existing models.ArticleChunk
+		err := tx.QueryRow(ctx, lookupQuery, hash).Scan(&existing.ID, &existing.ArticleID, &existing.ChunkIndex,
+			&existing.Content, &existing.TokenCount, &existing.CharacterCount, &existing.PineconeID, &existing.CreatedAt)
+		if err == nil {
+			existing.ContentHash = hash
+			existing.Duplicate = true
+			results = append(results, &existing)
+			continue
+		}
+		if err != pgx.ErrNoRows {
+			return nil, fmt.Errorf("failed to check chunk content hash in batch: %w", err)
+		}
+
+		var result models.ArticleChunk
+		err = tx.QueryRow(ctx, insertQuery, chunk.ArticleID, chunk.ChunkIndex, chunk.Content, chunk.TokenCount, chunk.CharacterCount, hash, pgvector.NewVector(embeddings[i])).
+			Scan(&result.ID, &result.ArticleID, &result.ChunkIndex, &result.Content, &result.TokenCount, &result.CharacterCount, &result.CreatedAt)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to create article chunk with embedding in batch: %w", err)
+		}
+
+		result.ContentHash = hash
 		results = append(results, &result)
 	}
 
@@ -294,5 +485,124 @@ func (r *articleChunkRepository) CreateBatch(ctx context.Context, chunks []*mode
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	for _, result := range results {
+		if !result.Duplicate {
+			r.indexChunk(result)
+		}
+	}
+
 	return results, nil
+}
+
+func (r *articleChunkRepository) SearchByEmbedding(ctx context.Context, embedding []float32, filter *models.ArticleChunkSearchFilter) ([]*models.ArticleChunkMatch, error) {
+	return r.searchByEmbedding(ctx, embedding, nil, filter)
+}
+
+func (r *articleChunkRepository) SearchByEmbeddingWithinArticles(ctx context.Context, embedding []float32, articleIDs []uuid.UUID, filter *models.ArticleChunkSearchFilter) ([]*models.ArticleChunkMatch, error) {
+	if len(articleIDs) == 0 {
+		return []*models.ArticleChunkMatch{}, nil
+	}
+	return r.searchByEmbedding(ctx, embedding, articleIDs, filter)
+}
+
+// searchByEmbedding backs both SearchByEmbedding and
+// SearchByEmbeddingWithinArticles: articleIDs narrows the search to those
+// articles when non-empty, and is otherwise unrestricted.
+func (r *articleChunkRepository) searchByEmbedding(ctx context.Context, embedding []float32, articleIDs []uuid.UUID, filter *models.ArticleChunkSearchFilter) ([]*models.ArticleChunkMatch, error) {
+	filter.SetDefaults()
+	vec := pgvector.NewVector(embedding)
+
+	query := `
+		SELECT id, article_id, chunk_index, content, 1 - (embedding <=> $1) AS score
+		FROM article_chunks
+		WHERE embedding IS NOT NULL`
+
+	args := []interface{}{vec}
+	argIndex := 2
+
+	if len(articleIDs) > 0 {
+		query += fmt.Sprintf(" AND article_id = ANY($%d)", argIndex)
+		args = append(args, articleIDs)
+		argIndex++
+	}
+
+	if filter.ExcludeArticleID != nil {
+		query += fmt.Sprintf(" AND article_id != $%d", argIndex)
+		args = append(args, *filter.ExcludeArticleID)
+		argIndex++
+	}
+
+	query += fmt.Sprintf(" AND 1 - (embedding <=> $1) >= $%d", argIndex)
+	args = append(args, filter.ScoreThreshold)
+	argIndex++
+
+	query += fmt.Sprintf(" ORDER BY embedding <=> $1 LIMIT $%d", argIndex)
+	args = append(args, filter.TopK)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search article chunks by embedding: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []*models.ArticleChunkMatch
+	for rows.Next() {
+		var match models.ArticleChunkMatch
+		var content string
+		if err := rows.Scan(&match.ChunkID, &match.ArticleID, &match.ChunkIndex, &content, &match.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan article chunk match: %w", err)
+		}
+		match.ContentPreview = content
+		matches = append(matches, &match)
+	}
+
+	return matches, nil
+}
+
+func (r *articleChunkRepository) SearchHybrid(ctx context.Context, embedding []float32, query string, filter *models.ArticleChunkSearchFilter) ([]*models.ArticleChunkMatch, error) {
+	filter.SetDefaults()
+	vec := pgvector.NewVector(embedding)
+
+	sqlQuery := `
+		SELECT id, article_id, chunk_index, content, score
+		FROM (
+			SELECT id, article_id, chunk_index, content,
+				$1 * (1 - (embedding <=> $2)) + (1 - $1) * COALESCE(ts_rank(search_vector, plainto_tsquery('english', $3)), 0) AS score
+			FROM article_chunks
+			WHERE embedding IS NOT NULL`
+
+	args := []interface{}{filter.Alpha, vec, query}
+	argIndex := 4
+
+	if filter.ExcludeArticleID != nil {
+		sqlQuery += fmt.Sprintf(" AND article_id != $%d", argIndex)
+		args = append(args, *filter.ExcludeArticleID)
+		argIndex++
+	}
+
+	sqlQuery += fmt.Sprintf(`
+		) scored
+		WHERE score >= $%d
+		ORDER BY score DESC
+		LIMIT $%d`, argIndex, argIndex+1)
+	args = append(args, filter.ScoreThreshold, filter.TopK)
+
+	rows, err := r.db.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hybrid search article chunks: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []*models.ArticleChunkMatch
+	for rows.Next() {
+		var match models.ArticleChunkMatch
+		var content string
+		if err := rows.Scan(&match.ChunkID, &match.ArticleID, &match.ChunkIndex, &content, &match.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan hybrid article chunk match: %w", err)
+		}
+		match.ContentPreview = content
+		matches = append(matches, &match)
+	}
+
+	return matches, nil
 }
\ No newline at end of file
@@ -60,8 +60,8 @@ func setupTestDBForChunks(t *testing.T) (*database.DB, ArticleRepository, Articl
 		t.Fatalf("Failed to create test tables: %v", err)
 	}
 
-	articleRepo := NewArticleRepository(db.Pool)
-	chunkRepo := NewArticleChunkRepository(db.Pool)
+	articleRepo := NewArticleRepository(db.Pool, nil, cfg.ArticleCacheMaxBytes)
+	chunkRepo := NewArticleChunkRepository(db.Pool, nil)
 	return db, articleRepo, chunkRepo
 }
 
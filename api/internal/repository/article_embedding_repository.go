@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"rec-mind/internal/mlclient"
+)
+
+// ArticleEmbeddingRepository fetches article-level embeddings for
+// consumers, like MMR re-ranking, that need to compare articles to each
+// other rather than to a search query.
+type ArticleEmbeddingRepository interface {
+	// GetCentroidEmbeddings returns each article's centroid embedding: the
+	// mean of its chunk vectors in the vector store. Articles the vector
+	// store has no vectors for are simply absent from the result.
+	GetCentroidEmbeddings(ctx context.Context, articleIDs []uuid.UUID) (map[uuid.UUID][]float64, error)
+}
+
+type articleEmbeddingRepository struct {
+	mlClient *mlclient.MLClient
+}
+
+// NewArticleEmbeddingRepository wraps the ML service client so callers can
+// depend on the repository interface rather than mlclient directly.
+func NewArticleEmbeddingRepository(mlClient *mlclient.MLClient) ArticleEmbeddingRepository {
+	return &articleEmbeddingRepository{mlClient: mlClient}
+}
+
+func (r *articleEmbeddingRepository) GetCentroidEmbeddings(ctx context.Context, articleIDs []uuid.UUID) (map[uuid.UUID][]float64, error) {
+	centroids, err := r.mlClient.FetchCentroidEmbeddings(ctx, articleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch centroid embeddings: %w", err)
+	}
+
+	return centroids, nil
+}
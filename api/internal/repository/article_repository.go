@@ -3,43 +3,115 @@ package repository
 import (
 	"context"
 	"fmt"
-	"strings"
+	"log"
 	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/owenHochwald/rec-mind-api/internal/database"
+
+	"rec-mind/internal/database"
+	"rec-mind/internal/search"
+	"rec-mind/models"
+	"rec-mind/mq"
 )
 
+// sqBuilder is the shared squirrel builder for every dynamic query this
+// repository composes (List, Count, Update), configured for Postgres's
+// $N placeholders instead of squirrel's default "?".
+var sqBuilder = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
 type ArticleRepository interface {
 	Create(ctx context.Context, req *database.CreateArticleRequest) (*database.Article, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*database.Article, error)
+	// GetByIDs batches a set of lookups into one query, instead of one
+	// round-trip per ID. Missing IDs are simply absent from the result map
+	// rather than an error, since a caller enriching a batch of
+	// recommendations expects some articles to have been deleted.
+	GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*database.Article, error)
 	GetByURL(ctx context.Context, url string) (*database.Article, error)
+	GetByContentHash(ctx context.Context, hash string) (*database.Article, error)
+	ExistsByContentHash(ctx context.Context, hash string) (bool, error)
+	// FindNearDuplicates returns articles whose SimHash is within
+	// hammingDistance bits of simhash, for catching syndicated copies and
+	// mirrors that content_hash's exact match misses.
+	FindNearDuplicates(ctx context.Context, simhash uint64, hammingDistance int) ([]*database.Article, error)
 	List(ctx context.Context, filter *database.ArticleFilter) ([]*database.Article, error)
 	Update(ctx context.Context, id uuid.UUID, req *database.UpdateArticleRequest) (*database.Article, error)
+	// Delete soft-deletes an article by setting deleted_at; see Restore to
+	// undo it.
 	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteBatch(ctx context.Context, ids []uuid.UUID) error
+	// Restore clears deleted_at on a soft-deleted article, making it visible
+	// again to every default (IncludeDeleted-unset) read.
+	Restore(ctx context.Context, id uuid.UUID) error
 	Count(ctx context.Context, filter *database.ArticleFilter) (int64, error)
 	GetByCategory(ctx context.Context, category string, limit int) ([]*database.Article, error)
+	// GetByCategorySlug looks up articles by their category's slug (joining
+	// through articles.category_id), paginated by limit/offset, for callers
+	// migrating off the exact-string GetByCategory match.
+	GetByCategorySlug(ctx context.Context, slug string, limit, offset int) ([]*database.Article, error)
+	// CountByCategory counts live articles whose category_id resolves to
+	// slug, for paginating GetByCategorySlug's results.
+	CountByCategory(ctx context.Context, slug string) (int64, error)
 	GetRecent(ctx context.Context, limit int) ([]*database.Article, error)
+	// CacheStats reports the in-process article cache's hit/miss counts and
+	// size, for surfacing on health.DatabaseHealth.
+	CacheStats() ArticleCacheStats
 }
 
 type articleRepository struct {
-	db *pgxpool.Pool
+	db          *pgxpool.Pool
+	searchIndex search.SearchIndex
+	cache       *articleCache
+}
+
+// NewArticleRepository constructs an ArticleRepository. searchIndex may be
+// nil, in which case writes skip indexing entirely (e.g. local dev without
+// a Bleve index configured). cacheMaxBytes bounds the in-process article
+// cache kept in front of GetByID/GetByURL; <= 0 falls back to
+// defaultArticleCacheMaxBytes.
+func NewArticleRepository(db *pgxpool.Pool, searchIndex search.SearchIndex, cacheMaxBytes int64) ArticleRepository {
+	return &articleRepository{db: db, searchIndex: searchIndex, cache: newArticleCache(cacheMaxBytes)}
+}
+
+func (r *articleRepository) CacheStats() ArticleCacheStats {
+	return r.cache.stats()
 }
 
-func NewArticleRepository(db *pgxpool.Pool) ArticleRepository {
-	return &articleRepository{db: db}
+// indexArticle best-effort mirrors article into the search index: a
+// failure here is logged, not returned, since losing keyword-search
+// freshness for one article shouldn't fail the write that's already
+// committed to Postgres.
+func (r *articleRepository) indexArticle(article *database.Article) {
+	if r.searchIndex == nil {
+		return
+	}
+	if err := r.searchIndex.Index(article); err != nil {
+		log.Printf("⚠️ Failed to index article %s: %v", article.ID, err)
+	}
 }
 
 func (r *articleRepository) Create(ctx context.Context, req *database.CreateArticleRequest) (*database.Article, error) {
+	hash := models.ContentHash(req.Content)
+
+	if existing, err := r.GetByContentHash(ctx, hash); err == nil {
+		existing.Duplicate = true
+		return existing, nil
+	}
+
+	simhash := models.SimHash(req.Content)
+	bands := models.SimHashBands(simhash)
+
 	query := `
-		INSERT INTO articles (title, content, url, category, published_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO articles (title, content, url, category, published_at, content_hash, simhash, simhash_band0, simhash_band1, simhash_band2, simhash_band3)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id, title, content, url, category, published_at, created_at, updated_at`
 
 	var article database.Article
-	err := r.db.QueryRow(ctx, query, req.Title, req.Content, req.URL, req.Category, req.PublishedAt).
+	err := r.db.QueryRow(ctx, query, req.Title, req.Content, req.URL, req.Category, req.PublishedAt, hash,
+		int64(simhash), int32(bands[0]), int32(bands[1]), int32(bands[2]), int32(bands[3])).
 		Scan(&article.ID, &article.Title, &article.Content, &article.URL, &article.Category,
 			&article.PublishedAt, &article.CreatedAt, &article.UpdatedAt)
 
@@ -47,17 +119,73 @@ func (r *articleRepository) Create(ctx context.Context, req *database.CreateArti
 		return nil, fmt.Errorf("failed to create article: %w", err)
 	}
 
+	article.ContentHash = hash
+	article.SimHash = simhash
+	r.indexArticle(&article)
 	return &article, nil
 }
 
-func (r *articleRepository) GetByID(ctx context.Context, id uuid.UUID) (*database.Article, error) {
+// ExistsByContentHash reports whether an article with hash already exists,
+// for the scraper to short-circuit on an exact content match before it
+// even bothers computing a SimHash for near-duplicate detection.
+func (r *articleRepository) ExistsByContentHash(ctx context.Context, hash string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM articles WHERE content_hash = $1)`, hash).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check content hash existence: %w", err)
+	}
+	return exists, nil
+}
+
+// FindNearDuplicates looks up candidate articles by banded SimHash match
+// (any of the 4 bands equal) and then filters to those genuinely within
+// hammingDistance bits, since a shared band is necessary but not
+// sufficient for near-duplication.
+func (r *articleRepository) FindNearDuplicates(ctx context.Context, simhash uint64, hammingDistance int) ([]*database.Article, error) {
+	bands := models.SimHashBands(simhash)
+
+	query := `
+		SELECT id, title, content, url, category, published_at, created_at, updated_at, content_hash, simhash
+		FROM articles
+		WHERE simhash_band0 = $1 OR simhash_band1 = $2 OR simhash_band2 = $3 OR simhash_band3 = $4`
+
+	rows, err := r.db.Query(ctx, query, int32(bands[0]), int32(bands[1]), int32(bands[2]), int32(bands[3]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query near-duplicate candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []*database.Article
+	for rows.Next() {
+		var article database.Article
+		var candidateHash int64
+		if err := rows.Scan(&article.ID, &article.Title, &article.Content, &article.URL, &article.Category,
+			&article.PublishedAt, &article.CreatedAt, &article.UpdatedAt, &article.ContentHash, &candidateHash); err != nil {
+			return nil, fmt.Errorf("failed to scan near-duplicate candidate: %w", err)
+		}
+
+		article.SimHash = uint64(candidateHash)
+		if models.HammingDistance(simhash, article.SimHash) <= hammingDistance {
+			matches = append(matches, &article)
+		}
+	}
+
+	return matches, rows.Err()
+}
+
+// GetByContentHash looks up an article by its normalized-content SHA-256
+// hash, the key behind content-addressable dedup: an upload whose content
+// matches an existing article byte-for-byte (after whitespace/case
+// normalization) is reported back as a duplicate instead of creating a new
+// row.
+func (r *articleRepository) GetByContentHash(ctx context.Context, hash string) (*database.Article, error) {
 	query := `
 		SELECT id, title, content, url, category, published_at, created_at, updated_at
 		FROM articles
-		WHERE id = $1`
+		WHERE content_hash = $1`
 
 	var article database.Article
-	err := r.db.QueryRow(ctx, query, id).
+	err := r.db.QueryRow(ctx, query, hash).
 		Scan(&article.ID, &article.Title, &article.Content, &article.URL, &article.Category,
 			&article.PublishedAt, &article.CreatedAt, &article.UpdatedAt)
 
@@ -65,20 +193,25 @@ func (r *articleRepository) GetByID(ctx context.Context, id uuid.UUID) (*databas
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("article not found")
 		}
-		return nil, fmt.Errorf("failed to get article: %w", err)
+		return nil, fmt.Errorf("failed to get article by content hash: %w", err)
 	}
 
+	article.ContentHash = hash
 	return &article, nil
 }
 
-func (r *articleRepository) GetByURL(ctx context.Context, url string) (*database.Article, error) {
+func (r *articleRepository) GetByID(ctx context.Context, id uuid.UUID) (*database.Article, error) {
+	if cached, ok := r.cache.get(articleCacheKeyByID(id.String())); ok {
+		return &cached, nil
+	}
+
 	query := `
 		SELECT id, title, content, url, category, published_at, created_at, updated_at
 		FROM articles
-		WHERE url = $1`
+		WHERE id = $1 AND deleted_at IS NULL`
 
 	var article database.Article
-	err := r.db.QueryRow(ctx, query, url).
+	err := r.db.QueryRow(ctx, query, id).
 		Scan(&article.ID, &article.Title, &article.Content, &article.URL, &article.Category,
 			&article.PublishedAt, &article.CreatedAt, &article.UpdatedAt)
 
@@ -86,54 +219,99 @@ func (r *articleRepository) GetByURL(ctx context.Context, url string) (*database
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("article not found")
 		}
-		return nil, fmt.Errorf("failed to get article by URL: %w", err)
+		return nil, fmt.Errorf("failed to get article: %w", err)
 	}
 
+	r.cacheArticle(&article)
 	return &article, nil
 }
 
-func (r *articleRepository) List(ctx context.Context, filter *database.ArticleFilter) ([]*database.Article, error) {
-	filter.SetDefaults()
+// cacheArticle populates both of articleCache's keys for article, so a
+// later lookup by either ID or URL can hit.
+func (r *articleRepository) cacheArticle(article *database.Article) {
+	r.cache.set(articleCacheKeyByID(article.ID.String()), *article)
+	r.cache.set(articleCacheKeyByURL(article.URL), *article)
+}
+
+// uncacheArticle drops article from both of articleCache's keys, used by
+// Update/Delete so a stale row can't be served after a write.
+func (r *articleRepository) uncacheArticle(id uuid.UUID, url string) {
+	r.cache.invalidate(articleCacheKeyByID(id.String()), articleCacheKeyByURL(url))
+}
+
+// GetByIDs issues a single WHERE id = ANY($1) query for every ID in ids,
+// rather than the N round-trips GetByID would take called in a loop.
+func (r *articleRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*database.Article, error) {
+	if len(ids) == 0 {
+		return map[uuid.UUID]*database.Article{}, nil
+	}
 
 	query := `
 		SELECT id, title, content, url, category, published_at, created_at, updated_at
-		FROM articles`
-
-	var conditions []string
-	var args []interface{}
-	argIndex := 1
+		FROM articles
+		WHERE id = ANY($1) AND deleted_at IS NULL`
 
-	if filter.Category != nil {
-		conditions = append(conditions, fmt.Sprintf("category = $%d", argIndex))
-		args = append(args, *filter.Category)
-		argIndex++
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get articles by ids: %w", err)
 	}
+	defer rows.Close()
 
-	if filter.StartDate != nil {
-		conditions = append(conditions, fmt.Sprintf("published_at >= $%d", argIndex))
-		args = append(args, *filter.StartDate)
-		argIndex++
+	articles := make(map[uuid.UUID]*database.Article, len(ids))
+	for rows.Next() {
+		var article database.Article
+		if err := rows.Scan(&article.ID, &article.Title, &article.Content, &article.URL,
+			&article.Category, &article.PublishedAt, &article.CreatedAt, &article.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+		articles[article.ID] = &article
 	}
 
-	if filter.EndDate != nil {
-		conditions = append(conditions, fmt.Sprintf("published_at <= $%d", argIndex))
-		args = append(args, *filter.EndDate)
-		argIndex++
-	}
+	return articles, nil
+}
 
-	if filter.SearchTerm != nil && *filter.SearchTerm != "" {
-		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR content ILIKE $%d)", argIndex, argIndex))
-		args = append(args, "%"+*filter.SearchTerm+"%")
-		argIndex++
+func (r *articleRepository) GetByURL(ctx context.Context, url string) (*database.Article, error) {
+	if cached, ok := r.cache.get(articleCacheKeyByURL(url)); ok {
+		return &cached, nil
 	}
 
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+	query := `
+		SELECT id, title, content, url, category, published_at, created_at, updated_at
+		FROM articles
+		WHERE url = $1`
+
+	var article database.Article
+	err := r.db.QueryRow(ctx, query, url).
+		Scan(&article.ID, &article.Title, &article.Content, &article.URL, &article.Category,
+			&article.PublishedAt, &article.CreatedAt, &article.UpdatedAt)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("article not found")
+		}
+		return nil, fmt.Errorf("failed to get article by URL: %w", err)
 	}
 
-	query += " ORDER BY published_at DESC"
-	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
-	args = append(args, filter.Limit, filter.Offset)
+	r.cacheArticle(&article)
+	return &article, nil
+}
+
+func (r *articleRepository) List(ctx context.Context, filter *database.ArticleFilter) ([]*database.Article, error) {
+	filter.SetDefaults()
+
+	builder := buildArticleFilter(sqBuilder.Select(
+		"id", "title", "content", "url", "category", "published_at", "created_at", "updated_at",
+	).From("articles"), filter)
+
+	builder = builder.
+		OrderBy(fmt.Sprintf("%s %s", sqSortBy(filter.SortBy), sqSortDir(filter.SortDir))).
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset))
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list query: %w", err)
+	}
 
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
@@ -156,59 +334,52 @@ func (r *articleRepository) List(ctx context.Context, filter *database.ArticleFi
 }
 
 func (r *articleRepository) Update(ctx context.Context, id uuid.UUID, req *database.UpdateArticleRequest) (*database.Article, error) {
-	var setParts []string
-	var args []interface{}
-	argIndex := 1
+	builder := sqBuilder.Update("articles")
+	changed := false
 
 	if req.Title != nil {
-		setParts = append(setParts, fmt.Sprintf("title = $%d", argIndex))
-		args = append(args, *req.Title)
-		argIndex++
+		builder = builder.Set("title", *req.Title)
+		changed = true
 	}
-
 	if req.Content != nil {
-		setParts = append(setParts, fmt.Sprintf("content = $%d", argIndex))
-		args = append(args, *req.Content)
-		argIndex++
+		builder = builder.Set("content", *req.Content)
+		changed = true
 	}
-
 	if req.URL != nil {
-		setParts = append(setParts, fmt.Sprintf("url = $%d", argIndex))
-		args = append(args, *req.URL)
-		argIndex++
+		builder = builder.Set("url", *req.URL)
+		changed = true
 	}
-
 	if req.Category != nil {
-		setParts = append(setParts, fmt.Sprintf("category = $%d", argIndex))
-		args = append(args, *req.Category)
-		argIndex++
+		builder = builder.Set("category", *req.Category)
+		changed = true
 	}
-
 	if req.PublishedAt != nil {
-		setParts = append(setParts, fmt.Sprintf("published_at = $%d", argIndex))
-		args = append(args, *req.PublishedAt)
-		argIndex++
+		builder = builder.Set("published_at", *req.PublishedAt)
+		changed = true
 	}
 
-	if len(setParts) == 0 {
+	if !changed {
 		return r.GetByID(ctx, id)
 	}
 
-	setParts = append(setParts, fmt.Sprintf("updated_at = $%d", argIndex))
-	args = append(args, time.Now())
-	argIndex++
-
-	query := fmt.Sprintf(`
-		UPDATE articles
-		SET %s
-		WHERE id = $%d
-		RETURNING id, title, content, url, category, published_at, created_at, updated_at`,
-		strings.Join(setParts, ", "), argIndex)
+	// Invalidate the cache under the pre-update URL too, in case req.URL
+	// changes it; the post-update cacheArticle call below re-populates
+	// under the new URL.
+	if cached, ok := r.cache.get(articleCacheKeyByID(id.String())); ok {
+		r.uncacheArticle(id, cached.URL)
+	}
 
-	args = append(args, id)
+	query, args, err := builder.
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": id}).
+		Suffix("RETURNING id, title, content, url, category, published_at, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build update query: %w", err)
+	}
 
 	var article database.Article
-	err := r.db.QueryRow(ctx, query, args...).
+	err = r.db.QueryRow(ctx, query, args...).
 		Scan(&article.ID, &article.Title, &article.Content, &article.URL, &article.Category,
 			&article.PublishedAt, &article.CreatedAt, &article.UpdatedAt)
 
@@ -219,73 +390,162 @@ func (r *articleRepository) Update(ctx context.Context, id uuid.UUID, req *datab
 		return nil, fmt.Errorf("failed to update article: %w", err)
 	}
 
+	r.cacheArticle(&article)
+	r.indexArticle(&article)
 	return &article, nil
 }
 
+// Delete soft-deletes an article by setting deleted_at instead of removing
+// its row, so a chunk or recommendation still referencing it can be
+// reconciled rather than hitting a hard foreign-key gap. It publishes an
+// article.deleted event (best-effort, like indexArticle) so the
+// chunk/embedding worker can purge the article's vectors and secondary-index
+// document asynchronously.
 func (r *articleRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM articles WHERE id = $1`
+	query := `UPDATE articles SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL RETURNING url`
 
-	result, err := r.db.Exec(ctx, query, id)
+	var url string
+	err := r.db.QueryRow(ctx, query, id).Scan(&url)
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("article not found")
+		}
 		return fmt.Errorf("failed to delete article: %w", err)
 	}
 
+	r.uncacheArticle(id, url)
+
+	if r.searchIndex != nil {
+		if err := r.searchIndex.Remove(id); err != nil {
+			log.Printf("⚠️ Failed to remove article %s from search index: %v", id, err)
+		}
+	}
+
+	if err := mq.PublishArticleDeleted(models.ArticleDeletedEvent{ArticleID: id.String(), DeletedAt: time.Now()}); err != nil {
+		log.Printf("⚠️ Failed to publish article.deleted event for %s: %v", id, err)
+	}
+
+	return nil
+}
+
+// Restore clears deleted_at on a soft-deleted article, making it visible
+// again to every default read path.
+func (r *articleRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE articles SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore article: %w", err)
+	}
+
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("article not found")
+		return fmt.Errorf("article not found or not deleted")
+	}
+
+	return nil
+}
+
+// DeleteBatch removes every article in ids in a single statement so a bulk
+// deletion doesn't round-trip once per article.
+func (r *articleRepository) DeleteBatch(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `DELETE FROM articles WHERE id = ANY($1)`
+
+	_, err := r.db.Exec(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to delete articles: %w", err)
+	}
+
+	if r.searchIndex != nil {
+		for _, id := range ids {
+			if err := r.searchIndex.Remove(id); err != nil {
+				log.Printf("⚠️ Failed to remove article %s from search index: %v", id, err)
+			}
+		}
 	}
 
 	return nil
 }
 
 func (r *articleRepository) Count(ctx context.Context, filter *database.ArticleFilter) (int64, error) {
-	query := "SELECT COUNT(*) FROM articles"
+	builder := buildArticleFilter(sqBuilder.Select("COUNT(*)").From("articles"), filter)
 
-	var conditions []string
-	var args []interface{}
-	argIndex := 1
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build count query: %w", err)
+	}
+
+	var count int64
+	err = r.db.QueryRow(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count articles: %w", err)
+	}
+
+	return count, nil
+}
+
+// buildArticleFilter applies filter's conditions to a squirrel
+// SelectBuilder, shared by List and Count (and any future Stream/Export
+// method) so they can't drift on what "matches the filter" means.
+func buildArticleFilter(b sq.SelectBuilder, filter *database.ArticleFilter) sq.SelectBuilder {
+	if !filter.IncludeDeleted {
+		b = b.Where("deleted_at IS NULL")
+	}
 
 	if filter.Category != nil {
-		conditions = append(conditions, fmt.Sprintf("category = $%d", argIndex))
-		args = append(args, *filter.Category)
-		argIndex++
+		b = b.Where(sq.Eq{"category": *filter.Category})
 	}
 
 	if filter.StartDate != nil {
-		conditions = append(conditions, fmt.Sprintf("published_at >= $%d", argIndex))
-		args = append(args, *filter.StartDate)
-		argIndex++
+		b = b.Where(sq.GtOrEq{"published_at": *filter.StartDate})
 	}
 
 	if filter.EndDate != nil {
-		conditions = append(conditions, fmt.Sprintf("published_at <= $%d", argIndex))
-		args = append(args, *filter.EndDate)
-		argIndex++
+		b = b.Where(sq.LtOrEq{"published_at": *filter.EndDate})
 	}
 
 	if filter.SearchTerm != nil && *filter.SearchTerm != "" {
-		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR content ILIKE $%d)", argIndex, argIndex))
-		args = append(args, "%"+*filter.SearchTerm+"%")
-		argIndex++
+		term := "%" + *filter.SearchTerm + "%"
+		b = b.Where(sq.Expr("(title ILIKE ? OR content ILIKE ?)", term, term))
 	}
 
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
+	return b
+}
 
-	var count int64
-	err := r.db.QueryRow(ctx, query, args...).Scan(&count)
-	if err != nil {
-		return 0, fmt.Errorf("failed to count articles: %w", err)
+// sqSortDir renders an ArticleSortDir as the SQL keyword ORDER BY expects.
+// dir is always one of the SortAsc/SortDesc constants set by
+// ArticleFilter.SetDefaults, so this never sees arbitrary caller input.
+func sqSortDir(dir database.ArticleSortDir) string {
+	if dir == database.SortAsc {
+		return "ASC"
 	}
+	return "DESC"
+}
 
-	return count, nil
+// sqSortBy renders an ArticleSortBy as the literal column name ORDER BY
+// expects. sortBy comes straight from a caller-supplied query param, so this
+// maps it through an explicit allowlist rather than interpolating it into
+// the query - anything outside the enum falls back to published_at instead
+// of reaching fmt.Sprintf.
+func sqSortBy(sortBy database.ArticleSortBy) string {
+	switch sortBy {
+	case database.SortByCreatedAt:
+		return "created_at"
+	case database.SortByTitle:
+		return "title"
+	default:
+		return "published_at"
+	}
 }
 
 func (r *articleRepository) GetByCategory(ctx context.Context, category string, limit int) ([]*database.Article, error) {
 	query := `
 		SELECT id, title, content, url, category, published_at, created_at, updated_at
 		FROM articles
-		WHERE category = $1
+		WHERE category = $1 AND deleted_at IS NULL
 		ORDER BY published_at DESC
 		LIMIT $2`
 
@@ -309,10 +569,60 @@ func (r *articleRepository) GetByCategory(ctx context.Context, category string,
 	return articles, nil
 }
 
+// GetByCategorySlug looks up articles by their category's slug, joining
+// articles to categories on category_id. Only live (non-soft-deleted)
+// articles are returned.
+func (r *articleRepository) GetByCategorySlug(ctx context.Context, slug string, limit, offset int) ([]*database.Article, error) {
+	query := `
+		SELECT a.id, a.title, a.content, a.url, a.category, a.published_at, a.created_at, a.updated_at
+		FROM articles a
+		JOIN categories c ON a.category_id = c.id
+		WHERE c.slug = $1 AND a.deleted_at IS NULL
+		ORDER BY a.published_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(ctx, query, slug, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get articles by category slug: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []*database.Article
+	for rows.Next() {
+		var article database.Article
+		err := rows.Scan(&article.ID, &article.Title, &article.Content, &article.URL,
+			&article.Category, &article.PublishedAt, &article.CreatedAt, &article.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+		articles = append(articles, &article)
+	}
+
+	return articles, nil
+}
+
+// CountByCategory counts live articles whose category_id resolves to slug.
+func (r *articleRepository) CountByCategory(ctx context.Context, slug string) (int64, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM articles a
+		JOIN categories c ON a.category_id = c.id
+		WHERE c.slug = $1 AND a.deleted_at IS NULL`
+
+	var count int64
+	err := r.db.QueryRow(ctx, query, slug).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count articles by category slug: %w", err)
+	}
+
+	return count, nil
+}
+
 func (r *articleRepository) GetRecent(ctx context.Context, limit int) ([]*database.Article, error) {
 	query := `
 		SELECT id, title, content, url, category, published_at, created_at, updated_at
 		FROM articles
+		WHERE deleted_at IS NULL
 		ORDER BY published_at DESC
 		LIMIT $1`
 
@@ -40,14 +40,22 @@ func setupTestDB(t *testing.T) (*database.DB, ArticleRepository) {
 			url VARCHAR(1000) UNIQUE NOT NULL,
 			category VARCHAR(100) NOT NULL,
 			created_at TIMESTAMP DEFAULT NOW(),
-			updated_at TIMESTAMP DEFAULT NOW()
+			updated_at TIMESTAMP DEFAULT NOW(),
+			deleted_at TIMESTAMPTZ,
+			published_at TIMESTAMP,
+			content_hash VARCHAR(64),
+			simhash BIGINT,
+			simhash_band0 INTEGER,
+			simhash_band1 INTEGER,
+			simhash_band2 INTEGER,
+			simhash_band3 INTEGER
 		);
 	`)
 	if err != nil {
 		t.Fatalf("Failed to create test table: %v", err)
 	}
 
-	repo := NewArticleRepository(db.Pool)
+	repo := NewArticleRepository(db.Pool, nil, cfg.ArticleCacheMaxBytes)
 	return db, repo
 }
 
@@ -148,6 +156,51 @@ func TestArticleRepository_GetByID_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "article not found")
 }
 
+func TestArticleRepository_GetByIDs(t *testing.T) {
+	db, repo := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer cleanupTestDB(t, db)
+
+	first, err := repo.Create(context.Background(), &database.CreateArticleRequest{
+		Title:    "First Article",
+		Content:  "First content",
+		URL:      "https://example.com/first",
+		Category: "Technology",
+	})
+	require.NoError(t, err)
+
+	second, err := repo.Create(context.Background(), &database.CreateArticleRequest{
+		Title:    "Second Article",
+		Content:  "Second content",
+		URL:      "https://example.com/second",
+		Category: "Technology",
+	})
+	require.NoError(t, err)
+
+	missing := uuid.New()
+	found, err := repo.GetByIDs(context.Background(), []uuid.UUID{first.ID, second.ID, missing})
+	require.NoError(t, err)
+
+	assert.Len(t, found, 2)
+	assert.Equal(t, first.Title, found[first.ID].Title)
+	assert.Equal(t, second.Title, found[second.ID].Title)
+	assert.NotContains(t, found, missing)
+}
+
+func TestArticleRepository_GetByIDs_Empty(t *testing.T) {
+	db, repo := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer cleanupTestDB(t, db)
+
+	found, err := repo.GetByIDs(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
 func TestArticleRepository_GetByURL(t *testing.T) {
 	db, repo := setupTestDB(t)
 	if db == nil {
@@ -300,6 +353,33 @@ func TestArticleRepository_Delete(t *testing.T) {
 	assert.Contains(t, err.Error(), "article not found")
 }
 
+func TestArticleRepository_Restore(t *testing.T) {
+	db, repo := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer cleanupTestDB(t, db)
+
+	req := &database.CreateArticleRequest{
+		Title:    "To Restore",
+		Content:  "Content to restore",
+		URL:      "https://example.com/to-restore",
+		Category: "Technology",
+	}
+
+	created, err := repo.Create(context.Background(), req)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(context.Background(), created.ID))
+
+	err = repo.Restore(context.Background(), created.ID)
+	require.NoError(t, err)
+
+	restored, err := repo.GetByID(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, restored.ID)
+}
+
 func TestArticleRepository_Count(t *testing.T) {
 	db, repo := setupTestDB(t)
 	if db == nil {
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"rec-mind/models"
+	"rec-mind/mq"
+)
+
+// CategoryRepository manages the categories table, giving
+// articles.category_id referential integrity and a stable slug for routing.
+type CategoryRepository interface {
+	Create(ctx context.Context, req *models.CreateCategoryRequest) (*models.Category, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Category, error)
+	List(ctx context.Context, filter *models.CategoryFilter) ([]*models.Category, error)
+	// Update applies req's non-nil fields; changing Name or Slug publishes a
+	// category.renamed event so downstream recommendation models can re-key
+	// their category features without a full re-embed.
+	Update(ctx context.Context, id uuid.UUID, req *models.UpdateCategoryRequest) (*models.Category, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type categoryRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCategoryRepository constructs a CategoryRepository.
+func NewCategoryRepository(db *pgxpool.Pool) CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+// Create inserts a category, deriving its slug from name via models.Slugify
+// when req.Slug is blank, then publishes a category.created event.
+func (r *categoryRepository) Create(ctx context.Context, req *models.CreateCategoryRequest) (*models.Category, error) {
+	slug := req.Slug
+	if slug == "" {
+		slug = models.Slugify(req.Name)
+	}
+
+	query := `
+		INSERT INTO categories (name, slug, description)
+		VALUES ($1, $2, $3)
+		RETURNING id, name, slug, description, created_at`
+
+	var category models.Category
+	err := r.db.QueryRow(ctx, query, req.Name, slug, req.Description).
+		Scan(&category.ID, &category.Name, &category.Slug, &category.Description, &category.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	if err := mq.PublishCategoryCreated(models.CategoryCreatedEvent{
+		CategoryID: category.ID.String(),
+		Slug:       category.Slug,
+		Name:       category.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to publish category.created event: %w", err)
+	}
+
+	return &category, nil
+}
+
+// GetBySlug looks up a category by its routing slug.
+func (r *categoryRepository) GetBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	query := `
+		SELECT id, name, slug, description, created_at
+		FROM categories
+		WHERE slug = $1`
+
+	var category models.Category
+	err := r.db.QueryRow(ctx, query, slug).
+		Scan(&category.ID, &category.Name, &category.Slug, &category.Description, &category.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("category not found")
+		}
+		return nil, fmt.Errorf("failed to get category by slug: %w", err)
+	}
+
+	return &category, nil
+}
+
+// List returns every category ordered by name, paginated by filter.
+func (r *categoryRepository) List(ctx context.Context, filter *models.CategoryFilter) ([]*models.Category, error) {
+	filter.SetDefaults()
+
+	query := `
+		SELECT id, name, slug, description, created_at
+		FROM categories
+		ORDER BY name
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Query(ctx, query, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*models.Category
+	for rows.Next() {
+		var category models.Category
+		if err := rows.Scan(&category.ID, &category.Name, &category.Slug, &category.Description, &category.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		categories = append(categories, &category)
+	}
+
+	return categories, rows.Err()
+}
+
+// Update applies req's non-nil fields to the category identified by id. A
+// change to Name or Slug publishes a category.renamed event after the
+// update commits, so a downstream re-key always sees the new slug.
+func (r *categoryRepository) Update(ctx context.Context, id uuid.UUID, req *models.UpdateCategoryRequest) (*models.Category, error) {
+	existing, err := r.getByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	renamed := (req.Name != nil && *req.Name != existing.Name) || (req.Slug != nil && *req.Slug != existing.Slug)
+
+	var setParts []string
+	var args []interface{}
+	argIndex := 1
+
+	if req.Name != nil {
+		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
+		args = append(args, *req.Name)
+		argIndex++
+	}
+	if req.Slug != nil {
+		setParts = append(setParts, fmt.Sprintf("slug = $%d", argIndex))
+		args = append(args, *req.Slug)
+		argIndex++
+	}
+	if req.Description != nil {
+		setParts = append(setParts, fmt.Sprintf("description = $%d", argIndex))
+		args = append(args, *req.Description)
+		argIndex++
+	}
+
+	if len(setParts) == 0 {
+		return existing, nil
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE categories
+		SET %s
+		WHERE id = $%d
+		RETURNING id, name, slug, description, created_at`,
+		strings.Join(setParts, ", "), argIndex)
+	args = append(args, id)
+
+	var category models.Category
+	err = r.db.QueryRow(ctx, query, args...).
+		Scan(&category.ID, &category.Name, &category.Slug, &category.Description, &category.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("category not found")
+		}
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	if renamed {
+		if err := mq.PublishCategoryRenamed(models.CategoryRenamedEvent{
+			CategoryID: category.ID.String(),
+			OldSlug:    existing.Slug,
+			NewSlug:    category.Slug,
+			Name:       category.Name,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to publish category.renamed event: %w", err)
+		}
+	}
+
+	return &category, nil
+}
+
+// Delete removes a category by id. Articles referencing it via category_id
+// are left alone; the FK has no ON DELETE CASCADE so a dangling reference
+// surfaces as an error rather than silently orphaning articles.
+func (r *categoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM categories WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("category not found")
+	}
+	return nil
+}
+
+func (r *categoryRepository) getByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	query := `
+		SELECT id, name, slug, description, created_at
+		FROM categories
+		WHERE id = $1`
+
+	var category models.Category
+	err := r.db.QueryRow(ctx, query, id).
+		Scan(&category.ID, &category.Name, &category.Slug, &category.Description, &category.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("category not found")
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return &category, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// FeedState tracks conditional-GET bookkeeping and failure backoff for one
+// RSS feed URL, letting the scraper skip an unchanged feed with a 304 and
+// back off a flaky one instead of hammering it every run.
+type FeedState struct {
+	URL           string
+	ETag          string
+	LastModified  string
+	LastFetchedAt time.Time
+	FailureCount  int
+	BackoffUntil  time.Time
+}
+
+// FeedStateRepository persists per-feed conditional-GET and backoff state.
+type FeedStateRepository interface {
+	// Get returns an error if url has never been fetched before; callers
+	// treat that as "no prior state" rather than a real failure.
+	Get(ctx context.Context, url string) (*FeedState, error)
+	Upsert(ctx context.Context, state *FeedState) error
+}
+
+type feedStateRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewFeedStateRepository constructs a FeedStateRepository.
+func NewFeedStateRepository(db *pgxpool.Pool) FeedStateRepository {
+	return &feedStateRepository{db: db}
+}
+
+func (r *feedStateRepository) Get(ctx context.Context, url string) (*FeedState, error) {
+	query := `
+		SELECT url, etag, last_modified, last_fetched_at, failure_count, backoff_until
+		FROM feed_state
+		WHERE url = $1`
+
+	var state FeedState
+	var etag, lastModified *string
+	var lastFetchedAt, backoffUntil *time.Time
+
+	err := r.db.QueryRow(ctx, query, url).
+		Scan(&state.URL, &etag, &lastModified, &lastFetchedAt, &state.FailureCount, &backoffUntil)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("feed state not found for %s", url)
+		}
+		return nil, fmt.Errorf("failed to get feed state: %w", err)
+	}
+
+	if etag != nil {
+		state.ETag = *etag
+	}
+	if lastModified != nil {
+		state.LastModified = *lastModified
+	}
+	if lastFetchedAt != nil {
+		state.LastFetchedAt = *lastFetchedAt
+	}
+	if backoffUntil != nil {
+		state.BackoffUntil = *backoffUntil
+	}
+
+	return &state, nil
+}
+
+func (r *feedStateRepository) Upsert(ctx context.Context, state *FeedState) error {
+	query := `
+		INSERT INTO feed_state (url, etag, last_modified, last_fetched_at, failure_count, backoff_until)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (url) DO UPDATE SET
+			etag = EXCLUDED.etag,
+			last_modified = EXCLUDED.last_modified,
+			last_fetched_at = EXCLUDED.last_fetched_at,
+			failure_count = EXCLUDED.failure_count,
+			backoff_until = EXCLUDED.backoff_until`
+
+	var backoffUntil *time.Time
+	if !state.BackoffUntil.IsZero() {
+		backoffUntil = &state.BackoffUntil
+	}
+
+	_, err := r.db.Exec(ctx, query, state.URL, state.ETag, state.LastModified, state.LastFetchedAt, state.FailureCount, backoffUntil)
+	if err != nil {
+		return fmt.Errorf("failed to upsert feed state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"rec-mind/models"
+)
+
+// JobRepository persists every QuerySearchJob's lifecycle state (status,
+// attempts, timings, tenant, error) in Postgres, so cancel/retry/history
+// APIs have a durable source of truth that survives past the 24h TTL on the
+// query_search_result:<id> Redis key. QueryRAGWorker writes to it as a job
+// moves through queued -> running -> completed/failed/cancelled;
+// SearchController's history/logs endpoints read it back out.
+type JobRepository interface {
+	// Create inserts jobID's row as JobStatusQueued. A second Create for the
+	// same JobID (e.g. a redelivered message) is a no-op, not an error.
+	Create(ctx context.Context, job models.QuerySearchJob) error
+	// MarkRunning bumps attempts and records started_at, called once per
+	// delivery attempt at the start of ProcessQuerySearchJob.
+	MarkRunning(ctx context.Context, jobID string) error
+	// MarkCompleted records a successful finish.
+	MarkCompleted(ctx context.Context, jobID string) error
+	// MarkFailed records a failed finish with errMsg.
+	MarkFailed(ctx context.Context, jobID, errMsg string) error
+	// MarkCancelled records that a cancel request was honored.
+	MarkCancelled(ctx context.Context, jobID string) error
+	// Get returns a single job's record, or nil if jobID is unknown.
+	Get(ctx context.Context, jobID string) (*models.SearchJobRecord, error)
+	// List returns jobs matching filter, most recent first.
+	List(ctx context.Context, filter *models.SearchJobFilter) ([]models.SearchJobRecord, error)
+}
+
+type jobRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewJobRepository constructs a JobRepository.
+func NewJobRepository(db *pgxpool.Pool) JobRepository {
+	return &jobRepository{db: db}
+}
+
+func (r *jobRepository) Create(ctx context.Context, job models.QuerySearchJob) error {
+	query, args, err := sqBuilder.Insert("search_jobs").
+		Columns("job_id", "tenant_id", "session_id", "query", "status", "correlation_id", "created_at", "updated_at").
+		Values(job.JobID, job.TenantID, job.SessionID, job.Query, models.JobStatusQueued, job.CorrelationID, job.CreatedAt, time.Now()).
+		Suffix("ON CONFLICT (job_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build create search job query: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create search job: %w", err)
+	}
+	return nil
+}
+
+// setStatus is shared by MarkRunning/MarkCompleted/MarkFailed/MarkCancelled
+// so they can't drift on which columns a status transition touches.
+func (r *jobRepository) setStatus(ctx context.Context, jobID string, extra func(sq.UpdateBuilder) sq.UpdateBuilder) error {
+	builder := sqBuilder.Update("search_jobs").
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"job_id": jobID})
+	builder = extra(builder)
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build search job update query: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to update search job %s: %w", jobID, err)
+	}
+	return nil
+}
+
+func (r *jobRepository) MarkRunning(ctx context.Context, jobID string) error {
+	return r.setStatus(ctx, jobID, func(b sq.UpdateBuilder) sq.UpdateBuilder {
+		return b.
+			Set("status", models.JobStatusRunning).
+			Set("attempts", sq.Expr("attempts + 1")).
+			Set("started_at", time.Now())
+	})
+}
+
+func (r *jobRepository) MarkCompleted(ctx context.Context, jobID string) error {
+	return r.setStatus(ctx, jobID, func(b sq.UpdateBuilder) sq.UpdateBuilder {
+		return b.
+			Set("status", models.JobStatusCompleted).
+			Set("completed_at", time.Now())
+	})
+}
+
+func (r *jobRepository) MarkFailed(ctx context.Context, jobID, errMsg string) error {
+	return r.setStatus(ctx, jobID, func(b sq.UpdateBuilder) sq.UpdateBuilder {
+		return b.
+			Set("status", models.JobStatusFailed).
+			Set("error", errMsg).
+			Set("completed_at", time.Now())
+	})
+}
+
+func (r *jobRepository) MarkCancelled(ctx context.Context, jobID string) error {
+	return r.setStatus(ctx, jobID, func(b sq.UpdateBuilder) sq.UpdateBuilder {
+		return b.
+			Set("status", models.JobStatusCancelled).
+			Set("completed_at", time.Now())
+	})
+}
+
+var searchJobColumns = []string{
+	"id", "job_id", "tenant_id", "session_id", "query", "status", "attempts",
+	"correlation_id", "error", "created_at", "started_at", "completed_at", "updated_at",
+}
+
+func scanSearchJobRow(row pgx.Row) (*models.SearchJobRecord, error) {
+	var rec models.SearchJobRecord
+	var errMsg *string
+	if err := row.Scan(&rec.ID, &rec.JobID, &rec.TenantID, &rec.SessionID, &rec.Query, &rec.Status, &rec.Attempts,
+		&rec.CorrelationID, &errMsg, &rec.CreatedAt, &rec.StartedAt, &rec.CompletedAt, &rec.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if errMsg != nil {
+		rec.Error = *errMsg
+	}
+	return &rec, nil
+}
+
+func (r *jobRepository) Get(ctx context.Context, jobID string) (*models.SearchJobRecord, error) {
+	query, args, err := sqBuilder.Select(searchJobColumns...).
+		From("search_jobs").
+		Where(sq.Eq{"job_id": jobID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get search job query: %w", err)
+	}
+
+	rec, err := scanSearchJobRow(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get search job %s: %w", jobID, err)
+	}
+	return rec, nil
+}
+
+func (r *jobRepository) List(ctx context.Context, filter *models.SearchJobFilter) ([]models.SearchJobRecord, error) {
+	filter.SetDefaults()
+
+	builder := sqBuilder.Select(searchJobColumns...).From("search_jobs")
+	if filter.Status != nil && *filter.Status != "" {
+		builder = builder.Where(sq.Eq{"status": *filter.Status})
+	}
+	if filter.TenantID != nil && *filter.TenantID != "" {
+		builder = builder.Where(sq.Eq{"tenant_id": *filter.TenantID})
+	}
+	if filter.Since != nil {
+		builder = builder.Where(sq.GtOrEq{"created_at": *filter.Since})
+	}
+
+	query, args, err := builder.
+		OrderBy("created_at DESC").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list search jobs query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list search jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []models.SearchJobRecord{}
+	for rows.Next() {
+		rec, err := scanSearchJobRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search job: %w", err)
+		}
+		jobs = append(jobs, *rec)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"rec-mind/models"
+)
+
+// QueryResultRepository persists completed query search results and their
+// per-article chunk matches, so history survives past Redis's 24h TTL.
+// QueryRAGWorker's archive worker is the only writer; GetHistory serves
+// reads back out for a session.
+type QueryResultRepository interface {
+	// Archive inserts result and one query_result_chunks row per
+	// recommendation in a single transaction.
+	Archive(ctx context.Context, result models.QueryRecommendationResult) error
+	// GetHistory returns up to limit results for sessionID created at or
+	// after since, most recent first.
+	GetHistory(ctx context.Context, sessionID string, since time.Time, limit int) ([]models.QueryRecommendationResult, error)
+}
+
+type queryResultRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewQueryResultRepository constructs a QueryResultRepository.
+func NewQueryResultRepository(db *pgxpool.Pool) QueryResultRepository {
+	return &queryResultRepository{db: db}
+}
+
+func (r *queryResultRepository) Archive(ctx context.Context, result models.QueryRecommendationResult) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertResult := `
+		INSERT INTO query_results (job_id, session_id, query, total_found, processing_time, status, error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	var queryResultID string
+	err = tx.QueryRow(ctx, insertResult, result.JobID, result.SessionID, result.Query, result.TotalFound,
+		result.ProcessingTime, result.Status, result.Error, result.CreatedAt).Scan(&queryResultID)
+	if err != nil {
+		return fmt.Errorf("failed to insert query result: %w", err)
+	}
+
+	insertChunk := `
+		INSERT INTO query_result_chunks (query_result_id, article_id, title, category, url, hybrid_score, max_similarity, avg_similarity, matched_chunks, chunk_matches)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	for _, rec := range result.Recommendations {
+		chunkMatches, err := json.Marshal(rec.ChunkMatches)
+		if err != nil {
+			return fmt.Errorf("failed to marshal chunk matches: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, insertChunk, queryResultID, rec.ArticleID, rec.Title, rec.Category, rec.URL,
+			rec.HybridScore, rec.MaxSimilarity, rec.AvgSimilarity, rec.MatchedChunks, chunkMatches)
+		if err != nil {
+			return fmt.Errorf("failed to insert query result chunk: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *queryResultRepository) GetHistory(ctx context.Context, sessionID string, since time.Time, limit int) ([]models.QueryRecommendationResult, error) {
+	resultsQuery := `
+		SELECT id, job_id, session_id, query, total_found, processing_time, status, error, created_at
+		FROM query_results
+		WHERE session_id = $1 AND created_at >= $2
+		ORDER BY created_at DESC
+		LIMIT $3`
+
+	rows, err := r.db.Query(ctx, resultsQuery, sessionID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query result history: %w", err)
+	}
+	defer rows.Close()
+
+	type row struct {
+		id    string
+		result models.QueryRecommendationResult
+	}
+	var ordered []row
+	for rows.Next() {
+		var rr row
+		var errMsg *string
+		if err := rows.Scan(&rr.id, &rr.result.JobID, &rr.result.SessionID, &rr.result.Query, &rr.result.TotalFound,
+			&rr.result.ProcessingTime, &rr.result.Status, &errMsg, &rr.result.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan query result: %w", err)
+		}
+		if errMsg != nil {
+			rr.result.Error = *errMsg
+		}
+		ordered = append(ordered, rr)
+	}
+
+	if len(ordered) == 0 {
+		return []models.QueryRecommendationResult{}, nil
+	}
+
+	chunksQuery := `
+		SELECT query_result_id, article_id, title, category, url, hybrid_score, max_similarity, avg_similarity, matched_chunks, chunk_matches
+		FROM query_result_chunks
+		WHERE query_result_id = ANY($1)`
+
+	ids := make([]string, len(ordered))
+	for i, rr := range ordered {
+		ids[i] = rr.id
+	}
+
+	chunkRows, err := r.db.Query(ctx, chunksQuery, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query result chunks: %w", err)
+	}
+	defer chunkRows.Close()
+
+	recommendations := make(map[string][]models.ArticleRecommendation, len(ordered))
+	for chunkRows.Next() {
+		var queryResultID string
+		var rec models.ArticleRecommendation
+		var chunkMatchesJSON []byte
+		if err := chunkRows.Scan(&queryResultID, &rec.ArticleID, &rec.Title, &rec.Category, &rec.URL,
+			&rec.HybridScore, &rec.MaxSimilarity, &rec.AvgSimilarity, &rec.MatchedChunks, &chunkMatchesJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan query result chunk: %w", err)
+		}
+		if err := json.Unmarshal(chunkMatchesJSON, &rec.ChunkMatches); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal chunk matches: %w", err)
+		}
+		recommendations[queryResultID] = append(recommendations[queryResultID], rec)
+	}
+
+	history := make([]models.QueryRecommendationResult, len(ordered))
+	for i, rr := range ordered {
+		rr.result.Recommendations = recommendations[rr.id]
+		history[i] = rr.result
+	}
+
+	return history, nil
+}
@@ -0,0 +1,125 @@
+// Package reranker calls a cross-encoder (sentence-transformers) service
+// that scores pairwise relevance between a source article and a set of
+// candidate articles, the second stage of a two-stage retrieval pipeline:
+// ANN chunk search produces a cheap candidate set, and the cross-encoder
+// re-scores that candidate set with a much more precise, more expensive
+// model.
+package reranker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RerankerClient scores how relevant each candidate article is to a source
+// article's chunk texts.
+type RerankerClient interface {
+	Rerank(ctx context.Context, req RerankRequest) ([]RerankScore, error)
+}
+
+// RerankRequest pairs a source article's chunk texts against a set of
+// candidate articles' chunk texts.
+type RerankRequest struct {
+	SourceTexts []string          `json:"source_texts"`
+	Candidates  []RerankCandidate `json:"candidates"`
+}
+
+// RerankCandidate is one article the cross-encoder scores against the
+// source article's texts.
+type RerankCandidate struct {
+	ArticleID uuid.UUID `json:"article_id"`
+	Texts     []string  `json:"texts"`
+}
+
+// RerankScore is the cross-encoder's relevance score for one candidate
+// article, higher is more relevant.
+type RerankScore struct {
+	ArticleID uuid.UUID `json:"article_id"`
+	Score     float64   `json:"score"`
+}
+
+type rerankResponse struct {
+	Scores []RerankScore `json:"scores"`
+}
+
+// ServiceError represents an error response from the reranking service.
+type ServiceError struct {
+	Message    string
+	StatusCode int
+}
+
+func (e *ServiceError) Error() string {
+	return fmt.Sprintf("reranker service error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// HTTPRerankerClient calls a sentence-transformers cross-encoder service
+// over HTTP.
+type HTTPRerankerClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPRerankerClient creates a client for the reranking service,
+// defaulting to a local sentence-transformers deployment if
+// RERANKER_SERVICE_URL isn't set.
+func NewHTTPRerankerClient() *HTTPRerankerClient {
+	baseURL := os.Getenv("RERANKER_SERVICE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8001"
+	}
+
+	return &HTTPRerankerClient{
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Rerank posts req to the service's /rerank endpoint and returns one score
+// per candidate that was found. Candidates the service couldn't score are
+// simply absent from the result rather than causing an error.
+func (c *HTTPRerankerClient) Rerank(ctx context.Context, req RerankRequest) ([]RerankScore, error) {
+	if len(req.Candidates) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rerank request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/rerank", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build rerank request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("rerank request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rerank response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &ServiceError{Message: string(responseBody), StatusCode: resp.StatusCode}
+	}
+
+	var parsed rerankResponse
+	if err := json.Unmarshal(responseBody, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse rerank response: %w", err)
+	}
+
+	return parsed.Scores, nil
+}
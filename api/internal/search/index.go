@@ -0,0 +1,179 @@
+// Package search maintains a Bleve full-text index of articles and chunks
+// alongside Postgres, giving the API a real keyword query surface instead
+// of deferring every lookup to the external ML service or a Postgres
+// ILIKE scan.
+package search
+
+import (
+	"fmt"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/google/uuid"
+
+	"rec-mind/models"
+)
+
+// defaultQueryLimit caps Query's result size when opts.Limit isn't set.
+const defaultQueryLimit = 10
+
+// previewLen bounds how much of a hit's content is returned as a preview.
+const previewLen = 240
+
+// SearchIndex keeps article and chunk documents in sync with Postgres and
+// serves keyword queries over them.
+type SearchIndex interface {
+	Index(article *models.Article) error
+	IndexChunk(chunk *models.ArticleChunk) error
+	Remove(id uuid.UUID) error
+	Query(q string, opts SearchOptions) ([]Hit, error)
+}
+
+// SearchOptions narrows a Query call.
+type SearchOptions struct {
+	Category string
+	Limit    int
+}
+
+// Hit is one ranked result, covering both article- and chunk-level
+// documents; Type distinguishes which.
+type Hit struct {
+	ID       string  `json:"id"`
+	Type     string  `json:"type"`
+	Score    float64 `json:"score"`
+	Title    string  `json:"title,omitempty"`
+	Category string  `json:"category,omitempty"`
+	Preview  string  `json:"preview"`
+}
+
+// articleDoc and chunkDoc are the documents actually stored in the index.
+// Their JSON field names double as the Bleve field names used by Query and
+// by buildMapping's per-field mappings.
+type articleDoc struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Content  string `json:"content"`
+	Category string `json:"category"`
+}
+
+type chunkDoc struct {
+	Type      string `json:"type"`
+	ArticleID string `json:"article_id"`
+	Content   string `json:"content"`
+}
+
+type bleveIndex struct {
+	index bleve.Index
+}
+
+// NewIndex opens the Bleve index at path, falling back to creating it with
+// buildMapping's mapping if it doesn't exist yet.
+func NewIndex(path string) (SearchIndex, error) {
+	idx, err := bleve.Open(path)
+	if err == nil {
+		return &bleveIndex{index: idx}, nil
+	}
+
+	idx, err = bleve.New(path, buildMapping())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bleve index at %s: %w", path, err)
+	}
+
+	return &bleveIndex{index: idx}, nil
+}
+
+// buildMapping gives title, content, category and chunk content their own
+// analyzed text mappings, each also included in Bleve's combined _all
+// field, which Query's unfielded query string searches by default.
+func buildMapping() *mapping.IndexMappingImpl {
+	textField := bleve.NewTextFieldMapping()
+	textField.IncludeInAll = true
+
+	articleMapping := bleve.NewDocumentMapping()
+	articleMapping.AddFieldMappingsAt("title", textField)
+	articleMapping.AddFieldMappingsAt("content", textField)
+	articleMapping.AddFieldMappingsAt("category", textField)
+
+	chunkMapping := bleve.NewDocumentMapping()
+	chunkMapping.AddFieldMappingsAt("content", textField)
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.TypeField = "type"
+	indexMapping.AddDocumentMapping("article", articleMapping)
+	indexMapping.AddDocumentMapping("chunk", chunkMapping)
+
+	return indexMapping
+}
+
+func (b *bleveIndex) Index(article *models.Article) error {
+	doc := articleDoc{Type: "article", Title: article.Title, Content: article.Content, Category: article.Category}
+	if err := b.index.Index(article.ID.String(), doc); err != nil {
+		return fmt.Errorf("failed to index article %s: %w", article.ID, err)
+	}
+	return nil
+}
+
+func (b *bleveIndex) IndexChunk(chunk *models.ArticleChunk) error {
+	doc := chunkDoc{Type: "chunk", ArticleID: chunk.ArticleID.String(), Content: chunk.Content}
+	if err := b.index.Index(chunk.ID.String(), doc); err != nil {
+		return fmt.Errorf("failed to index chunk %s: %w", chunk.ID, err)
+	}
+	return nil
+}
+
+func (b *bleveIndex) Remove(id uuid.UUID) error {
+	if err := b.index.Delete(id.String()); err != nil {
+		return fmt.Errorf("failed to remove %s from search index: %w", id, err)
+	}
+	return nil
+}
+
+func (b *bleveIndex) Query(q string, opts SearchOptions) ([]Hit, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = defaultQueryLimit
+	}
+
+	queries := []query.Query{bleve.NewQueryStringQuery(q)}
+	if opts.Category != "" {
+		categoryQuery := bleve.NewMatchQuery(opts.Category)
+		categoryQuery.SetField("category")
+		queries = append(queries, categoryQuery)
+	}
+
+	req := bleve.NewSearchRequest(bleve.NewConjunctionQuery(queries...))
+	req.Size = opts.Limit
+	req.Fields = []string{"type", "title", "category", "content"}
+
+	result, err := b.index.Search(req)
+	if err != nil {
+		return nil, fmt.Errorf("search query failed: %w", err)
+	}
+
+	hits := make([]Hit, 0, len(result.Hits))
+	for _, h := range result.Hits {
+		hit := Hit{ID: h.ID, Score: h.Score}
+		if t, ok := h.Fields["type"].(string); ok {
+			hit.Type = t
+		}
+		if title, ok := h.Fields["title"].(string); ok {
+			hit.Title = title
+		}
+		if category, ok := h.Fields["category"].(string); ok {
+			hit.Category = category
+		}
+		if content, ok := h.Fields["content"].(string); ok {
+			hit.Preview = preview(content)
+		}
+		hits = append(hits, hit)
+	}
+
+	return hits, nil
+}
+
+func preview(content string) string {
+	if len(content) <= previewLen {
+		return content
+	}
+	return content[:previewLen] + "..."
+}
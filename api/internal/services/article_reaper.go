@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	"rec-mind/internal/repository"
+	"rec-mind/models"
+	"rec-mind/mq"
+)
+
+// idempotencyKeyTTL bounds how long a client-supplied X-Idempotency-Key is
+// remembered, matching the resumable upload session TTL convention.
+const idempotencyKeyTTL = 24 * time.Hour
+
+func idempotencyRedisKey(key string) string {
+	return fmt.Sprintf("article_deletion_idempotency:%s", key)
+}
+
+// ArticleReaper resolves the set of articles a bulk-deletion request targets
+// and, for a non-dry-run, publishes an ArticleDeletionJob so a worker
+// cascade-deletes chunks from Postgres, invalidates Redis keys, and issues
+// vector deletes to the Python service outside the request/response cycle.
+type ArticleReaper struct {
+	articleRepo repository.ArticleRepository
+	chunkRepo   repository.ArticleChunkRepository
+	redis       *goredis.Client
+}
+
+// NewArticleReaper wires the reaper to its dependencies.
+func NewArticleReaper(articleRepo repository.ArticleRepository, chunkRepo repository.ArticleChunkRepository, redisClient *goredis.Client) *ArticleReaper {
+	return &ArticleReaper{
+		articleRepo: articleRepo,
+		chunkRepo:   chunkRepo,
+		redis:       redisClient,
+	}
+}
+
+// ResolveTargets expands a bulk deletion request (explicit IDs or a filter)
+// into the concrete set of article IDs it matches.
+func (r *ArticleReaper) ResolveTargets(ctx context.Context, req *models.DeleteArticlesBulkRequest) ([]uuid.UUID, error) {
+	if len(req.ArticleIDs) > 0 {
+		ids := make([]uuid.UUID, 0, len(req.ArticleIDs))
+		for _, raw := range req.ArticleIDs {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid article id %q: %w", raw, err)
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+
+	if req.Filter == nil {
+		return nil, fmt.Errorf("either article_ids or filter must be provided")
+	}
+
+	req.Filter.SetDefaults()
+	articles, err := r.articleRepo.List(ctx, req.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve articles matching filter: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(articles))
+	for i, article := range articles {
+		ids[i] = article.ID
+	}
+	return ids, nil
+}
+
+// CountChunks returns how many chunks belong to the given articles, used to
+// report dry-run impact across stores before anything is deleted.
+func (r *ArticleReaper) CountChunks(ctx context.Context, articleIDs []uuid.UUID) (int, error) {
+	total := 0
+	for _, id := range articleIDs {
+		count, err := r.chunkRepo.Count(ctx, &models.ArticleChunkFilter{ArticleID: &id})
+		if err != nil {
+			return 0, fmt.Errorf("failed to count chunks for article %s: %w", id, err)
+		}
+		total += int(count)
+	}
+	return total, nil
+}
+
+// CheckIdempotency reports whether idempotencyKey has already been used for
+// a bulk deletion, and if not, reserves it for idempotencyKeyTTL.
+func (r *ArticleReaper) CheckIdempotency(ctx context.Context, idempotencyKey string) (alreadySeen bool, err error) {
+	if idempotencyKey == "" {
+		return false, nil
+	}
+
+	ok, err := r.redis.SetNX(ctx, idempotencyRedisKey(idempotencyKey), "1", idempotencyKeyTTL).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check idempotency key: %w", err)
+	}
+	return !ok, nil
+}
+
+// Enqueue publishes an ArticleDeletionJob so a worker performs the actual
+// cross-store cascade delete.
+func (r *ArticleReaper) Enqueue(articleIDs []uuid.UUID, idempotencyKey string) (string, error) {
+	jobID := uuid.New().String()
+
+	ids := make([]string, len(articleIDs))
+	for i, id := range articleIDs {
+		ids[i] = id.String()
+	}
+
+	job := models.ArticleDeletionJob{
+		JobID:          jobID,
+		ArticleIDs:     ids,
+		IdempotencyKey: idempotencyKey,
+		CreatedAt:      time.Now(),
+	}
+
+	if err := mq.PublishArticleDeletionJob(job); err != nil {
+		return "", fmt.Errorf("failed to publish article deletion job: %w", err)
+	}
+
+	return jobID, nil
+}
@@ -2,19 +2,60 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
 
-	"github.com/owenHochwald/rec-mind-api/internal/database"
-	"github.com/owenHochwald/rec-mind-api/internal/mlclient"
-	"github.com/owenHochwald/rec-mind-api/internal/repository"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	"rec-mind/internal/database"
+	"rec-mind/internal/events"
+	"rec-mind/internal/jobs"
+	"rec-mind/internal/mlclient"
+	"rec-mind/internal/repository"
 )
 
+// embeddingJobType selects ArticleService.runEmbeddingJob out of a
+// jobs.Pool's handler map.
+const embeddingJobType = "article_embedding"
+
+// batchEmbeddingJobType selects ArticleService.runBatchEmbeddingJob out of a
+// jobs.Pool's handler map - a separate job type from embeddingJobType since
+// its payload carries many article IDs instead of one, and it reports
+// incremental progress via jobs.Queue.SetProgress as ProcessBatchArticlesChunked
+// works through them.
+const batchEmbeddingJobType = "article_batch_embedding"
+
+// embeddingSchedulerInterval is how often StartEmbeddingWorkers' Queue
+// promotes due retries from its scheduled set onto the ready list.
+const embeddingSchedulerInterval = 5 * time.Second
+
 // ArticleService handles article processing with ML integration
 type ArticleService struct {
 	repo     repository.ArticleRepository
 	mlClient *mlclient.MLClient
+	events   *events.Publisher
+	// jobQueue persists embedding work so CreateArticleWithAsyncEmbedding
+	// survives a process restart; see StartEmbeddingWorkers for the pool
+	// that drains it. May be nil (e.g. in tests), in which case
+	// CreateArticleWithAsyncEmbedding falls back to a bare goroutine.
+	jobQueue *jobs.Queue
+}
+
+// embeddingJobPayload is jobQueue's job.Payload for embeddingJobType: just
+// the article ID, since runEmbeddingJob re-fetches the article fresh on
+// every attempt rather than carrying title/content through retries.
+type embeddingJobPayload struct {
+	ArticleID string `json:"article_id"`
+}
+
+// batchEmbeddingJobPayload is jobQueue's job.Payload for batchEmbeddingJobType:
+// runBatchEmbeddingJob re-fetches every article fresh on each attempt, the
+// same way runEmbeddingJob does for a single article.
+type batchEmbeddingJobPayload struct {
+	ArticleIDs []string `json:"article_ids"`
 }
 
 // ArticleProcessingResult represents the result of processing an article
@@ -23,13 +64,21 @@ type ArticleProcessingResult struct {
 	EmbeddingResult *mlclient.BatchAndUploadResponse    `json:"embedding_result,omitempty"`
 	Error           string                              `json:"error,omitempty"`
 	ProcessingTime  time.Duration                       `json:"processing_time"`
+	// Duplicate is true when an article with identical (normalized) content
+	// already existed, so this result reuses that article instead of
+	// creating and re-embedding a new one.
+	Duplicate bool `json:"duplicate,omitempty"`
 }
 
-// NewArticleService creates a new article service
-func NewArticleService(repo repository.ArticleRepository, mlClient *mlclient.MLClient) *ArticleService {
+// NewArticleService creates a new article service. jobQueue may be nil,
+// in which case CreateArticleWithAsyncEmbedding falls back to a bare
+// goroutine instead of enqueueing durable work.
+func NewArticleService(repo repository.ArticleRepository, mlClient *mlclient.MLClient, eventPublisher *events.Publisher, jobQueue *jobs.Queue) *ArticleService {
 	return &ArticleService{
 		repo:     repo,
 		mlClient: mlClient,
+		events:   eventPublisher,
+		jobQueue: jobQueue,
 	}
 }
 
@@ -51,12 +100,26 @@ func (s *ArticleService) CreateArticleWithEmbedding(ctx context.Context, req *da
 	}
 	
 	result.Article = article
+
+	if article.Duplicate {
+		log.Printf("Article content already exists (ID: %s); skipping embedding", article.ID)
+		result.Duplicate = true
+		result.ProcessingTime = time.Since(startTime)
+		return result, nil
+	}
 	log.Printf("Article created successfully with ID: %s", article.ID)
 
 	// Step 2: Generate embedding and upload to Pinecone
+	if s.mlClient.BreakerOpen() {
+		log.Printf("Python ML service circuit breaker open; deferring embedding for article %s", article.ID)
+		result.Error = "Article created but embedding generation was deferred: Python ML service is unavailable"
+		result.ProcessingTime = time.Since(startTime)
+		return result, nil
+	}
+
 	log.Printf("Generating embedding for article: %s", article.ID)
 	embeddingText := mlclient.CreateEmbeddingText(article.Title, article.Content)
-	
+
 	embeddingResult, err := s.mlClient.GenerateSingleEmbeddingAndUpload(ctx, article.ID, embeddingText)
 	if err != nil {
 		// Log the error but don't fail the entire operation
@@ -74,32 +137,231 @@ func (s *ArticleService) CreateArticleWithEmbedding(ctx context.Context, req *da
 	return result, nil
 }
 
-// CreateArticleWithAsyncEmbedding creates an article and schedules embedding generation asynchronously
-func (s *ArticleService) CreateArticleWithAsyncEmbedding(ctx context.Context, req *database.CreateArticleRequest) (*database.Article, error) {
+// CreateArticleWithAsyncEmbedding creates an article and schedules embedding generation asynchronously.
+// The second return value reports whether an article with identical
+// (normalized) content already existed, in which case it is reused as-is
+// and no chunking/embedding is scheduled.
+func (s *ArticleService) CreateArticleWithAsyncEmbedding(ctx context.Context, req *database.CreateArticleRequest) (*database.Article, bool, error) {
 	// Step 1: Create article in database
 	article, err := s.repo.Create(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create article: %w", err)
+		return nil, false, fmt.Errorf("failed to create article: %w", err)
 	}
 
-	// Step 2: Generate embedding asynchronously (fire and forget)
-	go func() {
-		// Use a background context with timeout for the async operation
-		asyncCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-		defer cancel()
+	if article.Duplicate {
+		log.Printf("Article content already exists (ID: %s); skipping chunking and embedding", article.ID)
+		return article, true, nil
+	}
+
+	// Step 2: Generate embedding asynchronously (fire and forget), publishing
+	// progress events as we go so clients can subscribe via SSE instead of
+	// polling the article for its embedding status.
+	s.publishEvent(context.Background(), article.ID.String(), events.StageChunkingStarted, nil)
+
+	if s.mlClient.BreakerOpen() {
+		log.Printf("Python ML service circuit breaker open; deferring embedding for article %s", article.ID)
+		s.publishEvent(context.Background(), article.ID.String(), events.StageFailed, map[string]interface{}{
+			"error":    "Python ML service is unavailable",
+			"deferred": true,
+		})
+		return article, false, nil
+	}
+
+	if s.jobQueue == nil {
+		// No durable queue wired up (e.g. a test building ArticleService
+		// directly): fall back to the old fire-and-forget goroutine rather
+		// than silently dropping the embedding work.
+		go s.runEmbeddingJobBestEffort(article.ID)
+		return article, false, nil
+	}
+
+	payload := embeddingJobPayload{ArticleID: article.ID.String()}
+	if _, err := s.jobQueue.Enqueue(ctx, embeddingJobType, payload); err != nil {
+		log.Printf("Failed to enqueue embedding job for article %s: %v", article.ID, err)
+		s.publishEvent(context.Background(), article.ID.String(), events.StageFailed, map[string]interface{}{"error": err.Error()})
+		return article, false, nil
+	}
+
+	return article, false, nil
+}
+
+// JobQueue exposes the embedding job queue so routes can wire up
+// /api/v1/jobs/:job_id without reaching into ArticleService's internals.
+// Returns nil if NewArticleService was constructed without one.
+func (s *ArticleService) JobQueue() *jobs.Queue {
+	return s.jobQueue
+}
+
+// EnqueueEmbeddingJob enqueues the same embedding job
+// CreateArticleWithAsyncEmbedding does, for callers that already have an
+// article ID rather than a freshly-created article - currently
+// ArticleEventsWorker, reacting to article.created/article.updated events
+// instead of calling CreateArticleWithAsyncEmbedding directly. Returns an
+// error if no jobQueue is configured rather than silently falling back to
+// a goroutine, since a consumer processing a queued event has no request
+// context to fall back into.
+func (s *ArticleService) EnqueueEmbeddingJob(ctx context.Context, articleID uuid.UUID) (string, error) {
+	if s.jobQueue == nil {
+		return "", fmt.Errorf("no durable job queue configured")
+	}
+
+	payload := embeddingJobPayload{ArticleID: articleID.String()}
+	return s.jobQueue.Enqueue(ctx, embeddingJobType, payload)
+}
+
+// EnqueueBatchEmbeddingJob durably enqueues chunked batch processing of
+// articleIDs (see ProcessBatchArticlesChunked), so the /api/upload bulk
+// path and the scraper can poll /api/v1/jobs/:job_id for incremental
+// "<done>/<total>" chunk progress instead of blocking on one long request.
+func (s *ArticleService) EnqueueBatchEmbeddingJob(ctx context.Context, articleIDs []uuid.UUID) (string, error) {
+	if s.jobQueue == nil {
+		return "", fmt.Errorf("no durable job queue configured")
+	}
+
+	ids := make([]string, len(articleIDs))
+	for i, id := range articleIDs {
+		ids[i] = id.String()
+	}
+
+	payload := batchEmbeddingJobPayload{ArticleIDs: ids}
+	return s.jobQueue.Enqueue(ctx, batchEmbeddingJobType, payload)
+}
+
+// StartEmbeddingWorkers registers runEmbeddingJob on a jobs.Pool over
+// s.jobQueue and starts it, alongside the retry scheduler that promotes
+// due backoff retries and a reaper that reclaims jobs abandoned by a
+// worker that died mid-run. Call once per process after NewArticleService;
+// a nil jobQueue makes this a no-op.
+func (s *ArticleService) StartEmbeddingWorkers(ctx context.Context, redisClient *goredis.Client, concurrency int) {
+	if s.jobQueue == nil {
+		return
+	}
+
+	pool := jobs.NewPool(s.jobQueue, redisClient, concurrency)
+	pool.RegisterHandler(embeddingJobType, s.runEmbeddingJob)
+	pool.RegisterHandler(batchEmbeddingJobType, s.runBatchEmbeddingJob)
+	pool.Start(ctx)
+
+	s.jobQueue.StartScheduler(ctx, embeddingSchedulerInterval)
+	jobs.NewReaper(s.jobQueue, redisClient).Start(ctx, 0)
+}
 
-		log.Printf("Starting async embedding generation for article: %s", article.ID)
-		embeddingText := mlclient.CreateEmbeddingText(article.Title, article.Content)
-		
-		_, err := s.mlClient.GenerateSingleEmbeddingAndUpload(asyncCtx, article.ID, embeddingText)
+// runEmbeddingJob is the jobs.Handler for embeddingJobType: it re-fetches
+// the article (rather than trusting a payload that may be stale after a
+// retry), generates its embedding, and uploads it to Pinecone, publishing
+// the same progress events the old goroutine did.
+func (s *ArticleService) runEmbeddingJob(ctx context.Context, rawPayload []byte) error {
+	var payload embeddingJobPayload
+	if err := json.Unmarshal(rawPayload, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal embedding job payload: %w", err)
+	}
+
+	articleID, err := uuid.Parse(payload.ArticleID)
+	if err != nil {
+		return fmt.Errorf("invalid article id %q in embedding job: %w", payload.ArticleID, err)
+	}
+
+	article, err := s.repo.GetByID(ctx, articleID)
+	if err != nil {
+		return fmt.Errorf("failed to load article %s for embedding: %w", articleID, err)
+	}
+
+	if s.mlClient.BreakerOpen() {
+		return fmt.Errorf("python ml service circuit breaker open, deferring embedding for article %s", articleID)
+	}
+
+	embeddingText := mlclient.CreateEmbeddingText(article.Title, article.Content)
+	embeddingResult, err := s.mlClient.GenerateSingleEmbeddingAndUpload(ctx, article.ID, embeddingText)
+	if err != nil {
+		s.publishEvent(ctx, article.ID.String(), events.StageFailed, map[string]interface{}{"error": err.Error()})
+		return fmt.Errorf("failed to generate embedding for article %s: %w", articleID, err)
+	}
+
+	s.publishEvent(ctx, article.ID.String(), events.StageVectorsUploaded, map[string]interface{}{
+		"vectors_uploaded": len(embeddingResult.Uploads),
+	})
+	s.publishEvent(ctx, article.ID.String(), events.StageCompleted, nil)
+	return nil
+}
+
+// runBatchEmbeddingJob is the jobs.Handler for batchEmbeddingJobType: it
+// re-fetches every article, then runs ProcessBatchArticlesChunked, reporting
+// "<done>/<total>" chunk progress back onto this job's own hash via
+// jobs.JobIDFromContext + jobQueue.SetProgress as each chunk completes. A
+// partial failure (some chunks erroring) doesn't fail the job outright -
+// ProcessBatchArticlesChunked only returns an error if every chunk failed -
+// so a few bad articles in an otherwise-large batch don't exhaust this
+// job's retry budget and dead-letter the whole thing.
+func (s *ArticleService) runBatchEmbeddingJob(ctx context.Context, rawPayload []byte) error {
+	var payload batchEmbeddingJobPayload
+	if err := json.Unmarshal(rawPayload, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal batch embedding job payload: %w", err)
+	}
+
+	articles := make([]*database.Article, 0, len(payload.ArticleIDs))
+	for _, rawID := range payload.ArticleIDs {
+		articleID, err := uuid.Parse(rawID)
+		if err != nil {
+			return fmt.Errorf("invalid article id %q in batch embedding job: %w", rawID, err)
+		}
+
+		article, err := s.repo.GetByID(ctx, articleID)
 		if err != nil {
-			log.Printf("Async embedding generation failed for article %s: %v", article.ID, err)
-		} else {
-			log.Printf("Async embedding generation completed for article: %s", article.ID)
+			return fmt.Errorf("failed to load article %s for batch embedding: %w", articleID, err)
 		}
-	}()
+		articles = append(articles, article)
+	}
+
+	if s.mlClient.BreakerOpen() {
+		return fmt.Errorf("python ml service circuit breaker open, deferring batch embedding for %d articles", len(articles))
+	}
+
+	jobID, hasJobID := jobs.JobIDFromContext(ctx)
+
+	result, err := s.ProcessBatchArticlesChunked(ctx, articles, func(done, total int, lastErr error) {
+		if hasJobID {
+			s.jobQueue.SetProgress(ctx, jobID, done, total)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(result.ChunkErrors) > 0 {
+		log.Printf("Batch embedding job %s completed with %d chunk errors: %v", jobID, len(result.ChunkErrors), result.ChunkErrors)
+	}
 
-	return article, nil
+	return nil
+}
+
+// runEmbeddingJobBestEffort is CreateArticleWithAsyncEmbedding's fallback
+// when no jobQueue is configured; it mirrors runEmbeddingJob but swallows
+// its error the same way the goroutine it replaces did, since there's no
+// durable queue to retry it through.
+func (s *ArticleService) runEmbeddingJobBestEffort(articleID uuid.UUID) {
+	asyncCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	payload, err := json.Marshal(embeddingJobPayload{ArticleID: articleID.String()})
+	if err != nil {
+		log.Printf("Failed to marshal embedding job payload for article %s: %v", articleID, err)
+		return
+	}
+	if err := s.runEmbeddingJob(asyncCtx, payload); err != nil {
+		log.Printf("Best-effort embedding generation failed for article %s: %v", articleID, err)
+	}
+}
+
+// publishEvent is a best-effort progress notification: a missing publisher
+// (not every caller wires one up) or a transient Redis error should never
+// affect article processing itself.
+func (s *ArticleService) publishEvent(ctx context.Context, articleID, stage string, data map[string]interface{}) {
+	if s.events == nil {
+		return
+	}
+	if err := s.events.Publish(ctx, articleID, stage, data); err != nil {
+		log.Printf("Failed to publish %s event for article %s: %v", stage, articleID, err)
+	}
 }
 
 // ProcessBatchArticles processes multiple articles for embedding generation
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"rec-mind/config"
+	"rec-mind/internal/database"
+	"rec-mind/internal/mlclient"
+)
+
+// BatchProgressFunc reports incremental progress through a chunked batch:
+// done/total count chunks (not individual articles), and lastErr is the
+// error from the chunk that just finished, if any. Called from whichever
+// worker goroutine finished that chunk, so callers that aren't already
+// safe for concurrent use (e.g. writing to a shared job record) must do
+// their own locking.
+type BatchProgressFunc func(done, total int, lastErr error)
+
+// BatchProcessingResult mirrors mlclient.BatchAndUploadResponse's shape but
+// aggregates across every chunk dispatched by ProcessBatchArticlesChunked, with
+// ChunkErrors recording chunks that failed outright rather than aborting
+// the whole batch - the same "continue and report" convention
+// ScrapingResult.FeedResults[i].Errors uses for per-feed failures.
+type BatchProcessingResult struct {
+	Embeddings  mlclient.BatchEmbeddingResponse `json:"embeddings"`
+	Uploads     []mlclient.UploadResult         `json:"uploads"`
+	Summary     mlclient.ProcessingSummary      `json:"summary"`
+	ChunkErrors []string                        `json:"chunk_errors,omitempty"`
+}
+
+// chunkArticles splits articles into consecutive slices of at most size
+// articles each. size <= 0 is treated as "no chunking" (a single chunk).
+func chunkArticles(articles []*database.Article, size int) [][]*database.Article {
+	if size <= 0 || size >= len(articles) {
+		return [][]*database.Article{articles}
+	}
+
+	chunks := make([][]*database.Article, 0, (len(articles)+size-1)/size)
+	for start := 0; start < len(articles); start += size {
+		end := start + size
+		if end > len(articles) {
+			end = len(articles)
+		}
+		chunks = append(chunks, articles[start:end])
+	}
+	return chunks
+}
+
+// ProcessBatchArticlesChunked splits articles into config.LoadBatchPolicy's
+// chunk size and dispatches up to Concurrency chunks at a time against the
+// Python service, instead of ProcessBatchArticles' single all-at-once
+// request, which OOMs or times out once a scrape run feeds it a few hundred
+// articles. A chunk that fails is recorded in ChunkErrors rather than
+// aborting the rest; onProgress (may be nil) is called once per completed
+// chunk so a caller can surface incremental status through a job record or
+// SSE stream.
+func (s *ArticleService) ProcessBatchArticlesChunked(ctx context.Context, articles []*database.Article, onProgress BatchProgressFunc) (*BatchProcessingResult, error) {
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("no articles provided for batch processing")
+	}
+
+	policy := config.LoadBatchPolicy()
+	chunks := chunkArticles(articles, policy.ChunkSize)
+
+	log.Printf("Processing %d articles across %d chunks (chunk size %d, concurrency %d)", len(articles), len(chunks), policy.ChunkSize, policy.Concurrency)
+
+	merged := &BatchProcessingResult{}
+	var mu sync.Mutex
+	done := 0
+
+	concurrency := policy.Concurrency
+	if concurrency > len(chunks) {
+		concurrency = len(chunks)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, chunk := range chunks {
+		chunk := chunk
+		chunkNum := i + 1
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := s.ProcessBatchArticles(ctx, chunk)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				merged.ChunkErrors = append(merged.ChunkErrors, fmt.Sprintf("chunk %d (%d articles): %v", chunkNum, len(chunk), err))
+			} else {
+				mergeBatchResult(merged, result)
+			}
+
+			done++
+			if onProgress != nil {
+				onProgress(done, len(chunks), err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if merged.Summary.TotalProcessed == 0 && len(merged.ChunkErrors) > 0 {
+		return merged, fmt.Errorf("all %d chunks failed: %s", len(chunks), merged.ChunkErrors[0])
+	}
+
+	return merged, nil
+}
+
+// mergeBatchResult folds one chunk's response into merged: embedding
+// results and uploads are appended, tokens and processing time are summed.
+func mergeBatchResult(merged *BatchProcessingResult, result *mlclient.BatchAndUploadResponse) {
+	merged.Embeddings.Results = append(merged.Embeddings.Results, result.Embeddings.Results...)
+	merged.Embeddings.TotalTokens += result.Embeddings.TotalTokens
+	merged.Embeddings.ProcessingTime += result.Embeddings.ProcessingTime
+
+	merged.Uploads = append(merged.Uploads, result.Uploads...)
+
+	merged.Summary.TotalProcessed += result.Summary.TotalProcessed
+	merged.Summary.TotalTokens += result.Summary.TotalTokens
+	merged.Summary.ProcessingTime += result.Summary.ProcessingTime
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"rec-mind/internal/database"
+	"rec-mind/internal/outbox"
+	"rec-mind/models"
+)
+
+// articlesBatchQueue and articlesBatchDLQ name the queue pair
+// BulkArticleIngestor publishes its manifest messages to: a batch that
+// outlives articlesBatchTTL unconsumed is dead-lettered instead of lost.
+const (
+	articlesBatchQueue = "articles.batch"
+	articlesBatchDLQ   = "articles.batch.dlq"
+	articlesBatchTTL   = 24 * time.Hour
+)
+
+// defaultIngestBatchSize bounds how many articles BulkArticleIngestor
+// accumulates before CopyFrom-ing them into Postgres and publishing one
+// manifest message, when the caller doesn't override it.
+const defaultIngestBatchSize = 100
+
+// defaultMaxUnackedBatches bounds how many batches can be published but
+// not yet confirmed by RabbitMQ before Add blocks, so a slow or backed-up
+// broker applies backpressure to scraping instead of letting pending
+// batches pile up in memory.
+const defaultMaxUnackedBatches = 4
+
+// PendingArticle is one parsed, validated, and deduplicated article
+// queued for bulk insert. ID is generated by the caller (not Postgres)
+// so the batch manifest can reference every article's ID without a
+// round-trip to read generated keys back.
+type PendingArticle struct {
+	ID          uuid.UUID
+	Title       string
+	Content     string
+	URL         string
+	Category    string
+	ContentHash string
+	SimHash     uint64
+}
+
+// ArticleBatchMessage is published once per flushed batch to
+// articlesBatchQueue, carrying a manifest of article IDs so a downstream
+// ML worker can prefetch the whole batch in one query instead of reacting
+// to article_processing messages one article at a time.
+type ArticleBatchMessage struct {
+	ArticleIDs []uuid.UUID `json:"article_ids"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// BulkArticleIngestor batches parsed articles into fixed-size groups,
+// inserting each batch with a single CopyFrom transaction and publishing
+// one articles.batch manifest per batch instead of one article_processing
+// message per article (see ScraperService.publishArticleProcessingMessage
+// for that per-article path, which this doesn't replace). Callers that
+// can tolerate batch semantics use Add/Flush directly; processArticle's
+// per-article dedup checks still apply upstream of this type.
+type BulkArticleIngestor struct {
+	db        *pgxpool.Pool
+	stmtCache *database.StmtCache
+	publisher *outbox.Publisher
+	batchSize int
+
+	mu      sync.Mutex
+	pending []PendingArticle
+
+	// inFlight bounds how many batches are published but unconfirmed;
+	// flush blocks acquiring a slot once maxUnackedBatches are in flight.
+	inFlight chan struct{}
+	unacked  sync.WaitGroup
+}
+
+// NewBulkArticleIngestor constructs a BulkArticleIngestor. batchSize and
+// maxUnackedBatches fall back to defaultIngestBatchSize and
+// defaultMaxUnackedBatches when <= 0.
+func NewBulkArticleIngestor(db *database.DB, publisher *outbox.Publisher, batchSize, maxUnackedBatches int) *BulkArticleIngestor {
+	if batchSize <= 0 {
+		batchSize = defaultIngestBatchSize
+	}
+	if maxUnackedBatches <= 0 {
+		maxUnackedBatches = defaultMaxUnackedBatches
+	}
+
+	return &BulkArticleIngestor{
+		db:        db.Pool,
+		stmtCache: db.StmtCache,
+		publisher: publisher,
+		batchSize: batchSize,
+		pending:   make([]PendingArticle, 0, batchSize),
+		inFlight:  make(chan struct{}, maxUnackedBatches),
+	}
+}
+
+// DeclareQueue declares articlesBatchQueue and its DLQ. Call it once
+// before the ingestor starts accumulating batches.
+func (ing *BulkArticleIngestor) DeclareQueue() error {
+	return ing.publisher.DeclareDurableQueue(articlesBatchQueue, articlesBatchDLQ, articlesBatchTTL)
+}
+
+// Add queues article for the current batch, flushing immediately once
+// batchSize is reached.
+func (ing *BulkArticleIngestor) Add(ctx context.Context, article PendingArticle) error {
+	ing.mu.Lock()
+	ing.pending = append(ing.pending, article)
+	var batch []PendingArticle
+	if len(ing.pending) >= ing.batchSize {
+		batch = ing.pending
+		ing.pending = make([]PendingArticle, 0, ing.batchSize)
+	}
+	ing.mu.Unlock()
+
+	if batch == nil {
+		return nil
+	}
+	return ing.flush(ctx, batch)
+}
+
+// Flush inserts and publishes whatever's left in the current (possibly
+// smaller than batchSize) batch. Call it once scraping finishes so a
+// partial batch isn't dropped.
+func (ing *BulkArticleIngestor) Flush(ctx context.Context) error {
+	ing.mu.Lock()
+	batch := ing.pending
+	ing.pending = make([]PendingArticle, 0, ing.batchSize)
+	ing.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+	return ing.flush(ctx, batch)
+}
+
+// Wait blocks until every batch handed to flush has been acknowledged (or
+// parked in the outbox). Call it after the last Flush so a caller doesn't
+// return while batches are still in flight.
+func (ing *BulkArticleIngestor) Wait() {
+	ing.unacked.Wait()
+}
+
+// flush CopyFrom's batch into articles in one transaction, then
+// acquires an inFlight slot — blocking the caller once
+// maxUnackedBatches batches are already unacked — before publishing the
+// batch's manifest message asynchronously.
+func (ing *BulkArticleIngestor) flush(ctx context.Context, batch []PendingArticle) error {
+	if err := ing.copyBatch(ctx, batch); err != nil {
+		return fmt.Errorf("failed to copy article batch: %w", err)
+	}
+
+	select {
+	case ing.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	ing.unacked.Add(1)
+	go func() {
+		defer ing.unacked.Done()
+		defer func() { <-ing.inFlight }()
+
+		if err := ing.publishBatch(ctx, batch); err != nil {
+			log.Printf("⚠️ Failed to publish articles.batch manifest for %d articles: %v", len(batch), err)
+		}
+	}()
+
+	return nil
+}
+
+// copyBatch bulk-inserts batch in one round-trip transaction via
+// pgx.CopyFrom.
+func (ing *BulkArticleIngestor) copyBatch(ctx context.Context, batch []PendingArticle) error {
+	tx, err := ing.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows := make([][]interface{}, len(batch))
+	for i, article := range batch {
+		bands := models.SimHashBands(article.SimHash)
+		rows[i] = []interface{}{
+			article.ID, article.Title, article.Content, article.URL, article.Category,
+			article.ContentHash, int64(article.SimHash),
+			int32(bands[0]), int32(bands[1]), int32(bands[2]), int32(bands[3]),
+		}
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"articles"},
+		[]string{"id", "title", "content", "url", "category", "content_hash", "simhash", "simhash_band0", "simhash_band1", "simhash_band2", "simhash_band3"},
+		pgx.CopyFromRows(rows))
+	if err != nil {
+		return fmt.Errorf("failed to copy articles: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// publishBatch sends one manifest message for batch through the
+// confirm+retry+outbox publisher, so a broker hiccup parks the manifest
+// instead of dropping it.
+func (ing *BulkArticleIngestor) publishBatch(ctx context.Context, batch []PendingArticle) error {
+	ids := make([]uuid.UUID, len(batch))
+	for i, article := range batch {
+		ids[i] = article.ID
+	}
+
+	message := ArticleBatchMessage{ArticleIDs: ids, CreatedAt: time.Now()}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch manifest: %w", err)
+	}
+
+	headers := amqp.Table{"batch_size": len(batch)}
+
+	if err := ing.publisher.Publish(ctx, articlesBatchQueue, body, headers); err != nil {
+		return fmt.Errorf("failed to publish batch manifest: %w", err)
+	}
+
+	log.Printf("📤 Published articles.batch manifest for %d articles", len(batch))
+	return nil
+}
+
+// UpsertOneFallback inserts a single article outside of a batch, for
+// callers falling back off the bulk path (e.g. a batch whose CopyFrom
+// failed and is being retried article-by-article). It prepares its
+// upsert statement once per connection and reuses it via stmtCache
+// instead of re-preparing on every call.
+func (ing *BulkArticleIngestor) UpsertOneFallback(ctx context.Context, article PendingArticle) error {
+	const upsertSQL = `
+		INSERT INTO articles (id, title, content, url, category, content_hash, simhash, simhash_band0, simhash_band1, simhash_band2, simhash_band3)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (url) DO NOTHING`
+
+	conn, err := ing.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	stmtName, err := ing.stmtCache.Prepare(ctx, conn.Conn(), upsertSQL)
+	if err != nil {
+		return fmt.Errorf("failed to prepare upsert statement: %w", err)
+	}
+
+	bands := models.SimHashBands(article.SimHash)
+	_, err = conn.Exec(ctx, stmtName, article.ID, article.Title, article.Content, article.URL, article.Category,
+		article.ContentHash, int64(article.SimHash), int32(bands[0]), int32(bands[1]), int32(bands[2]), int32(bands[3]))
+	if err != nil {
+		return fmt.Errorf("failed to upsert article: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/mmcdole/gofeed"
+	"github.com/temoto/robotstxt"
+)
+
+// ExtractedContent is the cleaned article body and metadata pulled from the
+// full page at an RSS item's link, since the item's own description/content
+// fields are frequently truncated summaries rather than the full article.
+type ExtractedContent struct {
+	PlainText    string
+	CanonicalURL string
+	Author       string
+	PublishedAt  *time.Time
+	LeadImage    string
+}
+
+// ContentExtractor fetches and extracts the full article body behind an RSS
+// item. Swappable so ScraperService's tests can avoid real network calls.
+type ContentExtractor interface {
+	Extract(ctx context.Context, item *gofeed.Item) (*ExtractedContent, error)
+}
+
+// defaultFetchTimeout bounds a single article page fetch.
+const defaultFetchTimeout = 15 * time.Second
+
+// defaultHostInterval is the minimum gap enforced between requests to the
+// same host, across both robots.txt lookups and page fetches.
+const defaultHostInterval = 2 * time.Second
+
+// minContentNodeChars skips scoring nodes too short to plausibly be the
+// main article body, cutting down on noise from captions and bylines.
+const minContentNodeChars = 140
+
+var whitespaceRegex = regexp.MustCompile(`\s+`)
+
+// HTTPContentExtractor fetches item.Link, respects robots.txt and a
+// per-host rate limit, and runs a Readability-style scoring pass over the
+// parsed HTML to pull out the main article body plus its metadata.
+type HTTPContentExtractor struct {
+	client       *http.Client
+	hostInterval time.Duration
+
+	mu          sync.Mutex
+	robotsCache map[string]*robotstxt.RobotsData
+	lastFetch   map[string]time.Time
+}
+
+// NewHTTPContentExtractor builds an extractor using client for fetches and
+// hostInterval as the minimum gap between requests to the same host. A nil
+// client and a non-positive hostInterval fall back to sane defaults.
+func NewHTTPContentExtractor(client *http.Client, hostInterval time.Duration) *HTTPContentExtractor {
+	if client == nil {
+		client = &http.Client{Timeout: defaultFetchTimeout}
+	}
+	if hostInterval <= 0 {
+		hostInterval = defaultHostInterval
+	}
+
+	return &HTTPContentExtractor{
+		client:       client,
+		hostInterval: hostInterval,
+		robotsCache:  make(map[string]*robotstxt.RobotsData),
+		lastFetch:    make(map[string]time.Time),
+	}
+}
+
+// Extract fetches item.Link and returns the main article body. It refuses
+// to fetch pages disallowed by robots.txt and returns an error for the
+// caller to fall back to the RSS item's own content.
+func (e *HTTPContentExtractor) Extract(ctx context.Context, item *gofeed.Item) (*ExtractedContent, error) {
+	if item.Link == "" {
+		return nil, fmt.Errorf("item has no link to fetch")
+	}
+
+	pageURL, err := url.Parse(item.Link)
+	if err != nil {
+		return nil, fmt.Errorf("invalid article URL %q: %w", item.Link, err)
+	}
+
+	if allowed, err := e.allowedByRobots(ctx, pageURL); err != nil {
+		log.Printf("⚠️ robots.txt check failed for %s: %v (continuing)", pageURL.Host, err)
+	} else if !allowed {
+		return nil, fmt.Errorf("robots.txt disallows fetching %s", item.Link)
+	}
+
+	e.waitForHost(pageURL.Host)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, item.Link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s: %w", item.Link, err)
+	}
+	req.Header.Set("User-Agent", "rec-mind-scraper/1.0 (+https://github.com/owenHochwald/rec-mind)")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", item.Link, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, item.Link)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML from %s: %w", item.Link, err)
+	}
+
+	doc.Find("script, style, nav, aside, footer, header, noscript, form, iframe").Remove()
+
+	extracted := &ExtractedContent{
+		PlainText:    extractMainContent(doc),
+		CanonicalURL: metaContent(doc, "link[rel='canonical']", "href"),
+		Author:       firstNonEmpty(metaContent(doc, "meta[name='author']", "content"), metaContent(doc, "meta[property='article:author']", "content")),
+		LeadImage:    metaContent(doc, "meta[property='og:image']", "content"),
+	}
+	if extracted.CanonicalURL == "" {
+		extracted.CanonicalURL = item.Link
+	}
+	if published := metaContent(doc, "meta[property='article:published_time']", "content"); published != "" {
+		if t, err := time.Parse(time.RFC3339, published); err == nil {
+			extracted.PublishedAt = &t
+		}
+	}
+
+	if extracted.PlainText == "" {
+		return nil, fmt.Errorf("no main content extracted from %s", item.Link)
+	}
+
+	return extracted, nil
+}
+
+// extractMainContent runs a Readability-style scoring pass over doc's
+// candidate content nodes: longer text and lower link density score
+// higher, so boilerplate (nav links, share widgets) loses to the actual
+// article body.
+func extractMainContent(doc *goquery.Document) string {
+	var bestNode *goquery.Selection
+	bestScore := 0.0
+
+	doc.Find("p, div, article, section").Each(func(_ int, node *goquery.Selection) {
+		text := strings.TrimSpace(node.Text())
+		if len(text) < minContentNodeChars {
+			return
+		}
+
+		linkChars := 0
+		node.Find("a").Each(func(_ int, a *goquery.Selection) {
+			linkChars += len(strings.TrimSpace(a.Text()))
+		})
+		linkDensity := float64(linkChars) / float64(len(text)+1)
+
+		if score := float64(len(text)) * (1 - linkDensity); score > bestScore {
+			bestScore = score
+			bestNode = node
+		}
+	})
+
+	if bestNode == nil {
+		return ""
+	}
+
+	return normalizeWhitespace(bestNode.Text())
+}
+
+func normalizeWhitespace(s string) string {
+	return strings.TrimSpace(whitespaceRegex.ReplaceAllString(s, " "))
+}
+
+func metaContent(doc *goquery.Document, selector, attr string) string {
+	val, _ := doc.Find(selector).First().Attr(attr)
+	return strings.TrimSpace(html.UnescapeString(val))
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// allowedByRobots fetches and caches robots.txt per host, defaulting to
+// allowed when it can't be fetched or parsed.
+func (e *HTTPContentExtractor) allowedByRobots(ctx context.Context, pageURL *url.URL) (bool, error) {
+	e.mu.Lock()
+	data, cached := e.robotsCache[pageURL.Host]
+	e.mu.Unlock()
+
+	if !cached {
+		robotsURL := fmt.Sprintf("%s://%s/robots.txt", pageURL.Scheme, pageURL.Host)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+		if err != nil {
+			return true, err
+		}
+
+		resp, err := e.client.Do(req)
+		if err != nil {
+			return true, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			data, _ = robotstxt.FromString("")
+		} else {
+			data, err = robotstxt.FromResponse(resp)
+			if err != nil {
+				return true, err
+			}
+		}
+
+		e.mu.Lock()
+		e.robotsCache[pageURL.Host] = data
+		e.mu.Unlock()
+	}
+
+	return data.TestAgent(pageURL.Path, "rec-mind-scraper"), nil
+}
+
+// waitForHost blocks until hostInterval has elapsed since the last request
+// to host, enforcing a simple per-host rate limit across all fetches.
+func (e *HTTPContentExtractor) waitForHost(host string) {
+	e.mu.Lock()
+	last, ok := e.lastFetch[host]
+	e.mu.Unlock()
+
+	if ok {
+		if wait := e.hostInterval - time.Since(last); wait > 0 {
+			time.Sleep(wait)
+		}
+	}
+
+	e.mu.Lock()
+	e.lastFetch[host] = time.Now()
+	e.mu.Unlock()
+}
+
+// MockContentExtractor returns a canned ExtractedContent (or Err) for
+// tests, without making any network calls.
+type MockContentExtractor struct {
+	Content *ExtractedContent
+	Err     error
+}
+
+func (m *MockContentExtractor) Extract(ctx context.Context, item *gofeed.Item) (*ExtractedContent, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Content, nil
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"rec-mind/internal/repository"
+	searchindex "rec-mind/internal/search"
+	"rec-mind/models"
+)
+
+// defaultRRFK is Reciprocal Rank Fusion's rank-damping constant: a match
+// at rank r contributes 1/(defaultRRFK+r), so a higher constant flattens
+// the gap between a top result and one further down the list. 60 is the
+// standard value from the original RRF paper and what most BM25+vector
+// fusion implementations default to.
+const defaultRRFK = 60
+
+// defaultHybridSearchLimit is how many hits HybridSearch pulls from each
+// leg (Bleve keywords and pgvector chunks) before fusing, whatever the
+// caller's k is.
+const defaultHybridSearchLimit = 50
+
+// HybridSearchResult is one article-level hit from HybridSearch, scored
+// by Reciprocal Rank Fusion across the keyword and vector legs.
+type HybridSearchResult struct {
+	ArticleID uuid.UUID `json:"article_id"`
+	Score     float64   `json:"score"`
+}
+
+// HybridSearchService fuses Bleve keyword retrieval over articles with
+// pgvector retrieval over article_chunks via Reciprocal Rank Fusion,
+// giving a quality lift on short/keyword-heavy queries where pure
+// embedding search underperforms.
+type HybridSearchService struct {
+	searchIndex searchindex.SearchIndex
+	chunkRepo   repository.ArticleChunkRepository
+}
+
+// NewHybridSearchService constructs a HybridSearchService. searchIndex is
+// the same Bleve index articleRepository keeps in sync with Postgres.
+func NewHybridSearchService(searchIndex searchindex.SearchIndex, chunkRepo repository.ArticleChunkRepository) *HybridSearchService {
+	return &HybridSearchService{searchIndex: searchIndex, chunkRepo: chunkRepo}
+}
+
+// HybridSearch runs query through Bleve for keyword retrieval and
+// queryEmbedding through the pgvector chunk search for vector retrieval,
+// then fuses the two ranked article lists with Reciprocal Rank Fusion.
+// queryEmbedding is supplied by the caller rather than computed here:
+// this repo never generates embeddings in Go (see mlclient.MLClient),
+// only consumes ones the ML service already produced. k caps how many
+// fused results are returned.
+func (s *HybridSearchService) HybridSearch(ctx context.Context, query string, queryEmbedding []float32, k int) ([]HybridSearchResult, error) {
+	if k <= 0 {
+		k = models.DefaultSearchTopK
+	}
+
+	keywordRanks, err := s.keywordRanks(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run keyword leg: %w", err)
+	}
+
+	vectorRanks, err := s.vectorRanks(ctx, queryEmbedding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run vector leg: %w", err)
+	}
+
+	fused := fuseReciprocalRank(defaultRRFK, keywordRanks, vectorRanks)
+	if len(fused) > k {
+		fused = fused[:k]
+	}
+	return fused, nil
+}
+
+// keywordRanks returns article IDs in Bleve's keyword-ranked order,
+// skipping any hit that isn't an article-type document or doesn't parse
+// as a UUID (chunk hits share the same index but aren't articles).
+func (s *HybridSearchService) keywordRanks(query string) ([]uuid.UUID, error) {
+	hits, err := s.searchIndex.Query(query, searchindex.SearchOptions{Limit: defaultHybridSearchLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := make([]uuid.UUID, 0, len(hits))
+	for _, hit := range hits {
+		if hit.Type != "article" {
+			continue
+		}
+		id, err := uuid.Parse(hit.ID)
+		if err != nil {
+			continue
+		}
+		ranks = append(ranks, id)
+	}
+	return ranks, nil
+}
+
+// vectorRanks returns article IDs in pgvector-ranked order, collapsing
+// chunk-level matches down to their owning article and keeping only the
+// first (best-scoring) occurrence of each.
+func (s *HybridSearchService) vectorRanks(ctx context.Context, queryEmbedding []float32) ([]uuid.UUID, error) {
+	matches, err := s.chunkRepo.SearchByEmbedding(ctx, queryEmbedding, &models.ArticleChunkSearchFilter{TopK: defaultHybridSearchLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool, len(matches))
+	ranks := make([]uuid.UUID, 0, len(matches))
+	for _, match := range matches {
+		if seen[match.ArticleID] {
+			continue
+		}
+		seen[match.ArticleID] = true
+		ranks = append(ranks, match.ArticleID)
+	}
+	return ranks, nil
+}
+
+// fuseReciprocalRank combines any number of ranked ID lists into one
+// descending-score list: score(id) = Σ 1/(rrfK+rank_i) over every list id
+// appears in, rank_i being its 1-indexed position in that list.
+func fuseReciprocalRank(rrfK int, rankedLists ...[]uuid.UUID) []HybridSearchResult {
+	scores := make(map[uuid.UUID]float64)
+	for _, list := range rankedLists {
+		for i, id := range list {
+			rank := i + 1
+			scores[id] += 1.0 / float64(rrfK+rank)
+		}
+	}
+
+	results := make([]HybridSearchResult, 0, len(scores))
+	for id, score := range scores {
+		results = append(results, HybridSearchResult{ArticleID: id, Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ArticleID.String() < results[j].ArticleID.String()
+	})
+
+	return results
+}
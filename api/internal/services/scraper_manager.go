@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"rec-mind/config"
+	"rec-mind/internal/feeds"
+	"rec-mind/models"
+)
+
+// defaultFeedReconcileInterval is how often GlobalScraperManager re-reads
+// the feed store to add, stop, or restart per-feed schedulers without a
+// process restart.
+const defaultFeedReconcileInterval = 30 * time.Second
+
+// feedLockTTLFloor bounds how long a feed's distributed scrape lock is held
+// even for a very short poll_interval, so a lock never outlives the next
+// tick by so much that a legitimately-due scrape gets skipped entirely.
+const feedLockTTLFloor = 10 * time.Second
+
+// runningFeed tracks one feed's scheduler goroutine, so reconcile can tell
+// whether a feed already has one running and whether its config changed
+// enough to warrant restarting it.
+type runningFeed struct {
+	cancel context.CancelFunc
+	config models.FeedConfig
+}
+
+// GlobalScraperManager replaces ScrapeAllFeeds' hardcoded, one-shot feed
+// list with a live set of per-feed scheduler goroutines driven by feeds.Store
+// instead of config.ScraperConfig.Feeds. Start loads every enabled feed and
+// begins polling it on its own poll_interval; a background ticker then
+// re-reads the store every defaultFeedReconcileInterval to add newly
+// created feeds, stop disabled/deleted ones, and restart ones whose URL or
+// poll_interval changed - all without restarting the process.
+type GlobalScraperManager struct {
+	scraper *ScraperService
+	store   *feeds.Store
+	redis   *goredis.Client
+
+	mu      sync.Mutex
+	running map[string]*runningFeed
+
+	resultsMu    sync.Mutex
+	latestResult *ScrapingResult
+}
+
+// NewGlobalScraperManager wires the manager to its dependencies.
+func NewGlobalScraperManager(scraper *ScraperService, store *feeds.Store, redisClient *goredis.Client) *GlobalScraperManager {
+	return &GlobalScraperManager{
+		scraper: scraper,
+		store:   store,
+		redis:   redisClient,
+		running: make(map[string]*runningFeed),
+	}
+}
+
+// Start runs an initial reconcile synchronously (so every enabled feed has
+// a scheduler before Start returns) and then reconciles again on a ticker
+// until ctx is cancelled.
+func (m *GlobalScraperManager) Start(ctx context.Context) {
+	m.reconcile(ctx)
+
+	go func() {
+		ticker := time.NewTicker(defaultFeedReconcileInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.reconcile(ctx)
+			}
+		}
+	}()
+}
+
+// reconcile diffs the feed store against the currently-running schedulers:
+// starting newly enabled feeds, restarting ones whose URL or poll_interval
+// changed, refreshing other field changes in place, and stopping ones that
+// were disabled or deleted.
+func (m *GlobalScraperManager) reconcile(ctx context.Context) {
+	feedConfigs, err := m.store.List(ctx)
+	if err != nil {
+		log.Printf("⚠️ Failed to list feed configs: %v", err)
+		return
+	}
+
+	seen := make(map[string]bool, len(feedConfigs))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, cfg := range feedConfigs {
+		seen[cfg.Slug] = true
+
+		existing, isRunning := m.running[cfg.Slug]
+		if !cfg.Enabled {
+			if isRunning {
+				existing.cancel()
+				delete(m.running, cfg.Slug)
+				log.Printf("⏸️ Stopped feed scheduler for %s (disabled)", cfg.Slug)
+			}
+			continue
+		}
+
+		if isRunning && existing.config.URL == cfg.URL && existing.config.PollInterval == cfg.PollInterval {
+			// Category/MaxArticles can change without a restart - the
+			// running loop reads m.running[slug].config fresh every tick.
+			existing.config = *cfg
+			continue
+		}
+
+		if isRunning {
+			existing.cancel()
+			log.Printf("🔄 Restarting feed scheduler for %s (config changed)", cfg.Slug)
+		}
+
+		feedCtx, cancel := context.WithCancel(ctx)
+		m.running[cfg.Slug] = &runningFeed{cancel: cancel, config: *cfg}
+		go m.runFeedLoop(feedCtx, cfg.Slug)
+		log.Printf("▶️ Started feed scheduler for %s (poll every %ds)", cfg.Slug, pollIntervalOrDefault(cfg.PollInterval))
+	}
+
+	for slug, rf := range m.running {
+		if !seen[slug] {
+			rf.cancel()
+			delete(m.running, slug)
+			log.Printf("🗑️ Stopped feed scheduler for %s (removed)", slug)
+		}
+	}
+}
+
+func pollIntervalOrDefault(seconds int) int {
+	if seconds <= 0 {
+		return models.DefaultFeedPollInterval
+	}
+	return seconds
+}
+
+// runFeedLoop drives one feed's poll_interval ticking until reconcile
+// cancels its context (the feed was disabled, deleted, or its config
+// changed enough to need a fresh goroutine).
+func (m *GlobalScraperManager) runFeedLoop(ctx context.Context, slug string) {
+	for {
+		m.mu.Lock()
+		rf, ok := m.running[slug]
+		m.mu.Unlock()
+		if !ok {
+			return
+		}
+
+		interval := time.Duration(pollIntervalOrDefault(rf.config.PollInterval)) * time.Second
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		m.mu.Lock()
+		rf, ok = m.running[slug]
+		m.mu.Unlock()
+		if !ok {
+			return
+		}
+
+		m.scrapeOnce(ctx, rf.config)
+	}
+}
+
+// scrapeOnce claims slug's distributed scrape lock before fetching it, so a
+// multi-replica deployment running the same poll schedule on every replica
+// doesn't scrape (and attempt to save) the same feed twice in the same
+// window. A replica that loses the race simply skips this tick.
+func (m *GlobalScraperManager) scrapeOnce(ctx context.Context, cfg models.FeedConfig) {
+	locked, err := m.acquireFeedLock(ctx, cfg.Slug, cfg.PollInterval)
+	if err != nil {
+		log.Printf("⚠️ Failed to acquire scrape lock for feed %s: %v", cfg.Slug, err)
+		return
+	}
+	if !locked {
+		log.Printf("⏭️ Feed %s is already being scraped by another replica, skipping", cfg.Slug)
+		return
+	}
+
+	result := m.scraper.scrapeFeedConcurrent(ctx, config.RSSFeed{Name: cfg.Name, URL: cfg.URL, Category: cfg.Category})
+	m.recordResult(result)
+}
+
+// acquireFeedLock SETNXs feed_lock:<slug> with a TTL scaled to the feed's
+// own poll_interval (floored at feedLockTTLFloor), so the lock naturally
+// expires before the next tick even if this replica never releases it
+// explicitly - the same TTL-instead-of-unlock idiom ArticleReaper's
+// idempotency key and TenantSemaphore's counter key both use.
+func (m *GlobalScraperManager) acquireFeedLock(ctx context.Context, slug string, pollIntervalSeconds int) (bool, error) {
+	ttl := time.Duration(pollIntervalOrDefault(pollIntervalSeconds)) * time.Second
+	if ttl < feedLockTTLFloor {
+		ttl = feedLockTTLFloor
+	}
+
+	return m.redis.SetNX(ctx, fmt.Sprintf("feed_lock:%s", slug), "1", ttl).Result()
+}
+
+// recordResult folds result into latestResult, replacing this feed's prior
+// entry (by name) rather than appending forever across every poll.
+func (m *GlobalScraperManager) recordResult(result FeedScrapingResult) {
+	m.resultsMu.Lock()
+	defer m.resultsMu.Unlock()
+
+	if m.latestResult == nil {
+		m.latestResult = &ScrapingResult{}
+	}
+
+	replaced := false
+	for i, existing := range m.latestResult.FeedResults {
+		if existing.FeedName == result.FeedName {
+			m.latestResult.FeedResults[i] = result
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		m.latestResult.FeedResults = append(m.latestResult.FeedResults, result)
+	}
+
+	m.latestResult.TotalFeeds = len(m.latestResult.FeedResults)
+	m.latestResult.TotalArticles = 0
+	m.latestResult.ProcessedCount = 0
+	m.latestResult.SkippedCount = 0
+	m.latestResult.ErrorCount = 0
+	for _, fr := range m.latestResult.FeedResults {
+		m.latestResult.TotalArticles += fr.ArticlesFound
+		m.latestResult.ProcessedCount += fr.ArticlesSaved
+		m.latestResult.SkippedCount += fr.ArticlesSkipped
+		m.latestResult.ErrorCount += len(fr.Errors)
+	}
+}
+
+// LatestResult returns the most recently aggregated scrape outcome across
+// every dynamically-scheduled feed, for ScrapeArticles to report without
+// triggering a fresh synchronous scrape of its own.
+func (m *GlobalScraperManager) LatestResult() *ScrapingResult {
+	m.resultsMu.Lock()
+	defer m.resultsMu.Unlock()
+
+	if m.latestResult == nil {
+		return &ScrapingResult{}
+	}
+
+	snapshot := *m.latestResult
+	snapshot.FeedResults = append([]FeedScrapingResult(nil), m.latestResult.FeedResults...)
+	return &snapshot
+}
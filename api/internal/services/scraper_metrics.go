@@ -0,0 +1,29 @@
+package services
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// scraperFeedFetchDuration, scraperFeedItemsTotal and
+// scraperFeedSkippedTotal give per-feed visibility into the concurrent
+// scraper, served on the existing /metrics Prometheus endpoint.
+var (
+	scraperFeedFetchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rec_mind_scraper_feed_fetch_duration_seconds",
+		Help:    "Time to fetch and parse a single RSS feed, labeled by feed name.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"feed"})
+
+	scraperFeedItemsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rec_mind_scraper_feed_items_total",
+		Help: "Total number of RSS items seen per feed.",
+	}, []string{"feed"})
+
+	// scraperFeedSkippedTotal's reason label is one of: not_modified,
+	// backoff, fetch_error, parse_error, invalid_url.
+	scraperFeedSkippedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rec_mind_scraper_feed_skipped_total",
+		Help: "Total number of feed scrapes skipped, labeled by feed name and reason.",
+	}, []string{"feed", "reason"})
+)
@@ -4,18 +4,37 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/mmcdole/gofeed"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"golang.org/x/time/rate"
+
+	"rec-mind/config"
+	"rec-mind/internal/analytics"
+	"rec-mind/internal/database"
+	"rec-mind/internal/outbox"
+	"rec-mind/internal/repository"
+	"rec-mind/models"
+	"rec-mind/mq"
+)
 
-	"github.com/owenHochwald/rec-mind-api/config"
-	"github.com/owenHochwald/rec-mind-api/internal/database"
-	"github.com/owenHochwald/rec-mind-api/internal/repository"
+// articleProcessingQueue and articleProcessingDLQ name the durable queue
+// pair publishArticleProcessingMessage publishes to: messages that
+// outlive articleProcessingTTL without being consumed are dead-lettered
+// into the DLQ instead of being lost.
+const (
+	articleProcessingQueue = "article_processing"
+	articleProcessingDLQ   = "article_processing.dlq"
+	articleProcessingTTL   = 24 * time.Hour
 )
 
 // ArticleProcessingMessage represents the message sent to RabbitMQ for article processing
@@ -29,12 +48,36 @@ type ArticleProcessingMessage struct {
 	CreatedAt     time.Time `json:"created_at"`
 }
 
+// defaultMaxConcurrentFeeds bounds how many feeds ScrapeAllFeeds fetches at
+// once when config.ScraperConfig.MaxConcurrentFeeds isn't set.
+const defaultMaxConcurrentFeeds = 5
+
+// defaultHostRateLimitInterval is the minimum gap between requests to the
+// same host when config.ScraperConfig.RateLimit.DelaySeconds isn't set.
+const defaultHostRateLimitInterval = 2 * time.Second
+
+// baseFeedBackoff and maxFeedBackoff bound the exponential backoff applied
+// to a feed after consecutive fetch/parse failures.
+const (
+	baseFeedBackoff = 1 * time.Minute
+	maxFeedBackoff  = 6 * time.Hour
+)
+
 // ScraperService handles RSS feed scraping and article processing
 type ScraperService struct {
-	repo      repository.ArticleRepository
-	mqChannel *amqp.Channel
-	config    config.ScraperConfig
-	parser    *gofeed.Parser
+	repo          repository.ArticleRepository
+	feedStateRepo repository.FeedStateRepository
+	mqChannel     *amqp.Channel
+	config        config.ScraperConfig
+	parser        *gofeed.Parser
+	extractor     ContentExtractor
+	publisher     *outbox.Publisher
+	httpClient    *http.Client
+
+	hostLimitersMu sync.Mutex
+	hostLimiters   map[string]*rate.Limiter
+
+	analyticsClient analytics.Analytics
 }
 
 // ScrapingResult represents the result of a scraping operation
@@ -60,84 +103,226 @@ type FeedScrapingResult struct {
 	ProcessingTime time.Duration `json:"processing_time"`
 }
 
-// NewScraperService creates a new scraper service
-func NewScraperService(repo repository.ArticleRepository, mqChannel *amqp.Channel) *ScraperService {
+// NewScraperService creates a new scraper service. extractor fetches each
+// item's full page for its article body; pass an HTTPContentExtractor in
+// production or a MockContentExtractor in tests. publisher makes article
+// processing messages durable (confirms, retries, outbox fallback)
+// instead of the fire-and-forget publish this service used to do directly.
+// feedStateRepo tracks conditional-GET and backoff state per feed; it may be
+// nil, in which case every feed is fetched fresh on every run. analyticsClient
+// records each feed's outcome as a feed_events row; pass analytics.New's
+// result, which is a no-op when analytics is disabled.
+func NewScraperService(repo repository.ArticleRepository, mqChannel *amqp.Channel, extractor ContentExtractor, publisher *outbox.Publisher, feedStateRepo repository.FeedStateRepository, analyticsClient analytics.Analytics) *ScraperService {
 	return &ScraperService{
-		repo:      repo,
-		mqChannel: mqChannel,
-		config:    config.GetScraperConfig(),
-		parser:    gofeed.NewParser(),
+		repo:            repo,
+		feedStateRepo:   feedStateRepo,
+		mqChannel:       mqChannel,
+		config:          config.GetScraperConfig(),
+		parser:          gofeed.NewParser(),
+		extractor:       extractor,
+		publisher:       publisher,
+		analyticsClient: analyticsClient,
+		httpClient:      &http.Client{Timeout: 30 * time.Second},
+		hostLimiters:    make(map[string]*rate.Limiter),
 	}
 }
 
-// ScrapeAllFeeds scrapes all configured RSS feeds
+// ScrapeAllFeeds scrapes all configured RSS feeds and blocks until every
+// feed has been processed. It's a thin synchronous wrapper around
+// ScrapeAllFeedsStreaming for callers that just want the final tally.
 func (s *ScraperService) ScrapeAllFeeds(ctx context.Context) (*ScrapingResult, error) {
 	startTime := time.Now()
-	
-	result := &ScrapingResult{
-		TotalFeeds:    len(s.config.Feeds),
-		FeedResults:   make([]FeedScrapingResult, 0, len(s.config.Feeds)),
+
+	results, err := s.ScrapeAllFeedsStreaming(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	log.Printf("🔍 Starting to scrape %d RSS feeds", len(s.config.Feeds))
+	result := s.collectScrapingResults(results)
+	result.ProcessingTime = time.Since(startTime)
 
-	// Declare the article_processing queue
-	if err := s.declareQueue("article_processing"); err != nil {
-		return nil, fmt.Errorf("failed to declare article_processing queue: %w", err)
-	}
+	log.Printf("✅ Scraping completed: %d total articles, %d processed, %d skipped, %d errors in %v",
+		result.TotalArticles, result.ProcessedCount, result.SkippedCount, result.ErrorCount, result.ProcessingTime)
 
-	for i, feed := range s.config.Feeds {
-		// Apply rate limiting between feeds
-		if i > 0 {
-			time.Sleep(time.Duration(s.config.RateLimit.DelaySeconds) * time.Second)
-		}
+	return result, nil
+}
 
-		feedResult := s.scrapeFeed(ctx, feed)
+// collectScrapingResults drains a FeedScrapingResult channel into the
+// aggregate ScrapingResult shape, logging each feed as it arrives.
+func (s *ScraperService) collectScrapingResults(results <-chan FeedScrapingResult) *ScrapingResult {
+	result := &ScrapingResult{
+		TotalFeeds:  len(s.config.Feeds),
+		FeedResults: make([]FeedScrapingResult, 0, len(s.config.Feeds)),
+	}
+
+	for feedResult := range results {
 		result.FeedResults = append(result.FeedResults, feedResult)
 		result.TotalArticles += feedResult.ArticlesFound
 		result.ProcessedCount += feedResult.ArticlesSaved
 		result.SkippedCount += feedResult.ArticlesSkipped
 		result.ErrorCount += len(feedResult.Errors)
 
-		log.Printf("📰 Feed '%s': %d articles found, %d saved, %d skipped, %d errors", 
-			feed.Name, feedResult.ArticlesFound, feedResult.ArticlesSaved, 
+		log.Printf("📰 Feed '%s': %d articles found, %d saved, %d skipped, %d errors",
+			feedResult.FeedName, feedResult.ArticlesFound, feedResult.ArticlesSaved,
 			feedResult.ArticlesSkipped, len(feedResult.Errors))
 	}
 
-	result.ProcessingTime = time.Since(startTime)
-	
-	log.Printf("✅ Scraping completed: %d total articles, %d processed, %d skipped, %d errors in %v",
-		result.TotalArticles, result.ProcessedCount, result.SkippedCount, result.ErrorCount, result.ProcessingTime)
+	return result
+}
 
-	return result, nil
+// ScrapeAllFeedsStreaming scrapes all configured RSS feeds concurrently
+// (bounded by config.ScraperConfig.MaxConcurrentFeeds, falling back to
+// defaultMaxConcurrentFeeds), streaming each feed's FeedScrapingResult back
+// as soon as it's done instead of waiting for every feed to finish. The
+// channel is closed once all feeds have been processed; if ctx is cancelled,
+// feeds not yet started are skipped and it closes once the started ones finish.
+func (s *ScraperService) ScrapeAllFeedsStreaming(ctx context.Context) (<-chan FeedScrapingResult, error) {
+	log.Printf("🔍 Starting to scrape %d RSS feeds", len(s.config.Feeds))
+
+	// Declare the article_processing queue (with its DLQ and TTL, when a
+	// durable publisher is configured).
+	if s.publisher != nil {
+		if err := s.publisher.DeclareDurableQueue(articleProcessingQueue, articleProcessingDLQ, articleProcessingTTL); err != nil {
+			return nil, fmt.Errorf("failed to declare article_processing queue: %w", err)
+		}
+	} else if err := s.declareQueue(articleProcessingQueue); err != nil {
+		return nil, fmt.Errorf("failed to declare article_processing queue: %w", err)
+	}
+
+	maxConcurrent := s.config.MaxConcurrentFeeds
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrentFeeds
+	}
+
+	results := make(chan FeedScrapingResult, len(s.config.Feeds))
+
+	go func() {
+		defer close(results)
+
+		sem := make(chan struct{}, maxConcurrent)
+		var wg sync.WaitGroup
+
+		for _, feed := range s.config.Feeds {
+			feed := feed
+
+			select {
+			case <-ctx.Done():
+				wg.Wait()
+				return
+			case sem <- struct{}{}:
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				results <- s.scrapeFeedConcurrent(ctx, feed)
+			}()
+		}
+
+		wg.Wait()
+	}()
+
+	return results, nil
 }
 
-// scrapeFeed scrapes a single RSS feed
-func (s *ScraperService) scrapeFeed(ctx context.Context, feedConfig config.RSSFeed) FeedScrapingResult {
+// fetchedFeed is the raw result of conditionalFetch: the response body plus
+// the cache-validator headers needed to update feed_state.
+type fetchedFeed struct {
+	content      []byte
+	etag         string
+	lastModified string
+}
+
+// scrapeFeedConcurrent scrapes a single RSS feed, honoring per-feed backoff
+// and per-host rate limiting, and skipping the parse entirely on a 304.
+func (s *ScraperService) scrapeFeedConcurrent(ctx context.Context, feedConfig config.RSSFeed) (result FeedScrapingResult) {
 	startTime := time.Now()
-	
-	result := FeedScrapingResult{
+
+	result = FeedScrapingResult{
 		FeedName: feedConfig.Name,
 		FeedURL:  feedConfig.URL,
 		Category: feedConfig.Category,
 		Errors:   make([]string, 0),
 	}
 
+	defer func() {
+		s.analyticsClient.Index(analytics.FeedEvent{
+			FeedName:         result.FeedName,
+			Category:         result.Category,
+			ArticlesFound:    result.ArticlesFound,
+			ArticlesSaved:    result.ArticlesSaved,
+			ArticlesSkipped:  result.ArticlesSkipped,
+			ErrorCount:       len(result.Errors),
+			ProcessingTimeMS: result.ProcessingTime.Milliseconds(),
+			CreatedAt:        time.Now(),
+		})
+	}()
+
+	var state *repository.FeedState
+	if s.feedStateRepo != nil {
+		if fetched, err := s.feedStateRepo.Get(ctx, feedConfig.URL); err == nil {
+			state = fetched
+		}
+	}
+
+	if state != nil && !state.BackoffUntil.IsZero() && time.Now().Before(state.BackoffUntil) {
+		scraperFeedSkippedTotal.WithLabelValues(feedConfig.Name, "backoff").Inc()
+		log.Printf("⏭️ Feed '%s' is backing off until %v, skipping", feedConfig.Name, state.BackoffUntil)
+		result.ProcessingTime = time.Since(startTime)
+		return result
+	}
+
+	host, err := s.hostOf(feedConfig.URL)
+	if err != nil {
+		scraperFeedSkippedTotal.WithLabelValues(feedConfig.Name, "invalid_url").Inc()
+		result.Errors = append(result.Errors, fmt.Sprintf("Invalid feed URL %s: %v", feedConfig.URL, err))
+		result.ProcessingTime = time.Since(startTime)
+		return result
+	}
+	if err := s.limiterFor(host).Wait(ctx); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("Rate limiter wait cancelled for %s: %v", feedConfig.URL, err))
+		result.ProcessingTime = time.Since(startTime)
+		return result
+	}
+
 	log.Printf("📡 Scraping feed: %s (%s)", feedConfig.Name, feedConfig.URL)
 
-	// Parse the RSS feed
-	feed, err := s.parser.ParseURL(feedConfig.URL)
+	fetchStart := time.Now()
+	fetched, notModified, err := s.conditionalFetch(ctx, feedConfig.URL, state)
+	scraperFeedFetchDuration.WithLabelValues(feedConfig.Name).Observe(time.Since(fetchStart).Seconds())
 	if err != nil {
+		scraperFeedSkippedTotal.WithLabelValues(feedConfig.Name, "fetch_error").Inc()
+		errMsg := fmt.Sprintf("Failed to fetch RSS feed %s: %v", feedConfig.URL, err)
+		result.Errors = append(result.Errors, errMsg)
+		log.Printf("❌ %s", errMsg)
+		s.recordFeedFailure(ctx, feedConfig.URL, state)
+		result.ProcessingTime = time.Since(startTime)
+		return result
+	}
+
+	if notModified {
+		scraperFeedSkippedTotal.WithLabelValues(feedConfig.Name, "not_modified").Inc()
+		log.Printf("📭 Feed '%s' not modified since last fetch, skipping", feedConfig.Name)
+		s.recordFeedSuccess(ctx, feedConfig.URL, fetched.etag, fetched.lastModified)
+		result.ProcessingTime = time.Since(startTime)
+		return result
+	}
+
+	feed, err := s.parser.ParseString(string(fetched.content))
+	if err != nil {
+		scraperFeedSkippedTotal.WithLabelValues(feedConfig.Name, "parse_error").Inc()
 		errMsg := fmt.Sprintf("Failed to parse RSS feed %s: %v", feedConfig.URL, err)
 		result.Errors = append(result.Errors, errMsg)
 		log.Printf("❌ %s", errMsg)
+		s.recordFeedFailure(ctx, feedConfig.URL, state)
 		result.ProcessingTime = time.Since(startTime)
 		return result
 	}
 
 	result.ArticlesFound = len(feed.Items)
+	scraperFeedItemsTotal.WithLabelValues(feedConfig.Name).Add(float64(len(feed.Items)))
 
-	// Process each article in the feed
 	for _, item := range feed.Items {
 		select {
 		case <-ctx.Done():
@@ -158,15 +343,146 @@ func (s *ScraperService) scrapeFeed(ctx context.Context, feedConfig config.RSSFe
 
 	result.ArticlesSkipped = result.ArticlesFound - result.ArticlesSaved
 	result.ProcessingTime = time.Since(startTime)
-	
+
+	s.recordFeedSuccess(ctx, feedConfig.URL, fetched.etag, fetched.lastModified)
+
 	return result
 }
 
+// conditionalFetch GETs url, sending If-None-Match/If-Modified-Since from
+// state when available so an unchanged feed comes back as a cheap 304
+// instead of a full body. notModified is true only on an actual 304.
+func (s *ScraperService) conditionalFetch(ctx context.Context, url string, state *repository.FeedState) (*fetchedFeed, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	if state != nil {
+		if state.ETag != "" {
+			req.Header.Set("If-None-Match", state.ETag)
+		}
+		if state.LastModified != "" {
+			req.Header.Set("If-Modified-Since", state.LastModified)
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return &fetchedFeed{etag: resp.Header.Get("ETag"), lastModified: resp.Header.Get("Last-Modified")}, true, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &fetchedFeed{
+		content:      body,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}, false, nil
+}
+
+// limiterFor lazily creates and caches a per-host rate.Limiter so
+// concurrent feeds on the same host are still throttled to one request
+// per interval, while feeds on different hosts run unimpeded.
+func (s *ScraperService) limiterFor(host string) *rate.Limiter {
+	s.hostLimitersMu.Lock()
+	defer s.hostLimitersMu.Unlock()
+
+	if limiter, ok := s.hostLimiters[host]; ok {
+		return limiter
+	}
+
+	interval := time.Duration(s.config.RateLimit.DelaySeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultHostRateLimitInterval
+	}
+
+	limiter := rate.NewLimiter(rate.Every(interval), 1)
+	s.hostLimiters[host] = limiter
+	return limiter
+}
+
+// hostOf extracts the host component used to key per-host rate limiters.
+func (s *ScraperService) hostOf(rawURL string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("URL has no host: %s", rawURL)
+	}
+	return parsed.Host, nil
+}
+
+// recordFeedFailure increments the feed's failure count and pushes
+// backoff_until out exponentially (capped at maxFeedBackoff), so a feed
+// that's erroring repeatedly is retried less and less often.
+func (s *ScraperService) recordFeedFailure(ctx context.Context, feedURL string, prior *repository.FeedState) {
+	if s.feedStateRepo == nil {
+		return
+	}
+
+	failureCount := 1
+	if prior != nil {
+		failureCount = prior.FailureCount + 1
+	}
+
+	backoff := baseFeedBackoff << uint(failureCount-1)
+	if backoff <= 0 || backoff > maxFeedBackoff {
+		backoff = maxFeedBackoff
+	}
+
+	state := &repository.FeedState{
+		URL:           feedURL,
+		LastFetchedAt: time.Now(),
+		FailureCount:  failureCount,
+		BackoffUntil:  time.Now().Add(backoff),
+	}
+	if prior != nil {
+		state.ETag = prior.ETag
+		state.LastModified = prior.LastModified
+	}
+
+	if err := s.feedStateRepo.Upsert(ctx, state); err != nil {
+		log.Printf("⚠️ Failed to record feed failure for %s: %v", feedURL, err)
+	}
+}
+
+// recordFeedSuccess stores the new validator headers and clears backoff.
+func (s *ScraperService) recordFeedSuccess(ctx context.Context, feedURL, etag, lastModified string) {
+	if s.feedStateRepo == nil {
+		return
+	}
+
+	state := &repository.FeedState{
+		URL:           feedURL,
+		ETag:          etag,
+		LastModified:  lastModified,
+		LastFetchedAt: time.Now(),
+	}
+
+	if err := s.feedStateRepo.Upsert(ctx, state); err != nil {
+		log.Printf("⚠️ Failed to record feed success for %s: %v", feedURL, err)
+	}
+}
+
 // processArticle processes a single article from RSS feed
 func (s *ScraperService) processArticle(ctx context.Context, item *gofeed.Item, category string) error {
 	// Clean and extract content
 	title := s.cleanText(item.Title)
-	content := s.extractContent(item)
+	content := s.extractFullContent(ctx, item)
 	url := item.Link
 
 	// Validate article
@@ -183,6 +499,26 @@ func (s *ScraperService) processArticle(ctx context.Context, item *gofeed.Item,
 		return fmt.Errorf("article already exists with URL: %s", url)
 	}
 
+	// Short-circuit on an exact content match (e.g. the same story synced
+	// from two feeds) before bothering with near-duplicate detection.
+	contentHash := models.ContentHash(content)
+	if dup, err := s.repo.ExistsByContentHash(ctx, contentHash); err != nil {
+		return fmt.Errorf("failed to check content hash duplicate: %w", err)
+	} else if dup {
+		return fmt.Errorf("article already exists with matching content hash")
+	}
+
+	// Catch syndicated copies and mirrors with a different URL and minor
+	// edits, which content_hash's exact match can't see.
+	simhash := models.SimHash(content)
+	near, err := s.repo.FindNearDuplicates(ctx, simhash, s.config.Dedup.SimhashHammingRadius)
+	if err != nil {
+		return fmt.Errorf("failed to check near-duplicates: %w", err)
+	}
+	if len(near) > 0 {
+		return fmt.Errorf("article is a near-duplicate of existing article %s", near[0].ID)
+	}
+
 	// Create article in database
 	articleReq := &database.CreateArticleRequest{
 		Title:    title,
@@ -197,11 +533,20 @@ func (s *ScraperService) processArticle(ctx context.Context, item *gofeed.Item,
 	}
 
 	// Publish to RabbitMQ for ML processing
-	if err := s.publishArticleProcessingMessage(article); err != nil {
+	if err := s.publishArticleProcessingMessage(ctx, article); err != nil {
 		log.Printf("⚠️ Failed to publish article processing message for %s: %v", article.ID, err)
 		// Don't return error - article is already saved
 	}
 
+	// Publish article.created so ArticleEventsWorker enqueues embedding
+	// generation, the same way ArticleService.CreateArticleWithAsyncEmbedding
+	// does for the HTTP upload path - the scraper path had no embedding
+	// trigger at all before this event existed.
+	if err := mq.PublishArticleCreated(models.ArticleCreatedEvent{ArticleID: article.ID.String(), CreatedAt: article.CreatedAt}); err != nil {
+		log.Printf("⚠️ Failed to publish article.created event for %s: %v", article.ID, err)
+		// Don't return error - article is already saved
+	}
+
 	log.Printf("✅ Processed article: %s (ID: %s)", title, article.ID)
 	return nil
 }
@@ -242,47 +587,38 @@ func (s *ScraperService) cleanText(text string) string {
 	return cleaned
 }
 
-// extractContent extracts and cleans article content from RSS item
-func (s *ScraperService) extractContent(item *gofeed.Item) string {
-	var content string
-	
-	// Prefer content over description
-	if item.Content != "" {
-		content = item.Content
-	} else if item.Description != "" {
-		content = item.Description
-	} else {
-		content = item.Title // Fallback to title if no content
+// extractFullContent fetches item.Link through s.extractor for the full
+// article body, falling back to the RSS item's own (often truncated)
+// content/description when the fetch fails or no extractor is configured.
+func (s *ScraperService) extractFullContent(ctx context.Context, item *gofeed.Item) string {
+	if s.extractor != nil {
+		extracted, err := s.extractor.Extract(ctx, item)
+		if err == nil {
+			return s.cleanText(extracted.PlainText)
+		}
+		log.Printf("⚠️ Content extraction failed for %s, falling back to feed content: %v", item.Link, err)
 	}
 
-	// Clean the content
-	cleaned := s.cleanText(content)
-	
-	// Remove common ads and navigation text patterns
-	adPatterns := []string{
-		"Advertisement",
-		"Click here",
-		"Read more",
-		"Subscribe",
-		"Newsletter",
-		"Follow us",
-		"Share this",
-		"Related articles",
-		"Trending now",
+	return s.cleanText(s.rssFallbackContent(item))
+}
+
+// rssFallbackContent picks the best available content already present on
+// the RSS item, used when the full-page fetch can't be completed.
+func (s *ScraperService) rssFallbackContent(item *gofeed.Item) string {
+	if item.Content != "" {
+		return item.Content
 	}
-	
-	for _, pattern := range adPatterns {
-		cleaned = strings.ReplaceAll(cleaned, pattern, "")
+	if item.Description != "" {
+		return item.Description
 	}
-	
-	// Final cleanup
-	cleaned = strings.TrimSpace(cleaned)
-	
-	return cleaned
+	return item.Title
 }
 
-// publishArticleProcessingMessage publishes article to RabbitMQ for ML processing
-func (s *ScraperService) publishArticleProcessingMessage(article *database.Article) error {
+// publishArticleProcessingMessage publishes article to RabbitMQ for ML
+// processing. When s.publisher is configured it goes through publisher
+// confirms with retry and an outbox fallback; otherwise it falls back to a
+// direct best-effort publish.
+func (s *ScraperService) publishArticleProcessingMessage(ctx context.Context, article *database.Article) error {
 	message := ArticleProcessingMessage{
 		ArticleID:     article.ID,
 		Title:         article.Title,
@@ -298,19 +634,29 @@ func (s *ScraperService) publishArticleProcessingMessage(article *database.Artic
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
+	headers := amqp.Table{
+		"correlation_id": message.CorrelationID,
+		"article_id":     message.ArticleID.String(),
+		"category":       message.Category,
+	}
+
+	if s.publisher != nil {
+		if err := s.publisher.Publish(ctx, articleProcessingQueue, messageBody, headers); err != nil {
+			return fmt.Errorf("failed to publish message: %w", err)
+		}
+		log.Printf("📤 Published article processing message for article %s", article.ID)
+		return nil
+	}
+
 	err = s.mqChannel.Publish(
-		"",                   // exchange
-		"article_processing", // routing key (queue name)
-		false,                // mandatory
-		false,                // immediate
+		"",                     // exchange
+		articleProcessingQueue, // routing key (queue name)
+		false,                  // mandatory
+		false,                  // immediate
 		amqp.Publishing{
 			ContentType: "application/json",
 			Body:        messageBody,
-			Headers: amqp.Table{
-				"correlation_id": message.CorrelationID,
-				"article_id":     message.ArticleID.String(),
-				"category":       message.Category,
-			},
+			Headers:     headers,
 		},
 	)
 
@@ -0,0 +1,152 @@
+// Package storage provides an S3-compatible object store client used to
+// persist in-progress resumable uploads before they're chunked and embedded.
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// ObjectStore wraps an S3-compatible client (AWS S3, MinIO, R2, ...) scoped
+// to a single bucket, configured entirely from the environment so the
+// resumable upload endpoint doesn't hardcode a provider.
+type ObjectStore struct {
+	client *s3.Client
+	bucket string
+}
+
+// NewObjectStore builds an ObjectStore from S3_* environment variables.
+// S3_ENDPOINT is optional; when unset the AWS SDK's default resolver is used
+// (real S3), otherwise it points at a compatible endpoint such as MinIO.
+func NewObjectStore(ctx context.Context) (*ObjectStore, error) {
+	bucket := os.Getenv("S3_BUCKET")
+	if bucket == "" {
+		return nil, fmt.Errorf("S3_BUCKET is not set")
+	}
+
+	region := os.Getenv("S3_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	optFns := []func(*config.LoadOptions) error{
+		config.WithRegion(region),
+	}
+
+	if accessKey, secretKey := os.Getenv("S3_ACCESS_KEY"), os.Getenv("S3_SECRET_KEY"); accessKey != "" && secretKey != "" {
+		optFns = append(optFns, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(accessKey, secretKey, ""),
+		))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint := os.Getenv("S3_ENDPOINT"); endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	return &ObjectStore{client: client, bucket: bucket}, nil
+}
+
+// CreateMultipartUpload starts a new multipart upload and returns its S3
+// upload ID, which must be threaded through every subsequent part/complete call.
+func (s *ObjectStore) CreateMultipartUpload(ctx context.Context, key string) (string, error) {
+	out, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create multipart upload: %w", err)
+	}
+	return aws.ToString(out.UploadId), nil
+}
+
+// UploadPart uploads a single part of an in-progress multipart upload and
+// returns the ETag S3 assigns it, which is required to complete the upload.
+func (s *ObjectStore) UploadPart(ctx context.Context, key, s3UploadID string, partNumber int32, body []byte) (string, error) {
+	out, err := s.client.UploadPart(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(s.bucket),
+		Key:        aws.String(key),
+		UploadId:   aws.String(s3UploadID),
+		PartNumber: aws.Int32(partNumber),
+		Body:       bytes.NewReader(body),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload part %d: %w", partNumber, err)
+	}
+	return aws.ToString(out.ETag), nil
+}
+
+// CompletedPart mirrors the subset of s3 types the caller needs to supply
+// without importing the SDK directly.
+type CompletedPart struct {
+	PartNumber int32
+	ETag       string
+}
+
+// CompleteMultipartUpload finalizes the upload, assembling all committed
+// parts (in order) into a single object at key.
+func (s *ObjectStore) CompleteMultipartUpload(ctx context.Context, key, s3UploadID string, parts []CompletedPart) error {
+	completed := make([]s3types.CompletedPart, len(parts))
+	for i, p := range parts {
+		completed[i] = s3types.CompletedPart{
+			PartNumber: aws.Int32(p.PartNumber),
+			ETag:       aws.String(p.ETag),
+		}
+	}
+
+	_, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(s3UploadID),
+		MultipartUpload: &s3types.CompletedMultipartUpload{
+			Parts: completed,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return nil
+}
+
+// AbortMultipartUpload discards an in-progress upload and its parts.
+func (s *ObjectStore) AbortMultipartUpload(ctx context.Context, key, s3UploadID string) error {
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(s3UploadID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
+	return nil
+}
+
+// GetObject fetches a finalized object's full content.
+func (s *ObjectStore) GetObject(ctx context.Context, key string) ([]byte, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", key, err)
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(out.Body)
+}
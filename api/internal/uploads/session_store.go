@@ -0,0 +1,79 @@
+// Package uploads persists resumable article upload sessions in Redis so
+// that progress survives across requests (and API server restarts) until
+// the upload is finalized or its TTL expires.
+package uploads
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"rec-mind/models"
+)
+
+// sessionTTL bounds how long an abandoned upload session is retained before
+// Redis reclaims it and the underlying S3 multipart upload is left to expire
+// via the bucket's lifecycle policy.
+const sessionTTL = 24 * time.Hour
+
+func sessionKey(uploadID string) string {
+	return fmt.Sprintf("upload_session:%s", uploadID)
+}
+
+// SessionStore reads and writes UploadSession state in Redis.
+type SessionStore struct {
+	redis *goredis.Client
+}
+
+// NewSessionStore creates a SessionStore backed by the given Redis client.
+func NewSessionStore(redisClient *goredis.Client) *SessionStore {
+	return &SessionStore{redis: redisClient}
+}
+
+// Create persists a brand-new upload session.
+func (s *SessionStore) Create(ctx context.Context, session *models.UploadSession) error {
+	return s.save(ctx, session)
+}
+
+// Get fetches a session by upload ID.
+func (s *SessionStore) Get(ctx context.Context, uploadID string) (*models.UploadSession, error) {
+	raw, err := s.redis.Get(ctx, sessionKey(uploadID)).Result()
+	if err != nil {
+		if err == goredis.Nil {
+			return nil, fmt.Errorf("upload session not found")
+		}
+		return nil, fmt.Errorf("failed to fetch upload session: %w", err)
+	}
+
+	var session models.UploadSession
+	if err := json.Unmarshal([]byte(raw), &session); err != nil {
+		return nil, fmt.Errorf("failed to parse upload session: %w", err)
+	}
+	return &session, nil
+}
+
+// Update overwrites a session's stored state, refreshing its TTL.
+func (s *SessionStore) Update(ctx context.Context, session *models.UploadSession) error {
+	session.UpdatedAt = time.Now()
+	return s.save(ctx, session)
+}
+
+// Delete removes a session, e.g. once it's been finalized or aborted.
+func (s *SessionStore) Delete(ctx context.Context, uploadID string) error {
+	return s.redis.Del(ctx, sessionKey(uploadID)).Err()
+}
+
+func (s *SessionStore) save(ctx context.Context, session *models.UploadSession) error {
+	body, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal upload session: %w", err)
+	}
+
+	if err := s.redis.Set(ctx, sessionKey(session.UploadID), body, sessionTTL).Err(); err != nil {
+		return fmt.Errorf("failed to store upload session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package worker
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"rec-mind/internal/database"
+)
+
+// Aggregator combines the chunk-search results collected for a job into a
+// ranked list of article recommendations. RAGWorker selects one per job via
+// database.RecommendationJob.AggregationMode, so ranking strategies can be
+// swapped (and A/B tested) without touching the fan-out/collection code in
+// runSearches.
+type Aggregator interface {
+	Aggregate(results []SearchResultMessage) []database.ArticleRecommendation
+}
+
+// resolveAggregator returns the Aggregator for a job's requested mode,
+// defaulting to the hybrid scorer when the mode is empty or unrecognized.
+func resolveAggregator(mode database.AggregationMode) Aggregator {
+	switch mode {
+	case database.AggregationRRF:
+		return NewRRFAggregator()
+	default:
+		return NewHybridAggregator()
+	}
+}
+
+// groupMatchesByArticle collects every chunk match across all search
+// results, keyed by the article it matched, in the order results arrived.
+func groupMatchesByArticle(results []SearchResultMessage) map[uuid.UUID][]database.ChunkMatch {
+	articleMatches := make(map[uuid.UUID][]database.ChunkMatch)
+	for _, result := range results {
+		if result.Response == nil {
+			continue
+		}
+		for _, searchResult := range result.Response.Results {
+			articleMatches[searchResult.ArticleID] = append(articleMatches[searchResult.ArticleID], database.ChunkMatch{
+				ChunkID:        uuid.MustParse(searchResult.ChunkID),
+				Score:          searchResult.SimilarityScore,
+				ChunkIndex:     searchResult.ChunkIndex,
+				ContentPreview: searchResult.ContentPreview,
+			})
+		}
+	}
+	return articleMatches
+}
+
+func calculateSimilarityStats(matches []database.ChunkMatch) (float64, float64) {
+	if len(matches) == 0 {
+		return 0, 0
+	}
+
+	scores := make([]float64, len(matches))
+	for i, match := range matches {
+		scores[i] = match.Score
+	}
+
+	return slices.Max(scores), calculateMean(scores)
+}
+
+func calculateMean(scores []float64) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, score := range scores {
+		sum += score
+	}
+	return sum / float64(len(scores))
+}
+
+// HybridAggregator ranks articles by a weighted blend of their best and
+// average chunk similarity, plus a coverage bonus of min(chunks/3, 0.2),
+// which is already at its 0.2 cap with a single matching chunk. This is the
+// original, and still default, ranking strategy.
+type HybridAggregator struct{}
+
+// NewHybridAggregator constructs the weighted similarity aggregator.
+func NewHybridAggregator() *HybridAggregator {
+	return &HybridAggregator{}
+}
+
+func (a *HybridAggregator) Aggregate(results []SearchResultMessage) []database.ArticleRecommendation {
+	articleMatches := groupMatchesByArticle(results)
+
+	recommendations := make([]database.ArticleRecommendation, 0, len(articleMatches))
+	for articleID, matches := range articleMatches {
+		maxSim, avgSim := calculateSimilarityStats(matches)
+		recommendations = append(recommendations, database.ArticleRecommendation{
+			ArticleID:     articleID,
+			HybridScore:   a.score(matches, maxSim, avgSim),
+			MaxSimilarity: maxSim,
+			AvgSimilarity: avgSim,
+			ChunkMatches:  matches,
+			MatchedChunks: len(matches),
+		})
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		return recommendations[i].HybridScore > recommendations[j].HybridScore
+	})
+
+	return recommendations
+}
+
+func (a *HybridAggregator) score(matches []database.ChunkMatch, maxSimilarity, avgSimilarity float64) float64 {
+	if len(matches) == 0 {
+		return 0
+	}
+
+	chunkCount := float64(len(matches))
+	relevanceScore := (maxSimilarity * 0.6) + (avgSimilarity * 0.4)
+	coverageBonus := math.Min(chunkCount/3.0, 0.2)
+
+	return relevanceScore + coverageBonus
+}
+
+// defaultRRFK is the Reciprocal Rank Fusion smoothing constant recommended
+// by the original RRF paper; it dampens the influence of any single list's
+// top rank so breadth across lists beats one very high score in one list.
+const defaultRRFK = 60
+
+// RRFAggregator ranks articles by Reciprocal Rank Fusion: for each chunk
+// search (one ranked list per source chunk), an article scores
+// 1/(K+rank) using the best rank at which it appears in that list, and its
+// final score is the sum of that across every list it appears in. An
+// article absent from a list contributes 0 for that list. Unlike
+// HybridAggregator's raw similarity scores, RRF only looks at rank, so it
+// rewards an article that places reasonably well across many chunks over
+// one that scores highest in a single chunk's results and nowhere else.
+type RRFAggregator struct {
+	K int
+}
+
+// NewRRFAggregator constructs an RRFAggregator using the standard K=60.
+func NewRRFAggregator() *RRFAggregator {
+	return &RRFAggregator{K: defaultRRFK}
+}
+
+func (a *RRFAggregator) Aggregate(results []SearchResultMessage) []database.ArticleRecommendation {
+	k := a.K
+	if k <= 0 {
+		k = defaultRRFK
+	}
+
+	scores := make(map[uuid.UUID]float64)
+	for _, result := range results {
+		if result.Response == nil {
+			continue
+		}
+
+		for articleID, rank := range bestRanksByArticle(result.Response.Results) {
+			scores[articleID] += 1.0 / float64(k+rank)
+		}
+	}
+
+	articleMatches := groupMatchesByArticle(results)
+
+	recommendations := make([]database.ArticleRecommendation, 0, len(scores))
+	for articleID, score := range scores {
+		matches := articleMatches[articleID]
+		maxSim, avgSim := calculateSimilarityStats(matches)
+		recommendations = append(recommendations, database.ArticleRecommendation{
+			ArticleID:     articleID,
+			HybridScore:   score,
+			MaxSimilarity: maxSim,
+			AvgSimilarity: avgSim,
+			ChunkMatches:  matches,
+			MatchedChunks: len(matches),
+		})
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		return recommendations[i].HybridScore > recommendations[j].HybridScore
+	})
+
+	return recommendations
+}
+
+// bestRanksByArticle sorts one chunk search's results by similarity score
+// (descending) and returns each article's best (lowest, 1-based) rank in
+// that list.
+func bestRanksByArticle(results []database.ChunkSearchResult) map[uuid.UUID]int {
+	ranked := make([]database.ChunkSearchResult, len(results))
+	copy(ranked, results)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].SimilarityScore > ranked[j].SimilarityScore
+	})
+
+	bestRank := make(map[uuid.UUID]int, len(ranked))
+	for i, r := range ranked {
+		if _, seen := bestRank[r.ArticleID]; !seen {
+			bestRank[r.ArticleID] = i + 1
+		}
+	}
+	return bestRank
+}
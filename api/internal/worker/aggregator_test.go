@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"rec-mind/internal/database"
+)
+
+// searchResult builds a SearchResultMessage for one chunk search whose
+// results are already in descending-similarity order, as a real vector
+// search response would be.
+func searchResult(searchID string, matches ...database.ChunkSearchResult) SearchResultMessage {
+	return SearchResultMessage{
+		Response: &database.ChunkSearchResponse{
+			SearchID: searchID,
+			Results:  matches,
+		},
+	}
+}
+
+func chunkHit(articleID uuid.UUID, score float64) database.ChunkSearchResult {
+	return database.ChunkSearchResult{
+		ChunkID:         uuid.NewString(),
+		ArticleID:       articleID,
+		SimilarityScore: score,
+	}
+}
+
+func TestRRFAggregator_FavorsBreadthOverSingleHighScore(t *testing.T) {
+	breadth := uuid.New() // places well across many chunk searches
+	oneHit := uuid.New()  // scores very high, but only in one chunk search
+
+	results := []SearchResultMessage{
+		searchResult("s1", chunkHit(oneHit, 0.99), chunkHit(breadth, 0.75)),
+		searchResult("s2", chunkHit(breadth, 0.74)),
+		searchResult("s3", chunkHit(breadth, 0.73)),
+	}
+
+	recommendations := NewRRFAggregator().Aggregate(results)
+
+	assert.Len(t, recommendations, 2)
+	assert.Equal(t, breadth, recommendations[0].ArticleID, "article matching across more chunk searches should rank first under RRF")
+	assert.Equal(t, oneHit, recommendations[1].ArticleID)
+	assert.Greater(t, recommendations[0].HybridScore, recommendations[1].HybridScore)
+}
+
+func TestRRFAggregator_DefaultsKWhenUnset(t *testing.T) {
+	a := &RRFAggregator{}
+	articleID := uuid.New()
+
+	results := []SearchResultMessage{
+		searchResult("s1", chunkHit(articleID, 0.9)),
+	}
+
+	recommendations := a.Aggregate(results)
+
+	assert.Len(t, recommendations, 1)
+	assert.InDelta(t, 1.0/float64(defaultRRFK+1), recommendations[0].HybridScore, 1e-9)
+}
+
+func TestRRFAggregator_NoMatches(t *testing.T) {
+	recommendations := NewRRFAggregator().Aggregate(nil)
+	assert.Empty(t, recommendations)
+}
+
+func TestHybridAggregator_FavorsHighestSingleScore(t *testing.T) {
+	breadth := uuid.New()
+	oneHit := uuid.New()
+
+	results := []SearchResultMessage{
+		searchResult("s1", chunkHit(oneHit, 0.99), chunkHit(breadth, 0.75)),
+		searchResult("s2", chunkHit(breadth, 0.74)),
+		searchResult("s3", chunkHit(breadth, 0.73)),
+	}
+
+	recommendations := NewHybridAggregator().Aggregate(results)
+
+	assert.Equal(t, 2, len(recommendations))
+	assert.Equal(t, oneHit, recommendations[0].ArticleID, "hybrid scoring weighs max similarity heavily, so the single 0.99 hit should still win")
+}
+
+func TestResolveAggregator(t *testing.T) {
+	assert.IsType(t, &RRFAggregator{}, resolveAggregator(database.AggregationRRF))
+	assert.IsType(t, &HybridAggregator{}, resolveAggregator(database.AggregationHybrid))
+	assert.IsType(t, &HybridAggregator{}, resolveAggregator(""))
+}
@@ -0,0 +1,146 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"rec-mind/config"
+	"rec-mind/internal/services"
+	"rec-mind/mq"
+)
+
+// articleBatchPrefetch bounds how many articles.batch manifests this worker
+// holds unacked at once.
+const articleBatchPrefetch = 4
+
+// articleBatchRetryTiers is the config-driven retry ladder reused for
+// articles.batch, same convention as articleEventsRetryTiers.
+var articleBatchRetryTiers = mq.TiersFromPolicy(config.LoadRetryPolicy())
+
+// ArticleBatchWorker consumes articles.batch - the manifest
+// BulkArticleIngestor publishes once per flushed batch - and durably
+// enqueues ArticleService.ProcessBatchArticlesChunked for it via
+// EnqueueBatchEmbeddingJob, so a scrape run's bulk-inserted articles get
+// chunked, concurrent embedding generation with resumable per-chunk
+// progress instead of needing a consumer that calls the ML service
+// directly and loses that work on a crash mid-batch.
+type ArticleBatchWorker struct {
+	articleService *services.ArticleService
+	channel        *amqp.Channel
+	instanceID     string
+	isRunning      bool
+	wg             sync.WaitGroup
+}
+
+// NewArticleBatchWorker wires the worker to its dependencies.
+func NewArticleBatchWorker(articleService *services.ArticleService) (*ArticleBatchWorker, error) {
+	if mq.MQChannel == nil {
+		return nil, fmt.Errorf("RabbitMQ channel not initialized")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown-host"
+	}
+
+	return &ArticleBatchWorker{
+		articleService: articleService,
+		channel:        mq.MQChannel,
+		instanceID:     fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+	}, nil
+}
+
+// Start begins consuming articles.batch in the background.
+func (w *ArticleBatchWorker) Start() error {
+	if w.isRunning {
+		return fmt.Errorf("article batch worker is already running")
+	}
+
+	if err := w.channel.Qos(articleBatchPrefetch, 0, false); err != nil {
+		return fmt.Errorf("failed to set articles.batch QoS: %w", err)
+	}
+
+	msgs, err := w.channel.Consume(
+		"articles.batch", // queue
+		"article-batch",  // consumer
+		false,            // auto-ack
+		false,            // exclusive
+		false,            // no-local
+		false,            // no-wait
+		nil,              // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register articles.batch consumer: %w", err)
+	}
+
+	w.isRunning = true
+	w.wg.Add(1)
+
+	go func() {
+		defer w.wg.Done()
+		log.Println("🚀 Started articles.batch consumer")
+
+		for d := range msgs {
+			if !w.isRunning {
+				d.Nack(false, true)
+				continue
+			}
+
+			if err := w.handleBatch(d.Body); err != nil {
+				log.Printf("❌ Failed to process articles.batch message: %v", err)
+				if _, retryErr := mq.RetryOrDeadLetter(w.channel, d, "articles.batch", articleBatchRetryTiers, err, w.instanceID); retryErr != nil {
+					log.Printf("❌ Failed to retry/dead-letter articles.batch message: %v", retryErr)
+				}
+				continue
+			}
+
+			d.Ack(false)
+		}
+
+		log.Println("🛑 articles.batch consumer stopped")
+	}()
+
+	return nil
+}
+
+// handleBatch durably enqueues chunked batch embedding for every article ID
+// in the manifest, rather than calling the Python service directly here -
+// the durable jobQueue is what survives a crash mid-batch and reports
+// per-chunk progress to /api/v1/jobs/:job_id.
+func (w *ArticleBatchWorker) handleBatch(body []byte) error {
+	var message services.ArticleBatchMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		return fmt.Errorf("failed to unmarshal articles.batch manifest: %w", err)
+	}
+
+	if len(message.ArticleIDs) == 0 {
+		return nil
+	}
+
+	jobID, err := w.articleService.EnqueueBatchEmbeddingJob(context.Background(), message.ArticleIDs)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue batch embedding job for %d articles: %w", len(message.ArticleIDs), err)
+	}
+
+	log.Printf("📥 Enqueued batch embedding job %s for %d articles", jobID, len(message.ArticleIDs))
+	return nil
+}
+
+// Stop signals the consumer goroutine to exit and waits for it to drain any
+// in-flight delivery.
+func (w *ArticleBatchWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	log.Println("🛑 Stopping articles.batch consumer...")
+	w.isRunning = false
+	w.wg.Wait()
+	log.Println("✅ articles.batch consumer stopped")
+}
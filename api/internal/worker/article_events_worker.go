@@ -0,0 +1,232 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"rec-mind/config"
+	"rec-mind/internal/mlclient"
+	"rec-mind/internal/redis"
+	"rec-mind/internal/services"
+	"rec-mind/models"
+	"rec-mind/mq"
+)
+
+// articleEventsPrefetch bounds how many article_events deliveries this
+// worker holds unacked at once, so one slow embedding job can't starve the
+// channel the way an unbounded prefetch would.
+const articleEventsPrefetch = 10
+
+// articleEventsRetryTiers is the config-driven retry ladder
+// InitRabbitMQ declared for article_events (see the retryTiers loop in
+// mq.InitRabbitMQ), reused here so RetryOrDeadLetter republishes into the
+// same queues it created.
+var articleEventsRetryTiers = mq.TiersFromPolicy(config.LoadRetryPolicy())
+
+// ArticleEventsWorker consumes article_events - everything published on the
+// recmind.events exchange under the article.* routing keys - and dispatches
+// each delivery to a handler keyed by its routing key, replacing the old
+// mq.StartConsumer which just logged every message. article.created and
+// article.updated enqueue embedding work on ArticleService's durable job
+// queue; article.deleted purges the article's cached Redis keys and
+// Pinecone vectors, mirroring ArticleReaperWorker.processJob's cleanup for
+// the single-article (non-bulk) case.
+type ArticleEventsWorker struct {
+	articleService *services.ArticleService
+	mlClient       *mlclient.MLClient
+	channel        *amqp.Channel
+	instanceID     string
+	isRunning      bool
+	wg             sync.WaitGroup
+}
+
+// NewArticleEventsWorker wires the worker to its dependencies.
+func NewArticleEventsWorker(articleService *services.ArticleService, mlClient *mlclient.MLClient) (*ArticleEventsWorker, error) {
+	if mq.MQChannel == nil {
+		return nil, fmt.Errorf("RabbitMQ channel not initialized")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown-host"
+	}
+
+	return &ArticleEventsWorker{
+		articleService: articleService,
+		mlClient:       mlClient,
+		channel:        mq.MQChannel,
+		instanceID:     fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+	}, nil
+}
+
+// Start begins consuming article_events in the background.
+func (w *ArticleEventsWorker) Start() error {
+	if w.isRunning {
+		return fmt.Errorf("article events worker is already running")
+	}
+
+	if err := w.channel.Qos(articleEventsPrefetch, 0, false); err != nil {
+		return fmt.Errorf("failed to set article_events QoS: %w", err)
+	}
+
+	msgs, err := w.channel.Consume(
+		"article_events",  // queue
+		"article-events",  // consumer
+		false,              // auto-ack
+		false,              // exclusive
+		false,              // no-local
+		false,              // no-wait
+		nil,                // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register article_events consumer: %w", err)
+	}
+
+	w.isRunning = true
+	w.wg.Add(1)
+
+	go func() {
+		defer w.wg.Done()
+		log.Println("🚀 Started article_events consumer")
+
+		for d := range msgs {
+			if !w.isRunning {
+				d.Nack(false, true)
+				continue
+			}
+
+			if err := w.dispatch(d); err != nil {
+				log.Printf("❌ Failed to process %s article_events message: %v", d.RoutingKey, err)
+				if _, retryErr := mq.RetryOrDeadLetter(w.channel, d, "article_events", articleEventsRetryTiers, err, w.instanceID); retryErr != nil {
+					log.Printf("❌ Failed to retry/dead-letter article_events message: %v", retryErr)
+				}
+				continue
+			}
+
+			d.Ack(false)
+		}
+
+		log.Println("🛑 article_events consumer stopped")
+	}()
+
+	return nil
+}
+
+// dispatch routes d to the handler for its routing key. An unrecognized
+// routing key is acked rather than retried - a future publisher emitting a
+// new event type this worker doesn't know about yet isn't this delivery's
+// fault to keep retrying.
+func (w *ArticleEventsWorker) dispatch(d amqp.Delivery) error {
+	switch d.RoutingKey {
+	case "article.created":
+		return w.handleArticleCreated(d.Body)
+	case "article.updated":
+		return w.handleArticleUpdated(d.Body)
+	case "article.deleted":
+		return w.handleArticleDeleted(d.Body)
+	case "article.rescrape":
+		return w.handleArticleRescrape(d.Body)
+	default:
+		log.Printf("⚠️ article_events message with unrecognized routing key %q; acking without action", d.RoutingKey)
+		return nil
+	}
+}
+
+func (w *ArticleEventsWorker) handleArticleCreated(body []byte) error {
+	var event models.ArticleCreatedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal article created event: %w", err)
+	}
+
+	articleID, err := uuid.Parse(event.ArticleID)
+	if err != nil {
+		return fmt.Errorf("invalid article id %q in article.created event: %w", event.ArticleID, err)
+	}
+
+	if _, err := w.articleService.EnqueueEmbeddingJob(context.Background(), articleID); err != nil {
+		return fmt.Errorf("failed to enqueue embedding job for article %s: %w", articleID, err)
+	}
+
+	return nil
+}
+
+func (w *ArticleEventsWorker) handleArticleUpdated(body []byte) error {
+	var event models.ArticleUpdatedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal article updated event: %w", err)
+	}
+
+	articleID, err := uuid.Parse(event.ArticleID)
+	if err != nil {
+		return fmt.Errorf("invalid article id %q in article.updated event: %w", event.ArticleID, err)
+	}
+
+	if _, err := w.articleService.EnqueueEmbeddingJob(context.Background(), articleID); err != nil {
+		return fmt.Errorf("failed to enqueue embedding job for article %s: %w", articleID, err)
+	}
+
+	return nil
+}
+
+// handleArticleDeleted purges a soft-deleted article's cached Redis keys
+// and Pinecone vectors. Best-effort, same as ArticleReaperWorker.processJob:
+// a stale cache entry or vector is recoverable, so a failure here is logged
+// rather than propagated into a retry.
+func (w *ArticleEventsWorker) handleArticleDeleted(body []byte) error {
+	var event models.ArticleDeletedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal article deleted event: %w", err)
+	}
+
+	articleID, err := uuid.Parse(event.ArticleID)
+	if err != nil {
+		return fmt.Errorf("invalid article id %q in article.deleted event: %w", event.ArticleID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := redis.DeleteByPrefix(ctx, fmt.Sprintf("article:%s", articleID)); err != nil {
+		log.Printf("⚠️ Failed to invalidate cached keys for article %s: %v", articleID, err)
+	}
+
+	if err := w.mlClient.DeleteVectors(ctx, []uuid.UUID{articleID}); err != nil {
+		log.Printf("⚠️ Failed to delete vectors for article %s: %v", articleID, err)
+	}
+
+	return nil
+}
+
+func (w *ArticleEventsWorker) handleArticleRescrape(body []byte) error {
+	var event models.ArticleRescrapeEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal article rescrape event: %w", err)
+	}
+
+	// No scraper hook exists yet to re-fetch a single URL on demand
+	// (ScraperService currently only runs full feed polls); log for
+	// operator visibility until that entrypoint exists.
+	log.Printf("📰 article.rescrape received for article %s (%s); no single-URL rescrape entrypoint yet", event.ArticleID, event.SourceURL)
+	return nil
+}
+
+// Stop signals the consumer goroutine to exit and waits for it to drain any
+// in-flight delivery.
+func (w *ArticleEventsWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	log.Println("🛑 Stopping article_events consumer...")
+	w.isRunning = false
+	w.wg.Wait()
+	log.Println("✅ article_events consumer stopped")
+}
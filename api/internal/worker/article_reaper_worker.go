@@ -0,0 +1,182 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"rec-mind/config"
+	"rec-mind/internal/mlclient"
+	"rec-mind/internal/redis"
+	"rec-mind/internal/repository"
+	"rec-mind/models"
+	"rec-mind/mq"
+)
+
+// articleDeletionRetryTiers is the config-driven retry ladder InitRabbitMQ
+// declared for article_deletion_jobs (see the retryTiers loop in
+// mq.InitRabbitMQ), reused here so RetryOrDeadLetter republishes into the
+// same queues it created, same convention as articleEventsRetryTiers.
+var articleDeletionRetryTiers = mq.TiersFromPolicy(config.LoadRetryPolicy())
+
+// ArticleReaperWorker consumes article_deletion_jobs and cascade-deletes an
+// article's rows, chunks, cached Redis keys, and vector-store entries so
+// none of the three stores is left with an orphan.
+type ArticleReaperWorker struct {
+	articleRepo repository.ArticleRepository
+	chunkRepo   repository.ArticleChunkRepository
+	mlClient    *mlclient.MLClient
+	channel     *amqp.Channel
+	instanceID  string
+	isRunning   bool
+	wg          sync.WaitGroup
+}
+
+// NewArticleReaperWorker wires the worker to its dependencies.
+func NewArticleReaperWorker(articleRepo repository.ArticleRepository, chunkRepo repository.ArticleChunkRepository, mlClient *mlclient.MLClient) (*ArticleReaperWorker, error) {
+	if mq.MQChannel == nil {
+		return nil, fmt.Errorf("RabbitMQ channel not initialized")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown-host"
+	}
+
+	return &ArticleReaperWorker{
+		articleRepo: articleRepo,
+		chunkRepo:   chunkRepo,
+		mlClient:    mlClient,
+		channel:     mq.MQChannel,
+		instanceID:  fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+	}, nil
+}
+
+// Start begins consuming article_deletion_jobs in the background.
+func (w *ArticleReaperWorker) Start() error {
+	if w.isRunning {
+		return fmt.Errorf("article reaper worker is already running")
+	}
+
+	queue, err := w.channel.QueueDeclare(
+		"article_deletion_jobs", // name
+		true,                    // durable
+		false,                   // delete when unused
+		false,                   // exclusive
+		false,                   // no-wait
+		nil,                     // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare article_deletion_jobs queue: %w", err)
+	}
+
+	msgs, err := w.channel.Consume(
+		queue.Name,            // queue
+		"article-reaper",      // consumer
+		false,                 // auto-ack
+		false,                 // exclusive
+		false,                 // no-local
+		false,                 // no-wait
+		nil,                   // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register article deletion jobs consumer: %w", err)
+	}
+
+	w.isRunning = true
+	w.wg.Add(1)
+
+	go func() {
+		defer w.wg.Done()
+		log.Println("🚀 Started article deletion jobs consumer")
+
+		for d := range msgs {
+			if !w.isRunning {
+				break
+			}
+
+			var job models.ArticleDeletionJob
+			if err := json.Unmarshal(d.Body, &job); err != nil {
+				log.Printf("❌ Failed to unmarshal article deletion job: %v", err)
+				if _, retryErr := mq.RetryOrDeadLetter(w.channel, d, "article_deletion_jobs", articleDeletionRetryTiers, err, w.instanceID); retryErr != nil {
+					log.Printf("❌ Failed to retry/dead-letter article deletion job: %v", retryErr)
+				}
+				continue
+			}
+
+			if err := w.processJob(job); err != nil {
+				log.Printf("❌ Failed to process article deletion job %s: %v", job.JobID, err)
+				if _, retryErr := mq.RetryOrDeadLetter(w.channel, d, "article_deletion_jobs", articleDeletionRetryTiers, err, w.instanceID); retryErr != nil {
+					log.Printf("❌ Failed to retry/dead-letter article deletion job %s: %v", job.JobID, retryErr)
+				}
+				continue
+			}
+
+			d.Ack(false)
+			log.Printf("✅ Successfully processed article deletion job %s", job.JobID)
+		}
+
+		log.Println("🛑 Article deletion jobs consumer stopped")
+	}()
+
+	return nil
+}
+
+// processJob cascade-deletes every article in the job: chunks and the
+// article row from Postgres, cached keys from Redis, and vectors from the
+// Python service. Best-effort on the cache/vector steps — a stale cache
+// entry or vector is recoverable, a half-deleted Postgres row is not.
+func (w *ArticleReaperWorker) processJob(job models.ArticleDeletionJob) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	ids := make([]uuid.UUID, 0, len(job.ArticleIDs))
+	for _, raw := range job.ArticleIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return fmt.Errorf("invalid article id %q in job %s: %w", raw, job.JobID, err)
+		}
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids {
+		if err := w.chunkRepo.DeleteByArticleID(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete chunks for article %s: %w", id, err)
+		}
+	}
+
+	if err := w.articleRepo.DeleteBatch(ctx, ids); err != nil {
+		return fmt.Errorf("failed to delete articles: %w", err)
+	}
+
+	for _, id := range ids {
+		if _, err := redis.DeleteByPrefix(ctx, fmt.Sprintf("article:%s", id)); err != nil {
+			log.Printf("⚠️ Failed to invalidate cached keys for article %s: %v", id, err)
+		}
+	}
+
+	if err := w.mlClient.DeleteVectors(ctx, ids); err != nil {
+		log.Printf("⚠️ Failed to delete vectors for %d article(s) in job %s: %v", len(ids), job.JobID, err)
+	}
+
+	return nil
+}
+
+// Stop signals the consumer goroutine to exit and waits for it to drain.
+func (w *ArticleReaperWorker) Stop() {
+	if !w.isRunning {
+		return
+	}
+
+	log.Println("🛑 Stopping article deletion jobs consumer...")
+	w.isRunning = false
+	w.wg.Wait()
+	log.Println("✅ Article deletion jobs consumer stopped")
+}
@@ -0,0 +1,100 @@
+package worker
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// enrichmentCacheEntry holds just the fields enrichWithArticleData fills
+// in on an ArticleRecommendation, not a full database.Article, since
+// that's all the enrichment step ever reads.
+type enrichmentCacheEntry struct {
+	Title    string
+	Category string
+	URL      string
+}
+
+type enrichmentCacheElement struct {
+	id    uuid.UUID
+	entry enrichmentCacheEntry
+}
+
+// enrichmentCache is a fixed-size LRU over enrichmentCacheEntry, modeled
+// on ClusterCockpit's lrucache usage in JobRepository: a small, size-bounded
+// cache in front of a batched DB lookup, so articles that keep showing up
+// across many recommendation results don't cost a query every time.
+type enrichmentCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[uuid.UUID]*list.Element
+	order    *list.List // front = most recently used
+
+	hits   int64
+	misses int64
+}
+
+// newEnrichmentCache builds an enrichmentCache holding at most capacity
+// entries, evicting the least recently used one once it's full.
+func newEnrichmentCache(capacity int) *enrichmentCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &enrichmentCache{
+		capacity: capacity,
+		items:    make(map[uuid.UUID]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+func (c *enrichmentCache) get(id uuid.UUID) (enrichmentCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[id]
+	if !ok {
+		c.misses++
+		return enrichmentCacheEntry{}, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(el)
+	return el.Value.(*enrichmentCacheElement).entry, true
+}
+
+func (c *enrichmentCache) set(id uuid.UUID, entry enrichmentCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[id]; ok {
+		el.Value.(*enrichmentCacheElement).entry = entry
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&enrichmentCacheElement{id: id, entry: entry})
+	c.items[id] = el
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*enrichmentCacheElement).id)
+		}
+	}
+}
+
+// EnrichmentCacheStats reports an enrichmentCache's hit/miss counts and
+// current size, returned by QueryRAGWorker.GetStats().
+type EnrichmentCacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+	Size   int   `json:"size"`
+}
+
+func (c *enrichmentCache) stats() EnrichmentCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return EnrichmentCacheStats{Hits: c.hits, Misses: c.misses, Size: c.order.Len()}
+}
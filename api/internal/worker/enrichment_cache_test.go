@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrichmentCache_MissThenHit(t *testing.T) {
+	c := newEnrichmentCache(10)
+	id := uuid.New()
+
+	_, ok := c.get(id)
+	assert.False(t, ok)
+
+	c.set(id, enrichmentCacheEntry{Title: "A", Category: "news", URL: "http://a"})
+
+	entry, ok := c.get(id)
+	assert.True(t, ok)
+	assert.Equal(t, "A", entry.Title)
+
+	stats := c.stats()
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Size)
+}
+
+func TestEnrichmentCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newEnrichmentCache(2)
+	a, b, d := uuid.New(), uuid.New(), uuid.New()
+
+	c.set(a, enrichmentCacheEntry{Title: "A"})
+	c.set(b, enrichmentCacheEntry{Title: "B"})
+
+	// Touch a so b becomes the least recently used entry.
+	_, _ = c.get(a)
+
+	c.set(d, enrichmentCacheEntry{Title: "D"})
+
+	_, aStillCached := c.get(a)
+	_, bStillCached := c.get(b)
+	_, dCached := c.get(d)
+
+	assert.True(t, aStillCached, "recently touched entry should survive eviction")
+	assert.False(t, bStillCached, "least recently used entry should be evicted")
+	assert.True(t, dCached)
+	assert.Equal(t, 2, c.stats().Size)
+}
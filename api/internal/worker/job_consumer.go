@@ -3,25 +3,35 @@ package worker
 import (
 	"encoding/json"
 	"fmt"
-	"log"
+	"os"
 	"sync"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
+	"rec-mind/internal/analytics"
 	"rec-mind/internal/database"
+	"rec-mind/internal/events"
+	"rec-mind/internal/logging"
 	"rec-mind/internal/redis"
 	"rec-mind/internal/repository"
 	"rec-mind/mq"
 )
 
+var jobConsumerLog = logging.New("job-consumer")
+
 type JobConsumer struct {
 	queryWorker *QueryRAGWorker
 	channel     *amqp.Channel
 	isRunning   bool
 	wg          sync.WaitGroup
+
+	// instanceID identifies this process in a dead-lettered message's
+	// x-last-worker-instance header, the same hostname:pid shape
+	// QueryWorkerPool uses.
+	instanceID string
 }
 
-func NewJobConsumer(chunkRepo repository.ArticleChunkRepository, articleRepo repository.ArticleRepository) (*JobConsumer, error) {
+func NewJobConsumer(chunkRepo repository.ArticleChunkRepository, articleRepo repository.ArticleRepository, archiveRepo repository.QueryResultRepository, analyticsClient analytics.Analytics, eventPublisher *events.Publisher, jobRepo repository.JobRepository) (*JobConsumer, error) {
 	if mq.MQChannel == nil {
 		return nil, fmt.Errorf("RabbitMQ channel not initialized")
 	}
@@ -30,15 +40,21 @@ func NewJobConsumer(chunkRepo repository.ArticleChunkRepository, articleRepo rep
 		return nil, fmt.Errorf("Redis client not initialized")
 	}
 
-	queryWorker, err := NewQueryRAGWorker(articleRepo, redis.RedisClient)
+	queryWorker, err := NewQueryRAGWorker(articleRepo, redis.RedisClient, DefaultEnrichmentCacheSize, archiveRepo, analyticsClient, eventPublisher, jobRepo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create query RAG worker: %w", err)
 	}
 
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown-host"
+	}
+
 	return &JobConsumer{
 		queryWorker: queryWorker,
 		channel:     mq.MQChannel,
 		isRunning:   false,
+		instanceID:  fmt.Sprintf("%s:%d", hostname, os.Getpid()),
 	}, nil
 }
 
@@ -49,12 +65,12 @@ func (jc *JobConsumer) Start() error {
 
 	// Declare the query_search_jobs queue
 	queue, err := jc.channel.QueueDeclare(
-		"query_search_jobs", // name
-		true,                // durable
-		false,               // delete when unused
-		false,               // exclusive
-		false,               // no-wait
-		nil,                 // arguments
+		"query_search_jobs",           // name
+		true,                          // durable
+		false,                         // delete when unused
+		false,                         // exclusive
+		false,                         // no-wait
+		mq.QuerySearchJobsQueueArgs(), // arguments
 	)
 	if err != nil {
 		return fmt.Errorf("failed to declare query_search_jobs queue: %w", err)
@@ -88,7 +104,7 @@ func (jc *JobConsumer) Start() error {
 
 	go func() {
 		defer jc.wg.Done()
-		log.Println("🚀 Started query search jobs consumer")
+		jobConsumerLog.Info("started query search jobs consumer")
 
 		for d := range msgs {
 			if !jc.isRunning {
@@ -97,26 +113,31 @@ func (jc *JobConsumer) Start() error {
 
 			var job database.QuerySearchJob
 			if err := json.Unmarshal(d.Body, &job); err != nil {
-				log.Printf("❌ Failed to unmarshal query search job: %v", err)
+				jobConsumerLog.Error("failed to unmarshal query search job", "error", err)
 				d.Nack(false, false)
 				continue
 			}
 
-			log.Printf("📋 Processing query search job %s for query: %s", job.JobID, job.Query)
+			jobConsumerLog.Info("processing query search job", "job_id", job.JobID, "correlation_id", job.CorrelationID, "tenant", job.TenantID, "query", job.Query)
 
-			// Process the job using query RAG worker
+			// Process the job using query RAG worker. A failure here routes
+			// through the query_search_jobs retry ladder (mq.DefaultRetryTiers)
+			// instead of a naive Nack(requeue=true), which used to requeue a
+			// poisoned message forever.
 			if err := jc.queryWorker.ProcessQuerySearchJob(job); err != nil {
-				log.Printf("❌ Failed to process query search job %s: %v", job.JobID, err)
-				d.Nack(false, true) // Requeue for retry
+				jobConsumerLog.Error("failed to process query search job", "job_id", job.JobID, "correlation_id", job.CorrelationID, "error", err)
+				if _, retryErr := mq.RetryOrDeadLetter(jc.channel, d, "query_search_jobs", mq.DefaultRetryTiers, err, jc.instanceID); retryErr != nil {
+					jobConsumerLog.Error("failed to route query search job through retry ladder", "job_id", job.JobID, "error", retryErr)
+				}
 				continue
 			}
 
 			// Acknowledge successful processing
 			d.Ack(false)
-			log.Printf("✅ Successfully processed query search job %s", job.JobID)
+			jobConsumerLog.Info("successfully processed query search job", "job_id", job.JobID, "correlation_id", job.CorrelationID)
 		}
 
-		log.Println("🛑 Query search jobs consumer stopped")
+		jobConsumerLog.Info("query search jobs consumer stopped")
 	}()
 
 	return nil
@@ -127,10 +148,11 @@ func (jc *JobConsumer) Stop() {
 		return
 	}
 
-	log.Println("🛑 Stopping query search jobs consumer...")
+	jobConsumerLog.Info("stopping query search jobs consumer")
 	jc.isRunning = false
 	jc.wg.Wait()
-	log.Println("✅ Query search jobs consumer stopped")
+	jc.queryWorker.Close()
+	jobConsumerLog.Info("query search jobs consumer stopped")
 }
 
 func (jc *JobConsumer) IsRunning() bool {
@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// searchResultRetriesTotal, searchResultDLQTotal and searchResultDroppedTotal
+// track the chunk-search retry/DLQ pipeline (both the search_results
+// consumer's retry-queue path and runSearches' publish-side retries), served
+// on the existing /metrics Prometheus endpoint.
+var (
+	searchResultRetriesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rec_mind_search_result_retries_total",
+		Help: "Total number of chunk-search message retries, covering both consumer-side parse failures and publish-side RabbitMQ hiccups.",
+	})
+
+	searchResultDLQTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rec_mind_search_result_dlq_total",
+		Help: "Total number of search result messages sent to search_results.dlq after exhausting retries.",
+	})
+
+	searchResultDroppedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rec_mind_search_result_dropped_total",
+		Help: "Total number of search result messages dropped without going through the retry queue, labeled by reason.",
+	}, []string{"reason"})
+)
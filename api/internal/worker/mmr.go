@@ -0,0 +1,132 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"math"
+
+	"github.com/google/uuid"
+
+	"rec-mind/internal/database"
+	"rec-mind/internal/repository"
+)
+
+// applyMMR re-ranks an already-scored recommendation list with Maximal
+// Marginal Relevance, trading off relevance against redundancy so that, for
+// example, ten near-duplicate articles about the same event don't dominate
+// the output. lambda weighs relevance (1) against diversity (0); resultCount
+// caps how many recommendations are kept. If embeddingRepo can't produce
+// embeddings for the candidates (the vector store has none, or the request
+// fails), the input order is preserved and only truncated to resultCount,
+// since diversity re-ranking isn't possible without them.
+func applyMMR(ctx context.Context, embeddingRepo repository.ArticleEmbeddingRepository, recommendations []database.ArticleRecommendation, lambda float64, resultCount int) []database.ArticleRecommendation {
+	if lambda <= 0 {
+		lambda = database.DefaultMMRLambda
+	}
+	if resultCount <= 0 {
+		resultCount = database.DefaultMMRResultCount
+	}
+	if len(recommendations) <= resultCount {
+		return recommendations
+	}
+
+	articleIDs := make([]uuid.UUID, len(recommendations))
+	for i, rec := range recommendations {
+		articleIDs[i] = rec.ArticleID
+	}
+
+	embeddings, err := embeddingRepo.GetCentroidEmbeddings(ctx, articleIDs)
+	if err != nil {
+		log.Printf("⚠️ MMR: failed to fetch candidate embeddings, skipping diversity re-ranking: %v", err)
+		return recommendations[:resultCount]
+	}
+
+	queryRelevance := normalizedHybridScores(recommendations)
+
+	remaining := make([]int, len(recommendations))
+	for i := range recommendations {
+		remaining[i] = i
+	}
+
+	selected := make([]int, 0, resultCount)
+	for len(selected) < resultCount && len(remaining) > 0 {
+		bestIdx := 0
+		bestScore := math.Inf(-1)
+
+		for i, candidate := range remaining {
+			redundancy := 0.0
+			candidateVec := embeddings[recommendations[candidate].ArticleID]
+			for _, s := range selected {
+				sim := cosineSimilarity(candidateVec, embeddings[recommendations[s].ArticleID])
+				redundancy = math.Max(redundancy, sim)
+			}
+
+			mmrScore := lambda*queryRelevance[candidate] - (1-lambda)*redundancy
+			if mmrScore > bestScore {
+				bestScore = mmrScore
+				bestIdx = i
+			}
+		}
+
+		selected = append(selected, remaining[bestIdx])
+		remaining = append(remaining[:bestIdx], remaining[bestIdx+1:]...)
+	}
+
+	result := make([]database.ArticleRecommendation, len(selected))
+	for i, idx := range selected {
+		result[i] = recommendations[idx]
+	}
+	return result
+}
+
+// normalizedHybridScores min-max normalizes each recommendation's
+// HybridScore to [0, 1] so it can stand in for sim(d, query) regardless of
+// which Aggregator produced the raw score.
+func normalizedHybridScores(recommendations []database.ArticleRecommendation) []float64 {
+	if len(recommendations) == 0 {
+		return nil
+	}
+
+	min, max := recommendations[0].HybridScore, recommendations[0].HybridScore
+	for _, rec := range recommendations {
+		if rec.HybridScore < min {
+			min = rec.HybridScore
+		}
+		if rec.HybridScore > max {
+			max = rec.HybridScore
+		}
+	}
+
+	scores := make([]float64, len(recommendations))
+	spread := max - min
+	for i, rec := range recommendations {
+		if spread == 0 {
+			scores[i] = 1
+			continue
+		}
+		scores[i] = (rec.HybridScore - min) / spread
+	}
+	return scores
+}
+
+// cosineSimilarity returns 0 for mismatched, empty, or missing vectors
+// rather than erroring, since a missing embedding should just fail to
+// penalize a candidate instead of aborting the whole re-ranking pass.
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(b) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rec-mind/internal/database"
+)
+
+// fakeEmbeddingRepo returns canned embeddings for tests, without touching
+// the ML service.
+type fakeEmbeddingRepo struct {
+	embeddings map[uuid.UUID][]float64
+	err        error
+}
+
+func (f *fakeEmbeddingRepo) GetCentroidEmbeddings(ctx context.Context, articleIDs []uuid.UUID) (map[uuid.UUID][]float64, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.embeddings, nil
+}
+
+func recommendation(articleID uuid.UUID, score float64) database.ArticleRecommendation {
+	return database.ArticleRecommendation{ArticleID: articleID, HybridScore: score}
+}
+
+func TestApplyMMR_DiversifiesNearDuplicates(t *testing.T) {
+	// Three near-duplicate articles about the same event, all scoring high,
+	// and one lower-scoring but distinct article.
+	dupA, dupB, dupC := uuid.New(), uuid.New(), uuid.New()
+	distinct := uuid.New()
+
+	recommendations := []database.ArticleRecommendation{
+		recommendation(dupA, 0.95),
+		recommendation(dupB, 0.93),
+		recommendation(dupC, 0.91),
+		recommendation(distinct, 0.80),
+	}
+
+	embeddings := map[uuid.UUID][]float64{
+		dupA:     {1, 0, 0},
+		dupB:     {0.99, 0.01, 0},
+		dupC:     {0.98, 0, 0.02},
+		distinct: {0, 1, 0},
+	}
+	repo := &fakeEmbeddingRepo{embeddings: embeddings}
+
+	result := applyMMR(context.Background(), repo, recommendations, 0.5, 2)
+
+	require.Len(t, result, 2)
+	ids := []uuid.UUID{result[0].ArticleID, result[1].ArticleID}
+	assert.Contains(t, ids, dupA, "highest-scoring article should always be selected first")
+	assert.Contains(t, ids, distinct, "the distinct article should be preferred over a second near-duplicate")
+}
+
+func TestApplyMMR_FewerCandidatesThanResultCountReturnsAllUnchanged(t *testing.T) {
+	recommendations := []database.ArticleRecommendation{
+		recommendation(uuid.New(), 0.9),
+		recommendation(uuid.New(), 0.5),
+	}
+
+	result := applyMMR(context.Background(), &fakeEmbeddingRepo{}, recommendations, 0.5, 10)
+
+	assert.Equal(t, recommendations, result)
+}
+
+func TestApplyMMR_FallsBackToTruncationWhenEmbeddingsUnavailable(t *testing.T) {
+	recommendations := []database.ArticleRecommendation{
+		recommendation(uuid.New(), 0.9),
+		recommendation(uuid.New(), 0.8),
+		recommendation(uuid.New(), 0.7),
+	}
+	repo := &fakeEmbeddingRepo{err: assert.AnError}
+
+	result := applyMMR(context.Background(), repo, recommendations, 0.5, 2)
+
+	require.Len(t, result, 2)
+	assert.Equal(t, recommendations[:2], result)
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	assert.InDelta(t, 1.0, cosineSimilarity([]float64{1, 0}, []float64{1, 0}), 1e-9)
+	assert.InDelta(t, 0.0, cosineSimilarity([]float64{1, 0}, []float64{0, 1}), 1e-9)
+	assert.Equal(t, 0.0, cosineSimilarity(nil, []float64{1, 0}))
+	assert.Equal(t, 0.0, cosineSimilarity([]float64{1}, []float64{1, 0}))
+}
@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rec-mind/internal/database"
+	"rec-mind/internal/recostream"
+)
+
+// fakeResultPublisher records every snapshot it's asked to publish, without
+// touching Redis.
+type fakeResultPublisher struct {
+	snapshots []database.RecommendationResult
+	partials  []bool
+}
+
+func (f *fakeResultPublisher) Publish(ctx context.Context, result database.RecommendationResult, partial bool) error {
+	f.snapshots = append(f.snapshots, result)
+	f.partials = append(f.partials, partial)
+	return nil
+}
+
+func (f *fakeResultPublisher) PublishStage(ctx context.Context, jobID string, stage recostream.Stage, errMsg string) error {
+	return nil
+}
+
+func TestNewProgressPublisher_PublishesOnFractionThreshold(t *testing.T) {
+	publisher := &fakeResultPublisher{}
+	w := &RAGWorker{resultPublisher: publisher}
+	job := database.RecommendationJob{JobID: "job-1"}
+
+	onProgress := w.newProgressPublisher(context.Background(), job, time.Now(), NewHybridAggregator(), 8)
+	require.NotNil(t, onProgress)
+
+	// threshold = ceil(8*0.25) = 2, so one new result shouldn't publish yet.
+	onProgress([]SearchResultMessage{{}})
+	assert.Empty(t, publisher.snapshots)
+
+	// a second new result crosses the threshold.
+	onProgress([]SearchResultMessage{{}, {}})
+	require.Len(t, publisher.snapshots, 1)
+	assert.True(t, publisher.partials[0])
+	assert.Equal(t, "job-1", publisher.snapshots[0].JobID)
+}
+
+func TestNewProgressPublisher_PublishesOnTimeThreshold(t *testing.T) {
+	publisher := &fakeResultPublisher{}
+	w := &RAGWorker{resultPublisher: publisher}
+	job := database.RecommendationJob{JobID: "job-2"}
+
+	// Large totalSearches so the fraction threshold alone wouldn't fire,
+	// isolating the time-based trigger.
+	onProgress := w.newProgressPublisher(context.Background(), job, time.Now(), NewHybridAggregator(), 1000)
+
+	onProgress([]SearchResultMessage{{}})
+	assert.Empty(t, publisher.snapshots, "one result shouldn't cross the 25% fraction threshold of 1000")
+
+	time.Sleep(partialSnapshotInterval + 50*time.Millisecond)
+	onProgress([]SearchResultMessage{{}})
+	assert.Len(t, publisher.snapshots, 1, "the interval threshold should fire even with no new results")
+}
+
+func TestNewProgressPublisher_NilWhenNoPublisher(t *testing.T) {
+	w := &RAGWorker{}
+	onProgress := w.newProgressPublisher(context.Background(), database.RecommendationJob{}, time.Now(), NewHybridAggregator(), 4)
+	assert.Nil(t, onProgress)
+}
@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"time"
+
+	"rec-mind/internal/database"
+	"rec-mind/mq"
+)
+
+// maxPublishRetries bounds how many attempts, the first included, runSearches
+// makes to publish a single chunk_search message before giving up on that one chunk instead of
+// failing the whole job — a transient RabbitMQ hiccup shouldn't cost every
+// other chunk's search.
+const maxPublishRetries = 3
+
+// publishChunkSearchWithRetry retries a failed publish with a short linear
+// backoff, recording each retry attempt on searchResultRetriesTotal.
+func publishChunkSearchWithRetry(msg database.ChunkSearchMessage) error {
+	var err error
+	for attempt := 0; attempt < maxPublishRetries; attempt++ {
+		if attempt > 0 {
+			searchResultRetriesTotal.Inc()
+			time.Sleep(publishRetryBackoff(attempt))
+		}
+		if err = mq.PublishChunkSearch(msg); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func publishRetryBackoff(attempt int) time.Duration {
+	d := time.Duration(attempt) * 100 * time.Millisecond
+	if d > time.Second {
+		return time.Second
+	}
+	return d
+}
@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryCountOf(t *testing.T) {
+	assert.Equal(t, 0, retryCountOf(nil))
+	assert.Equal(t, 0, retryCountOf(amqp.Table{}))
+	assert.Equal(t, 3, retryCountOf(amqp.Table{"x-retry-count": int32(3)}))
+	assert.Equal(t, 3, retryCountOf(amqp.Table{"x-retry-count": int64(3)}))
+	assert.Equal(t, 3, retryCountOf(amqp.Table{"x-retry-count": 3}))
+}
+
+func TestPublishRetryBackoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), publishRetryBackoff(0))
+	assert.Equal(t, 100*time.Millisecond, publishRetryBackoff(1))
+	assert.Equal(t, time.Second, publishRetryBackoff(20), "backoff should cap at one second")
+}
@@ -7,24 +7,115 @@ import (
 	"log"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/redis/go-redis/v9"
 
-	"rec-mind/models"
+	"rec-mind/internal/analytics"
+	"rec-mind/internal/events"
+	"rec-mind/internal/logging"
+	"rec-mind/internal/metrics"
 	"rec-mind/internal/repository"
+	"rec-mind/models"
 	"rec-mind/mq"
 )
 
+var ragWorkerLog = logging.New("query-rag-worker")
+
+// queryRAGWorkerMetricsOnce guards Prometheus registration: a process runs
+// exactly one QueryRAGWorker, and promauto panics on duplicate registration.
+var queryRAGWorkerMetricsOnce sync.Once
+
+// orphanTTL bounds how long a query search result is held in the orphans
+// bucket waiting for a late-registering ProcessQuerySearchJob call, before
+// orphanSweeper discards it. A result only ends up here if it arrives
+// between the ML service's response and this process finishing its own
+// map registration, which should be microseconds in practice.
+const orphanTTL = 10 * time.Second
+
+// Default widths for QueryRAGWorker's two independent concurrency bounds:
+// MaxInFlightPerML throttles how many ProcessQuerySearchJob calls may have
+// an outstanding PublishQuerySearch in flight at once, and MaxDBEnrichment
+// throttles how many may be running enrichWithArticleData against the pgx
+// pool at once. They're sized independently because the ML service and
+// Postgres have different capacity, so ProcessBatch shouldn't let a burst
+// of one starve the other.
+const (
+	DefaultMaxInFlightPerML = 10
+	DefaultMaxDBEnrichment  = 20
+)
+
+// DefaultEnrichmentCacheSize is how many articles NewQueryRAGWorker's LRU
+// enrichment cache holds when the caller doesn't override it via
+// --enrichment-cache-size.
+const DefaultEnrichmentCacheSize = 1000
+
 type QueryRAGWorker struct {
-	articleRepo   repository.ArticleRepository
-	redisClient   *redis.Client
-	channel       *amqp.Channel
-	resultChannel chan QuerySearchResultMessage
-	timeoutMap    map[string]*QuerySearchTimeout
-	timeoutMutex  sync.RWMutex
+	articleRepo repository.ArticleRepository
+	redisClient *redis.Client
+	channel     *amqp.Channel
+
+	mlSem chan struct{}
+	dbSem chan struct{}
+
+	// pending demultiplexes startQuerySearchResultsConsumer's single
+	// delivery stream by SearchID, so two concurrent ProcessQuerySearchJob
+	// calls can never steal each other's result (the shared resultChannel
+	// this replaced required every reader to inspect and discard every
+	// other reader's messages).
+	pendingMu sync.Mutex
+	pending   map[string]chan QuerySearchResultMessage
+
+	// orphans holds a result that arrived before its waiter registered in
+	// pending, keyed by SearchID, so collectQuerySearchResult can still
+	// pick it up instead of timing out.
+	orphansMu sync.Mutex
+	orphans   map[string]orphanResult
+
+	enrichCache *enrichmentCache
+
+	// archiveChannel buffers completed results for archiveWorker to persist
+	// into query_results/query_result_chunks, so storeQueryResult's Redis
+	// write never blocks on a Postgres round-trip. Both are nil when
+	// archiveRepo is nil (archiving disabled, e.g. via --archive=false).
+	archiveChannel chan *models.QueryRecommendationResult
+	archivePending sync.WaitGroup
+	archiveRepo    repository.QueryResultRepository
+
+	// analyticsClient records every completed job as a query_events row.
+	// New callers always get a non-nil Analytics (analytics.New returns a
+	// noopAnalytics for config.AnalyticsBackendNone), so this field is
+	// never nil-checked the way archiveRepo is.
+	analyticsClient analytics.Analytics
+
+	// eventPublisher streams this job's progress (retrieving/completed/
+	// failed) so SearchStream can forward it over SSE instead of a client
+	// polling Redis in a loop. Nil disables publishing entirely, the same
+	// optional-dependency shape as archiveRepo.
+	eventPublisher *events.Publisher
+
+	// jobRepo persists each job's lifecycle (queued/running/completed/
+	// failed/cancelled, attempts, timings) in Postgres, so
+	// SearchController's cancel/retry/history/logs endpoints have a durable
+	// source of truth beyond the 24h query_search_result:<id> Redis key.
+	// Nil disables persistence entirely, the same optional-dependency shape
+	// as archiveRepo.
+	jobRepo repository.JobRepository
+}
+
+// archiveChannelSize bounds how many completed results may be queued for
+// archiving before storeQueryResult blocks on a full channel, providing
+// backpressure if Postgres falls behind the rate of completed searches.
+const archiveChannelSize = 128
+
+type orphanResult struct {
+	message QuerySearchResultMessage
+	arrived time.Time
 }
 
 type QuerySearchResultMessage struct {
@@ -32,36 +123,149 @@ type QuerySearchResultMessage struct {
 	Error    *models.QuerySearchError
 }
 
-type QuerySearchTimeout struct {
-	SearchID string
-	Timer    *time.Timer
-	JobID    string
-}
-
-func NewQueryRAGWorker(articleRepo repository.ArticleRepository, redisClient *redis.Client) (*QueryRAGWorker, error) {
+// NewQueryRAGWorker constructs a QueryRAGWorker whose enrichment LRU cache
+// holds enrichmentCacheSize articles; pass DefaultEnrichmentCacheSize
+// unless the caller overrides it (e.g. via --enrichment-cache-size).
+// archiveRepo may be nil to disable archiving entirely (e.g.
+// --archive=false for local testing without the query_results migrations
+// applied), in which case storeQueryResult only ever writes to Redis.
+// analyticsClient is never nil in practice - pass analytics.New's result,
+// which is a no-op implementation when analytics is disabled.
+// eventPublisher may be nil to disable search job progress streaming
+// entirely (e.g. for a worker process that doesn't need it). jobRepo may be
+// nil to disable the durable search_jobs lifecycle record entirely (e.g.
+// --archive=false deployments without the search_jobs migration applied).
+func NewQueryRAGWorker(articleRepo repository.ArticleRepository, redisClient *redis.Client, enrichmentCacheSize int, archiveRepo repository.QueryResultRepository, analyticsClient analytics.Analytics, eventPublisher *events.Publisher, jobRepo repository.JobRepository) (*QueryRAGWorker, error) {
 	if mq.MQChannel == nil {
 		return nil, fmt.Errorf("RabbitMQ channel not initialized")
 	}
 
 	worker := &QueryRAGWorker{
-		articleRepo:   articleRepo,
-		redisClient:   redisClient,
-		channel:       mq.MQChannel,
-		resultChannel: make(chan QuerySearchResultMessage, 100),
-		timeoutMap:    make(map[string]*QuerySearchTimeout),
+		articleRepo:     articleRepo,
+		redisClient:     redisClient,
+		channel:         mq.MQChannel,
+		mlSem:           make(chan struct{}, DefaultMaxInFlightPerML),
+		dbSem:           make(chan struct{}, DefaultMaxDBEnrichment),
+		pending:         make(map[string]chan QuerySearchResultMessage),
+		orphans:         make(map[string]orphanResult),
+		enrichCache:     newEnrichmentCache(enrichmentCacheSize),
+		archiveRepo:     archiveRepo,
+		analyticsClient: analyticsClient,
+		eventPublisher:  eventPublisher,
+		jobRepo:         jobRepo,
+	}
+
+	if archiveRepo != nil {
+		worker.archiveChannel = make(chan *models.QueryRecommendationResult, archiveChannelSize)
+		go worker.archiveWorker()
 	}
 
 	// Start search results consumer
 	go worker.startQuerySearchResultsConsumer()
+	go worker.orphanSweeper()
+
+	queryRAGWorkerMetricsOnce.Do(func() {
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "rec_mind_query_rag_worker_pending_searches",
+			Help: "Number of query searches published to the ML service and awaiting a result.",
+		}, func() float64 { return float64(worker.PendingSearches()) })
+	})
 
 	return worker, nil
 }
 
-func (w *QueryRAGWorker) ProcessQuerySearchJob(job models.QuerySearchJob) error {
+// PendingSearches reports how many query searches are currently registered
+// and awaiting a result, for the /metrics endpoint.
+func (w *QueryRAGWorker) PendingSearches() int {
+	w.pendingMu.Lock()
+	defer w.pendingMu.Unlock()
+	return len(w.pending)
+}
+
+// register creates and returns this search's result channel, checking the
+// orphans bucket first in case the result already arrived.
+func (w *QueryRAGWorker) register(searchID string) chan QuerySearchResultMessage {
+	ch := make(chan QuerySearchResultMessage, 1)
+
+	w.orphansMu.Lock()
+	if orphan, ok := w.orphans[searchID]; ok {
+		delete(w.orphans, searchID)
+		w.orphansMu.Unlock()
+		ch <- orphan.message
+		return ch
+	}
+	w.orphansMu.Unlock()
+
+	w.pendingMu.Lock()
+	w.pending[searchID] = ch
+	w.pendingMu.Unlock()
+	return ch
+}
+
+func (w *QueryRAGWorker) unregister(searchID string) {
+	w.pendingMu.Lock()
+	delete(w.pending, searchID)
+	w.pendingMu.Unlock()
+}
+
+// deliver routes a result to its waiting ProcessQuerySearchJob call, or
+// parks it in the orphans bucket if nothing has registered for it yet.
+func (w *QueryRAGWorker) deliver(searchID string, result QuerySearchResultMessage) {
+	w.pendingMu.Lock()
+	ch, ok := w.pending[searchID]
+	w.pendingMu.Unlock()
+
+	if ok {
+		ch <- result
+		return
+	}
+
+	w.orphansMu.Lock()
+	w.orphans[searchID] = orphanResult{message: result, arrived: time.Now()}
+	w.orphansMu.Unlock()
+}
+
+// orphanSweeper periodically discards orphaned results older than
+// orphanTTL so a search that never registers (e.g. its job goroutine
+// crashed before collectQuerySearchResult ran) doesn't leak memory.
+func (w *QueryRAGWorker) orphanSweeper() {
+	ticker := time.NewTicker(orphanTTL)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		cutoff := time.Now().Add(-orphanTTL)
+		w.orphansMu.Lock()
+		for searchID, orphan := range w.orphans {
+			if orphan.arrived.Before(cutoff) {
+				delete(w.orphans, searchID)
+			}
+		}
+		w.orphansMu.Unlock()
+	}
+}
+
+func (w *QueryRAGWorker) ProcessQuerySearchJob(job models.QuerySearchJob) (err error) {
 	startTime := time.Now()
-	ctx := context.Background()
+	ctx := logging.WithCorrelationID(context.Background(), job.CorrelationID)
+	logger := logging.FromContext(ctx, ragWorkerLog)
+
+	defer func() {
+		outcome := "success"
+		if err != nil {
+			outcome = "error"
+		}
+		metrics.JobTotalSeconds.WithLabelValues("query_search", outcome).Observe(time.Since(startTime).Seconds())
+	}()
+
+	logger.Info("processing query search job", "job_id", job.JobID, "query", job.Query)
+
+	if !w.acquireJobLock(ctx, job.JobID) {
+		logger.Info("query search job already in flight on another delivery, skipping", "job_id", job.JobID)
+		return nil
+	}
+	defer w.releaseJobLock(context.Background(), job.JobID)
 
-	log.Printf("🔍 Processing query search job %s for query: \"%s\"", job.JobID, job.Query)
+	w.recordJobStart(ctx, job)
 
 	// Create single query search message (no chunking needed)
 	searchID := uuid.New().String()
@@ -73,36 +277,66 @@ func (w *QueryRAGWorker) ProcessQuerySearchJob(job models.QuerySearchJob) error
 		ScoreThreshold: job.ScoreThreshold,
 	}
 
-	// Publish query search to ML service
-	err := mq.PublishQuerySearch(searchMsg)
+	// Register this search's result channel before publishing, so a result
+	// that races ahead of this goroutine lands in w.pending rather than
+	// the orphans bucket.
+	resultCh := w.register(searchID)
+	defer w.unregister(searchID)
+
+	w.publishStage(ctx, job.JobID, events.StageRetrieving, nil)
+
+	// Publish query search to ML service, bounded by MaxInFlightPerML so a
+	// batch of jobs can't open more simultaneous ML requests than the
+	// service can take.
+	w.mlSem <- struct{}{}
+	publishStart := time.Now()
+	err = mq.PublishQuerySearch(searchMsg)
+	<-w.mlSem
+	metrics.QuerySearchPhaseSeconds.WithLabelValues("publish").Observe(time.Since(publishStart).Seconds())
 	if err != nil {
-		log.Printf("❌ Failed to publish query search %s: %v", searchID, err)
-		return w.storeQueryErrorResult(job.JobID, job.Query, fmt.Sprintf("Failed to publish search: %v", err))
+		logger.Error("failed to publish query search", "search_id", searchID, "error", err)
+		return w.storeQueryErrorResult(job.JobID, job.SessionID, job.Query, fmt.Sprintf("Failed to publish search: %v", err))
 	}
 
-	log.Printf("📤 Published query search %s for job %s", searchID, job.JobID)
+	logger.Info("published query search", "search_id", searchID, "job_id", job.JobID)
 
 	// Wait for search result with timeout
+	waitStart := time.Now()
 	timeout := 30 * time.Second
-	result := w.collectQuerySearchResult(searchID, timeout, job.JobID)
+	result := w.collectQuerySearchResult(resultCh, timeout, job.JobID)
+	metrics.QuerySearchPhaseSeconds.WithLabelValues("wait").Observe(time.Since(waitStart).Seconds())
 
 	if result == nil {
-		log.Printf("⏰ Timeout waiting for query search result for job %s", job.JobID)
-		return w.storeQueryErrorResult(job.JobID, job.Query, "Search timeout - no response from ML service")
+		logger.Warn("timeout waiting for query search result", "job_id", job.JobID)
+		return w.storeQueryErrorResult(job.JobID, job.SessionID, job.Query, "Search timeout - no response from ML service")
 	}
 
-	log.Printf("📥 Received query search result for job %s", job.JobID)
+	logger.Info("received query search result", "job_id", job.JobID)
+
+	// Check for a cancellation request between retrieval and enrichment -
+	// the one real stage boundary this worker has, since it doesn't run a
+	// separate rerank/generation phase like the request envisioned.
+	if w.isCancelled(ctx, job.JobID) {
+		logger.Info("query search job was cancelled", "job_id", job.JobID)
+		return w.storeQueryCancelledResult(job.JobID, job.SessionID, job.Query)
+	}
 
 	// Process and enrich results
 	var recommendations []models.ArticleRecommendation
 	if result.Response != nil && len(result.Response.Results) > 0 {
 		recommendations = w.processQueryResults(result.Response.Results)
 
-		// Enrich with full article data
+		// Enrich with full article data, bounded by MaxDBEnrichment
+		// separately from MaxInFlightPerML since it stresses the pgx pool
+		// instead of the ML service.
+		w.dbSem <- struct{}{}
+		enrichStart := time.Now()
 		enrichedResults, err := w.enrichWithArticleData(recommendations)
+		<-w.dbSem
+		metrics.QuerySearchPhaseSeconds.WithLabelValues("enrich").Observe(time.Since(enrichStart).Seconds())
 		if err != nil {
-			log.Printf("❌ Failed to enrich results for job %s: %v", job.JobID, err)
-			return w.storeQueryErrorResult(job.JobID, job.Query, fmt.Sprintf("Failed to enrich results: %v", err))
+			logger.Error("failed to enrich results", "job_id", job.JobID, "error", err)
+			return w.storeQueryErrorResult(job.JobID, job.SessionID, job.Query, fmt.Sprintf("Failed to enrich results: %v", err))
 		}
 		recommendations = enrichedResults
 	}
@@ -111,6 +345,7 @@ func (w *QueryRAGWorker) ProcessQuerySearchJob(job models.QuerySearchJob) error
 	processingTime := time.Since(startTime)
 	queryResult := models.QueryRecommendationResult{
 		JobID:           job.JobID,
+		SessionID:       job.SessionID,
 		Query:           job.Query,
 		Recommendations: recommendations,
 		TotalFound:      len(recommendations),
@@ -119,55 +354,123 @@ func (w *QueryRAGWorker) ProcessQuerySearchJob(job models.QuerySearchJob) error
 		CreatedAt:       time.Now(),
 	}
 
+	storeStart := time.Now()
 	err = w.storeQueryResult(ctx, queryResult)
+	metrics.QuerySearchPhaseSeconds.WithLabelValues("store").Observe(time.Since(storeStart).Seconds())
 	if err != nil {
-		log.Printf("❌ Failed to store query results for job %s: %v", job.JobID, err)
+		logger.Error("failed to store query results", "job_id", job.JobID, "error", err)
 		return fmt.Errorf("failed to store results: %w", err)
 	}
 
-	log.Printf("✅ Completed query search job %s in %v - found %d recommendations", 
-		job.JobID, processingTime, len(recommendations))
+	logger.Info("completed query search job", "job_id", job.JobID, "duration_ms", processingTime.Milliseconds(), "recommendation_count", len(recommendations))
 	return nil
 }
 
-func (w *QueryRAGWorker) collectQuerySearchResult(searchID string, timeout time.Duration, jobID string) *QuerySearchResultMessage {
-	// Set up timeout
-	w.timeoutMutex.Lock()
-	timer := time.NewTimer(timeout)
-	w.timeoutMap[jobID] = &QuerySearchTimeout{
-		SearchID: searchID,
-		Timer:    timer,
-		JobID:    jobID,
-	}
-	w.timeoutMutex.Unlock()
+// BatchResult pairs a job from a ProcessBatch call with its outcome, since
+// ProcessQuerySearchJob's own per-job error isn't enough to tell a caller
+// which of many fanned-out jobs failed.
+type BatchResult struct {
+	Job models.QuerySearchJob
+	Err error
+}
 
-	defer func() {
-		w.timeoutMutex.Lock()
-		delete(w.timeoutMap, jobID)
-		w.timeoutMutex.Unlock()
-		timer.Stop()
-	}()
+// ProcessBatch runs ProcessQuerySearchJob for jobs with at most concurrency
+// goroutines in flight, via forEachIndexed's ForEachJob-style dispatch. The
+// MaxInFlightPerML/MaxDBEnrichment semaphores inside ProcessQuerySearchJob
+// bound ML and DB load independently of concurrency, so raising
+// concurrency just widens the dispatch loop without necessarily widening
+// those two underlying bounds.
+//
+// The first job error cancels ctx, matching errgroup.Group's SetLimit +
+// fail-fast semantics: remaining unclaimed jobs are skipped rather than
+// started, though a job already mid-flight when ctx is canceled still runs
+// to completion, since ProcessQuerySearchJob doesn't accept a context to
+// abort on (see the same tradeoff noted in QueryWorkerPool.handle).
+func (w *QueryRAGWorker) ProcessBatch(ctx context.Context, jobs []models.QuerySearchJob, concurrency int) ([]BatchResult, error) {
+	results := make([]BatchResult, len(jobs))
+
+	err := forEachIndexed(ctx, len(jobs), concurrency, func(ctx context.Context, idx int) error {
+		job := jobs[idx]
+		if ctx.Err() != nil {
+			results[idx] = BatchResult{Job: job, Err: ctx.Err()}
+			return ctx.Err()
+		}
+		jobErr := w.ProcessQuerySearchJob(job)
+		results[idx] = BatchResult{Job: job, Err: jobErr}
+		return jobErr
+	})
 
-	// Wait for result
-	for {
-		select {
-		case result := <-w.resultChannel:
-			var resultSearchID string
-			if result.Response != nil {
-				resultSearchID = result.Response.SearchID
-			} else if result.Error != nil {
-				resultSearchID = result.Error.SearchID
-			}
+	return results, err
+}
+
+// forEachIndexed runs fn for every index from 0 up to n-1 with at most concurrency
+// goroutines in flight. Each worker goroutine atomically claims the next
+// index rather than being handed a fixed slice up front, so a few slow
+// items never leave other workers idle waiting on a static split. The
+// first error returned by fn cancels ctx and becomes forEachIndexed's
+// return value, mirroring errgroup.Group.SetLimit's fail-fast semantics.
+// fn is always called for every claimed index - including ones claimed
+// after ctx was canceled - so it can check ctx.Err() itself and still
+// record an outcome for that index; forEachIndexed never calls fn(idx)
+// more than once or skips a claimed index silently.
+func forEachIndexed(ctx context.Context, n int, concurrency int, fn func(ctx context.Context, idx int) error) error {
+	if n == 0 {
+		return nil
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > n {
+		concurrency = n
+	}
 
-			// Check if this result belongs to our search
-			if resultSearchID == searchID {
-				return &result
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var nextIndex int64 = -1
+	var firstErr error
+	var firstErrOnce sync.Once
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				idx := int(atomic.AddInt64(&nextIndex, 1))
+				if idx >= n {
+					return
+				}
+
+				if err := fn(ctx, idx); err != nil {
+					firstErrOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
 			}
+		}()
+	}
+	wg.Wait()
 
-		case <-timer.C:
-			log.Printf("⏰ Timeout collecting query search result for job %s", jobID)
-			return nil
-		}
+	return firstErr
+}
+
+// collectQuerySearchResult blocks on this search's dedicated channel
+// (registered by ProcessQuerySearchJob via w.register) until its result
+// arrives or timeout elapses. Because resultCh is keyed to exactly one
+// SearchID, no demultiplexing or discard logic is needed here - that work
+// happens once, in deliver.
+func (w *QueryRAGWorker) collectQuerySearchResult(resultCh <-chan QuerySearchResultMessage, timeout time.Duration, jobID string) *QuerySearchResultMessage {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-resultCh:
+		return &result
+	case <-timer.C:
+		log.Printf("⏰ Timeout collecting query search result for job %s", jobID)
+		return nil
 	}
 }
 
@@ -222,22 +525,56 @@ func (w *QueryRAGWorker) processQueryResults(results []models.QuerySearchResult)
 	return recommendations
 }
 
+// enrichWithArticleData fills in Title/Category/URL for each recommendation,
+// probing the LRU cache first and batching every miss into a single
+// GetByIDs query instead of one GetByID round-trip per recommendation.
 func (w *QueryRAGWorker) enrichWithArticleData(recommendations []models.ArticleRecommendation) ([]models.ArticleRecommendation, error) {
+	missing := make([]uuid.UUID, 0, len(recommendations))
 	for i := range recommendations {
-		article, err := w.articleRepo.GetByID(context.Background(), recommendations[i].ArticleID)
-		if err != nil {
-			log.Printf("⚠️ Failed to get article %s: %v", recommendations[i].ArticleID, err)
+		if entry, ok := w.enrichCache.get(recommendations[i].ArticleID); ok {
+			recommendations[i].Title = entry.Title
+			recommendations[i].Category = entry.Category
+			recommendations[i].URL = entry.URL
+			continue
+		}
+		missing = append(missing, recommendations[i].ArticleID)
+	}
+
+	if len(missing) == 0 {
+		return recommendations, nil
+	}
+
+	articles, err := w.articleRepo.GetByIDs(context.Background(), missing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch-fetch articles: %w", err)
+	}
+
+	for i := range recommendations {
+		article, ok := articles[recommendations[i].ArticleID]
+		if !ok {
+			// Already filled from cache above, or the article was deleted
+			// since this recommendation was generated.
 			continue
 		}
 
-		recommendations[i].Title = article.Title
-		recommendations[i].Category = article.Category
-		recommendations[i].URL = article.URL
+		entry := enrichmentCacheEntry{Title: article.Title, Category: article.Category, URL: article.URL}
+		w.enrichCache.set(recommendations[i].ArticleID, entry)
+
+		recommendations[i].Title = entry.Title
+		recommendations[i].Category = entry.Category
+		recommendations[i].URL = entry.URL
 	}
 
 	return recommendations, nil
 }
 
+// GetStats reports the enrichment cache's hit/miss/size counters, so a
+// /metrics or debug endpoint can see how effective
+// --enrichment-cache-size is.
+func (w *QueryRAGWorker) GetStats() EnrichmentCacheStats {
+	return w.enrichCache.stats()
+}
+
 func (w *QueryRAGWorker) storeQueryResult(ctx context.Context, result models.QueryRecommendationResult) error {
 	// Store in Redis with TTL
 	key := fmt.Sprintf("query_search_result:%s", result.JobID)
@@ -252,18 +589,216 @@ func (w *QueryRAGWorker) storeQueryResult(ctx context.Context, result models.Que
 	}
 
 	log.Printf("💾 Stored query search result for job %s in Redis", result.JobID)
+
+	w.enqueueArchive(result)
+	w.analyticsClient.Index(buildQueryEvent(result))
+	w.updateJobRecord(ctx, result)
+
+	var stage string
+	switch result.Status {
+	case "completed":
+		stage = events.StageCompleted
+	case "cancelled":
+		stage = events.StageCancelled
+	default:
+		stage = events.StageFailed
+	}
+	metrics.SearchJobsProcessedTotal.WithLabelValues(result.Status).Inc()
+	w.publishStage(ctx, result.JobID, stage, map[string]interface{}{"status": result.Status, "total_found": result.TotalFound})
+
 	return nil
 }
 
-func (w *QueryRAGWorker) storeQueryErrorResult(jobID string, query string, errorMsg string) error {
+// recordJobStart persists job's search_jobs row (creating it if this is the
+// first delivery attempt) and bumps it to running, best-effort: a failure
+// here is logged, not returned, since it would otherwise turn a durable
+// bookkeeping hiccup into a failed search for the caller.
+func (w *QueryRAGWorker) recordJobStart(ctx context.Context, job models.QuerySearchJob) {
+	if w.jobRepo == nil {
+		return
+	}
+	if err := w.jobRepo.Create(ctx, job); err != nil {
+		ragWorkerLog.Error("failed to persist search job record", "job_id", job.JobID, "error", err)
+	}
+	if err := w.jobRepo.MarkRunning(ctx, job.JobID); err != nil {
+		ragWorkerLog.Error("failed to mark search job running", "job_id", job.JobID, "error", err)
+	}
+}
+
+// updateJobRecord moves jobRepo's row to result's terminal status, the same
+// best-effort/logged-not-returned treatment as recordJobStart.
+func (w *QueryRAGWorker) updateJobRecord(ctx context.Context, result models.QueryRecommendationResult) {
+	if w.jobRepo == nil {
+		return
+	}
+
+	var err error
+	switch result.Status {
+	case "completed":
+		err = w.jobRepo.MarkCompleted(ctx, result.JobID)
+	case "cancelled":
+		err = w.jobRepo.MarkCancelled(ctx, result.JobID)
+	default:
+		err = w.jobRepo.MarkFailed(ctx, result.JobID, result.Error)
+	}
+	if err != nil {
+		ragWorkerLog.Error("failed to update search job record", "job_id", result.JobID, "status", result.Status, "error", err)
+	}
+}
+
+// searchJobCancelKeyPrefix must match SearchController.CancelSearchJob's key
+// format - the two packages coordinate through this Redis key the same way
+// storeQueryResult's "query_search_result:%s" key is shared with
+// GetQuerySearchJobStatus.
+const searchJobCancelKeyPrefix = "search_job_cancel:"
+
+// isCancelled reports whether a client requested cancellation of jobID via
+// SearchController.CancelSearchJob setting the search_job_cancel:<id> flag.
+func (w *QueryRAGWorker) isCancelled(ctx context.Context, jobID string) bool {
+	_, err := w.redisClient.Get(ctx, searchJobCancelKeyPrefix+jobID).Result()
+	return err == nil
+}
+
+// jobLockKeyPrefix namespaces the per-job execution lock acquireJobLock/
+// releaseJobLock use to guard against a redelivered message (e.g. after a
+// broker requeue) running ProcessQuerySearchJob a second time concurrently.
+const jobLockKeyPrefix = "job_lock:"
+
+// jobLockTTL bounds how long a job's execution lock survives if this
+// process crashes mid-job without reaching releaseJobLock, generously
+// beyond ProcessQuerySearchJob's own 30s ML wait so a crashed worker can't
+// permanently wedge a job_id.
+const jobLockTTL = 2 * time.Minute
+
+// acquireJobLock SETNXs jobLockKeyPrefix+jobID, reporting true if this call
+// won the lock. A Redis error is treated as "proceed anyway" rather than
+// refusing to process the job, the same best-effort tradeoff as
+// TenantSemaphore falling open on a Redis outage.
+func (w *QueryRAGWorker) acquireJobLock(ctx context.Context, jobID string) bool {
+	acquired, err := w.redisClient.SetNX(ctx, jobLockKeyPrefix+jobID, "1", jobLockTTL).Result()
+	if err != nil {
+		ragWorkerLog.Error("failed to acquire job lock, proceeding without it", "job_id", jobID, "error", err)
+		return true
+	}
+	return acquired
+}
+
+// releaseJobLock clears jobID's execution lock so a legitimate retry (after
+// RetryOrDeadLetter's backoff) isn't blocked by a lock this same job
+// already finished with.
+func (w *QueryRAGWorker) releaseJobLock(ctx context.Context, jobID string) {
+	if err := w.redisClient.Del(ctx, jobLockKeyPrefix+jobID).Err(); err != nil {
+		ragWorkerLog.Error("failed to release job lock", "job_id", jobID, "error", err)
+	}
+}
+
+// publishStage best-effort publishes a search job progress event; failures
+// are logged, not returned, since the job's actual result is already
+// durably stored by the time this runs and a dropped progress event
+// shouldn't turn an otherwise successful job into an error.
+func (w *QueryRAGWorker) publishStage(ctx context.Context, jobID, stage string, data map[string]interface{}) {
+	if w.eventPublisher == nil {
+		return
+	}
+	if err := w.eventPublisher.Publish(ctx, jobID, stage, data); err != nil {
+		ragWorkerLog.Error("failed to publish search job event", "job_id", jobID, "stage", stage, "error", err)
+	}
+}
+
+// buildQueryEvent summarizes result's recommendations into the aggregate
+// fields query_events stores, so ProcessQuerySearchJob's success and error
+// paths - which both funnel through storeQueryResult - get the same
+// analytics row shape.
+func buildQueryEvent(result models.QueryRecommendationResult) analytics.QueryEvent {
+	var matchedChunks int
+	var maxScore, scoreSum float64
+	for _, rec := range result.Recommendations {
+		matchedChunks += rec.MatchedChunks
+		scoreSum += rec.HybridScore
+		if rec.HybridScore > maxScore {
+			maxScore = rec.HybridScore
+		}
+	}
+
+	var avgScore float64
+	if len(result.Recommendations) > 0 {
+		avgScore = scoreSum / float64(len(result.Recommendations))
+	}
+
+	var latencyMS int64
+	if d, err := time.ParseDuration(result.ProcessingTime); err == nil {
+		latencyMS = d.Milliseconds()
+	}
+
+	return analytics.QueryEvent{
+		JobID:          result.JobID,
+		Query:          result.Query,
+		Status:         result.Status,
+		MatchedChunks:  matchedChunks,
+		MaxHybridScore: maxScore,
+		AvgHybridScore: avgScore,
+		LatencyMS:      latencyMS,
+		ErrorCode:      result.Error,
+		CreatedAt:      result.CreatedAt,
+	}
+}
+
+// enqueueArchive hands result to archiveWorker, a no-op when archiving is
+// disabled. archivePending is incremented before the send so Close can
+// always wait for exactly the results it queued, never fewer.
+func (w *QueryRAGWorker) enqueueArchive(result models.QueryRecommendationResult) {
+	if w.archiveRepo == nil {
+		return
+	}
+	w.archivePending.Add(1)
+	w.archiveChannel <- &result
+}
+
+// archiveWorker drains archiveChannel and persists each result via
+// archiveRepo, logging rather than returning a failure since the result is
+// already safely in Redis by the time it reaches here.
+func (w *QueryRAGWorker) archiveWorker() {
+	for result := range w.archiveChannel {
+		if err := w.archiveRepo.Archive(context.Background(), *result); err != nil {
+			ragWorkerLog.Error("failed to archive query result", "job_id", result.JobID, "error", err)
+		}
+		w.archivePending.Done()
+	}
+}
+
+// Close stops the archive worker, blocking until every result already
+// queued has finished writing to Postgres, so a shutdown can't silently
+// drop archived history. Safe to call when archiving is disabled.
+func (w *QueryRAGWorker) Close() {
+	if w.archiveChannel != nil {
+		close(w.archiveChannel)
+	}
+	w.archivePending.Wait()
+}
+
+func (w *QueryRAGWorker) storeQueryErrorResult(jobID string, sessionID string, query string, errorMsg string) error {
+	return w.storeQueryTerminalResult(jobID, sessionID, query, "error", errorMsg)
+}
+
+// storeQueryCancelledResult records a job that was stopped early by
+// isCancelled, the cancellation counterpart to storeQueryErrorResult.
+func (w *QueryRAGWorker) storeQueryCancelledResult(jobID, sessionID, query string) error {
+	return w.storeQueryTerminalResult(jobID, sessionID, query, "cancelled", "cancelled by request")
+}
+
+// storeQueryTerminalResult is storeQueryErrorResult/storeQueryCancelledResult's
+// shared plumbing: both give up before producing recommendations, so only
+// status/errorMsg differ between them.
+func (w *QueryRAGWorker) storeQueryTerminalResult(jobID, sessionID, query, status, errorMsg string) error {
 	ctx := context.Background()
 	result := models.QueryRecommendationResult{
 		JobID:           jobID,
+		SessionID:       sessionID,
 		Query:           query,
 		Recommendations: []models.ArticleRecommendation{},
 		TotalFound:      0,
 		ProcessingTime:  "0s",
-		Status:          "error",
+		Status:          status,
 		Error:           errorMsg,
 		CreatedAt:       time.Now(),
 	}
@@ -318,12 +853,13 @@ func (w *QueryRAGWorker) startQuerySearchResultsConsumer() {
 			}
 		}
 
-		// Send to result channel (non-blocking)
-		select {
-		case w.resultChannel <- resultMsg:
-		default:
-			log.Printf("⚠️ Query result channel is full, dropping message")
+		var searchID string
+		if resultMsg.Response != nil {
+			searchID = resultMsg.Response.SearchID
+		} else {
+			searchID = resultMsg.Error.SearchID
 		}
+		w.deliver(searchID, resultMsg)
 
 		d.Ack(false)
 	}
@@ -0,0 +1,214 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"rec-mind/models"
+)
+
+func newTestQueryRAGWorker() *QueryRAGWorker {
+	return &QueryRAGWorker{
+		pending: make(map[string]chan QuerySearchResultMessage),
+		orphans: make(map[string]orphanResult),
+	}
+}
+
+// TestQueryRAGWorker_ConcurrentSearchesDoNotCrossDeliver fans out 100
+// concurrent searches, each registering its own result channel and then
+// receiving a result delivered (out of order, from a shared dispatcher
+// goroutine standing in for startQuerySearchResultsConsumer) by SearchID.
+// Before the pending-map demultiplexer, a shared resultChannel meant any
+// reader could observe - and have to discard - any other reader's message;
+// this proves every search gets exactly its own result.
+func TestQueryRAGWorker_ConcurrentSearchesDoNotCrossDeliver(t *testing.T) {
+	w := newTestQueryRAGWorker()
+	const n = 100
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			searchID := fmt.Sprintf("search-%d", i)
+			resultCh := w.register(searchID)
+			defer w.unregister(searchID)
+
+			// Simulate the ML service responding on another goroutine, as
+			// startQuerySearchResultsConsumer would for a real delivery.
+			go w.deliver(searchID, QuerySearchResultMessage{
+				Response: &models.QuerySearchResponse{SearchID: searchID},
+			})
+
+			result := <-resultCh
+			assert.NotNil(t, result.Response)
+			assert.Equal(t, searchID, result.Response.SearchID, "search %s must not receive another search's result", searchID)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// TestQueryRAGWorker_OrphanResultIsClaimedByLateRegister covers a result
+// arriving before ProcessQuerySearchJob has registered its channel - the
+// race the shared resultChannel couldn't safely handle either. deliver
+// should park it in orphans, and a subsequent register should find it
+// there instead of blocking until orphanTTL/timeout.
+func TestQueryRAGWorker_OrphanResultIsClaimedByLateRegister(t *testing.T) {
+	w := newTestQueryRAGWorker()
+	searchID := "late-search"
+
+	w.deliver(searchID, QuerySearchResultMessage{
+		Response: &models.QuerySearchResponse{SearchID: searchID},
+	})
+
+	select {
+	case result := <-w.register(searchID):
+		assert.Equal(t, searchID, result.Response.SearchID)
+	case <-time.After(time.Second):
+		t.Fatal("expected orphaned result to be claimed immediately on register")
+	}
+
+	w.orphansMu.Lock()
+	_, stillOrphaned := w.orphans[searchID]
+	w.orphansMu.Unlock()
+	assert.False(t, stillOrphaned, "claimed orphan should be removed from the bucket")
+}
+
+func TestForEachIndexed_VisitsEveryIndexExactlyOnce(t *testing.T) {
+	const n = 100
+	var visits [n]int32
+
+	err := forEachIndexed(context.Background(), n, 8, func(_ context.Context, idx int) error {
+		atomic.AddInt32(&visits[idx], 1)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	for idx, count := range visits {
+		assert.Equal(t, int32(1), count, "index %d should be visited exactly once", idx)
+	}
+}
+
+func TestForEachIndexed_RespectsConcurrencyLimit(t *testing.T) {
+	const concurrency = 4
+	var inFlight int32
+	var maxInFlight int32
+
+	err := forEachIndexed(context.Background(), 50, concurrency, func(_ context.Context, _ int) error {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, int(atomic.LoadInt32(&maxInFlight)), concurrency)
+}
+
+func TestForEachIndexed_FirstErrorCancelsRemainingSiblings(t *testing.T) {
+	boom := errors.New("boom")
+	var started int32
+
+	err := forEachIndexed(context.Background(), 50, 4, func(ctx context.Context, idx int) error {
+		atomic.AddInt32(&started, 1)
+		if idx == 0 {
+			return boom
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	assert.ErrorIs(t, err, boom)
+	// Every claimed index must still have been visited - forEachIndexed
+	// never silently drops a claimed index even after cancellation.
+	assert.Equal(t, int32(50), atomic.LoadInt32(&started))
+}
+
+func TestQueryRAGWorker_PendingSearches(t *testing.T) {
+	w := newTestQueryRAGWorker()
+	assert.Equal(t, 0, w.PendingSearches())
+
+	ch := w.register("s1")
+	assert.Equal(t, 1, w.PendingSearches())
+
+	w.unregister("s1")
+	assert.Equal(t, 0, w.PendingSearches())
+	_ = ch
+}
+
+// fakeQueryResultRepository records every archived result instead of
+// writing to Postgres, so the archiving tests below don't need a live DB.
+type fakeQueryResultRepository struct {
+	mu       sync.Mutex
+	archived []models.QueryRecommendationResult
+}
+
+func (f *fakeQueryResultRepository) Archive(_ context.Context, result models.QueryRecommendationResult) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.archived = append(f.archived, result)
+	return nil
+}
+
+func (f *fakeQueryResultRepository) GetHistory(_ context.Context, _ string, _ time.Time, _ int) ([]models.QueryRecommendationResult, error) {
+	return nil, nil
+}
+
+// TestQueryRAGWorker_CloseDrainsArchiveChannel proves Close blocks until
+// every result already enqueued by storeQueryResult has actually reached
+// archiveRepo, not just until the channel is closed.
+func TestQueryRAGWorker_CloseDrainsArchiveChannel(t *testing.T) {
+	repo := &fakeQueryResultRepository{}
+	w := newTestQueryRAGWorker()
+	w.archiveRepo = repo
+	w.archiveChannel = make(chan *models.QueryRecommendationResult, archiveChannelSize)
+	go w.archiveWorker()
+
+	const n = 20
+	for i := 0; i < n; i++ {
+		w.enqueueArchive(models.QueryRecommendationResult{JobID: fmt.Sprintf("job-%d", i)})
+	}
+
+	w.Close()
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	assert.Len(t, repo.archived, n, "Close must wait for every enqueued result to be archived")
+}
+
+// TestQueryRAGWorker_CloseWithArchivingDisabledReturnsImmediately covers
+// the --archive=false path, where archiveChannel is nil and Close must not
+// block or panic on a nil channel close.
+func TestQueryRAGWorker_CloseWithArchivingDisabledReturnsImmediately(t *testing.T) {
+	w := newTestQueryRAGWorker()
+
+	done := make(chan struct{})
+	go func() {
+		w.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close should return immediately when archiving is disabled")
+	}
+}
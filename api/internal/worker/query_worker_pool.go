@@ -0,0 +1,415 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"rec-mind/internal/metrics"
+	"rec-mind/models"
+	"rec-mind/mq"
+)
+
+// queryWorkerPoolMetricsOnce guards Prometheus registration: only the first
+// QueryWorkerPool constructed in a process reports gauges, since a process
+// runs exactly one pool and promauto panics on duplicate registration.
+var queryWorkerPoolMetricsOnce sync.Once
+
+// QueryWorkerPoolMetrics is a point-in-time snapshot of a QueryWorkerPool's
+// throughput, served on the query worker's /metrics endpoint.
+type QueryWorkerPoolMetrics struct {
+	Active       int64 `json:"active"`
+	Queued       int64 `json:"queued"`
+	Processed    int64 `json:"processed"`
+	Failed       int64 `json:"failed"`
+	DeadLettered int64 `json:"dead_lettered"`
+}
+
+// QueryWorkerPool consumes query_search_jobs with a bounded number of
+// concurrent ProcessQuerySearchJob calls, rather than the single goroutine
+// ranging over msgs that used to serialize every job. QoS prefetch is set
+// to match concurrency so RabbitMQ never hands this consumer more unacked
+// work than it can run at once.
+type QueryWorkerPool struct {
+	queryWorker *QueryRAGWorker
+	channel     *amqp.Channel
+	concurrency int
+	jobTimeout  time.Duration
+	consumerTag string
+	instanceID  string
+
+	sem chan struct{}
+	wg  sync.WaitGroup
+
+	mu        sync.Mutex
+	pending   map[uint64]amqp.Delivery
+	isRunning bool
+
+	active       int64
+	queued       int64
+	processed    int64
+	failed       int64
+	deadLettered int64
+
+	// tenantSem caps how many jobs from a single TenantID may run at once
+	// across the whole fleet, so one noisy tenant can't starve others of
+	// this pool's shared concurrency. Nil disables the cap entirely.
+	tenantSem *TenantSemaphore
+
+	// queuedByBand/activeByBand give queue depth broken down by priority
+	// band (see priorityBand), read directly off amqp.Delivery.Priority so
+	// no job body needs to be unmarshaled before dispatch can count it.
+	queuedByBand, activeByBand bandCounts
+}
+
+// priorityBand buckets an AMQP priority (0-QuerySearchJobsMaxPriority)
+// into one of three coarse bands for metrics, rather than one label per
+// priority value.
+func priorityBand(priority uint8) string {
+	switch {
+	case priority >= 7:
+		return "high"
+	case priority >= 4:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// bandCounts holds one atomically-updated counter per priorityBand value.
+type bandCounts struct {
+	low, medium, high int64
+}
+
+func (b *bandCounts) counter(band string) *int64 {
+	switch band {
+	case "high":
+		return &b.high
+	case "medium":
+		return &b.medium
+	default:
+		return &b.low
+	}
+}
+
+// NewQueryWorkerPool creates a QueryWorkerPool bounded to concurrency
+// in-flight jobs, each given up to jobTimeout to complete. tenantSem may be
+// nil to disable per-tenant concurrency caps entirely.
+func NewQueryWorkerPool(queryWorker *QueryRAGWorker, channel *amqp.Channel, concurrency int, jobTimeout time.Duration, tenantSem *TenantSemaphore) (*QueryWorkerPool, error) {
+	if channel == nil {
+		return nil, fmt.Errorf("RabbitMQ channel not initialized")
+	}
+	if concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1, got %d", concurrency)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown-host"
+	}
+
+	return &QueryWorkerPool{
+		queryWorker: queryWorker,
+		channel:     channel,
+		concurrency: concurrency,
+		jobTimeout:  jobTimeout,
+		consumerTag: "query-rag-worker-pool",
+		instanceID:  fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+		sem:         make(chan struct{}, concurrency),
+		pending:     make(map[uint64]amqp.Delivery),
+		tenantSem:   tenantSem,
+	}, nil
+}
+
+// Start declares the query_search_jobs queue, sets QoS to match the pool's
+// concurrency, and begins dispatching deliveries across the worker pool.
+func (p *QueryWorkerPool) Start() error {
+	p.mu.Lock()
+	if p.isRunning {
+		p.mu.Unlock()
+		return fmt.Errorf("query worker pool is already running")
+	}
+	p.isRunning = true
+	p.mu.Unlock()
+
+	queue, err := p.channel.QueueDeclare(
+		"query_search_jobs",           // name
+		true,                          // durable
+		false,                         // delete when unused
+		false,                         // exclusive
+		false,                         // no-wait
+		mq.QuerySearchJobsQueueArgs(), // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare query_search_jobs queue: %w", err)
+	}
+
+	if err := p.channel.Qos(p.concurrency, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	msgs, err := p.channel.Consume(
+		queue.Name,    // queue
+		p.consumerTag, // consumer
+		false,         // auto-ack
+		false,         // exclusive
+		false,         // no-local
+		false,         // no-wait
+		nil,           // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register query search jobs consumer: %w", err)
+	}
+
+	p.wg.Add(1)
+	go p.dispatch(msgs)
+
+	p.registerMetrics()
+
+	ragWorkerLog.Info("query worker pool started", "concurrency", p.concurrency, "job_timeout", p.jobTimeout)
+	return nil
+}
+
+// registerMetrics exposes the pool's counters as Prometheus gauges on the
+// process's /metrics endpoint.
+func (p *QueryWorkerPool) registerMetrics() {
+	queryWorkerPoolMetricsOnce.Do(func() {
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "rec_mind_query_worker_pool_active",
+			Help: "Number of query search jobs currently being processed by the query worker pool.",
+		}, func() float64 { return float64(atomic.LoadInt64(&p.active)) })
+
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "rec_mind_query_worker_pool_queued",
+			Help: "Number of query search jobs received but waiting for a free worker slot.",
+		}, func() float64 { return float64(atomic.LoadInt64(&p.queued)) })
+
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "rec_mind_query_worker_pool_processed_total",
+			Help: "Total number of query search jobs successfully processed by the query worker pool.",
+		}, func() float64 { return float64(atomic.LoadInt64(&p.processed)) })
+
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "rec_mind_query_worker_pool_failed_total",
+			Help: "Total number of query search jobs that failed or timed out in the query worker pool.",
+		}, func() float64 { return float64(atomic.LoadInt64(&p.failed)) })
+
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "rec_mind_query_worker_pool_dead_lettered_total",
+			Help: "Total number of query search jobs sent to query_search_jobs.dead after exhausting the retry ladder.",
+		}, func() float64 { return float64(atomic.LoadInt64(&p.deadLettered)) })
+
+		for _, band := range []string{"low", "medium", "high"} {
+			band := band
+			promauto.NewGaugeFunc(prometheus.GaugeOpts{
+				Name:        "rec_mind_query_worker_pool_queued_by_priority",
+				Help:        "Number of query search jobs waiting for a free worker slot, labeled by priority band.",
+				ConstLabels: prometheus.Labels{"priority_band": band},
+			}, func() float64 { return float64(atomic.LoadInt64(p.queuedByBand.counter(band))) })
+
+			promauto.NewGaugeFunc(prometheus.GaugeOpts{
+				Name:        "rec_mind_query_worker_pool_active_by_priority",
+				Help:        "Number of query search jobs currently being processed, labeled by priority band.",
+				ConstLabels: prometheus.Labels{"priority_band": band},
+			}, func() float64 { return float64(atomic.LoadInt64(p.activeByBand.counter(band))) })
+		}
+	})
+}
+
+// dispatch ranges over deliveries and hands each to a bounded worker
+// goroutine, blocking on the pool's semaphore once concurrency in-flight
+// jobs are already running. That block is the pool's backpressure: RabbitMQ
+// QoS keeps at most concurrency unacked deliveries outstanding, so a full
+// pool simply stalls the dispatch loop instead of piling up goroutines.
+func (p *QueryWorkerPool) dispatch(msgs <-chan amqp.Delivery) {
+	defer p.wg.Done()
+
+	for d := range msgs {
+		band := priorityBand(d.Priority)
+
+		p.trackPending(d)
+		atomic.AddInt64(&p.queued, 1)
+		atomic.AddInt64(p.queuedByBand.counter(band), 1)
+
+		p.sem <- struct{}{}
+		atomic.AddInt64(&p.queued, -1)
+		atomic.AddInt64(p.queuedByBand.counter(band), -1)
+		atomic.AddInt64(&p.active, 1)
+		atomic.AddInt64(p.activeByBand.counter(band), 1)
+
+		p.wg.Add(1)
+		go func(d amqp.Delivery) {
+			defer p.wg.Done()
+			defer func() { <-p.sem }()
+			defer atomic.AddInt64(&p.active, -1)
+			defer atomic.AddInt64(p.activeByBand.counter(band), -1)
+
+			p.handle(d)
+		}(d)
+	}
+
+	ragWorkerLog.Info("query worker pool dispatch loop stopped")
+}
+
+func (p *QueryWorkerPool) handle(d amqp.Delivery) {
+	defer p.untrackPending(d.DeliveryTag)
+
+	var job models.QuerySearchJob
+	if err := json.Unmarshal(d.Body, &job); err != nil {
+		ragWorkerLog.Error("failed to unmarshal query search job", "error", err)
+		atomic.AddInt64(&p.failed, 1)
+		metrics.RabbitMQConsumeTotal.WithLabelValues("query_search_jobs", "error").Inc()
+		p.retryOrDeadLetter(d, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.jobTimeout)
+	defer cancel()
+
+	tenantID := job.TenantID
+	if tenantID == "" {
+		tenantID = job.SessionID
+	}
+
+	tenantSlotHeld := false
+	if p.tenantSem != nil {
+		acquired, err := p.tenantSem.Acquire(ctx, tenantID)
+		if err != nil {
+			ragWorkerLog.Warn("failed to check tenant semaphore, proceeding without the cap", "tenant_id", tenantID, "error", err)
+		} else if !acquired {
+			ragWorkerLog.Warn("tenant at concurrency cap, deferring job", "tenant_id", tenantID, "job_id", job.JobID)
+			metrics.RabbitMQConsumeTotal.WithLabelValues("query_search_jobs", "tenant_cap").Inc()
+			p.retryOrDeadLetter(d, fmt.Errorf("tenant %s is at its concurrency cap", tenantID))
+			return
+		} else {
+			tenantSlotHeld = true
+		}
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- p.queryWorker.ProcessQuerySearchJob(job) }()
+
+	select {
+	case err := <-done:
+		if tenantSlotHeld {
+			p.tenantSem.Release(context.Background(), tenantID)
+		}
+		if err != nil {
+			ragWorkerLog.Error("failed to process query search job", "job_id", job.JobID, "error", err)
+			atomic.AddInt64(&p.failed, 1)
+			metrics.RabbitMQConsumeTotal.WithLabelValues("query_search_jobs", "error").Inc()
+			p.retryOrDeadLetter(d, err)
+			return
+		}
+		atomic.AddInt64(&p.processed, 1)
+		metrics.RabbitMQConsumeTotal.WithLabelValues("query_search_jobs", "ack").Inc()
+		d.Ack(false)
+	case <-ctx.Done():
+		timeoutErr := fmt.Errorf("query search job %s exceeded job timeout of %s", job.JobID, p.jobTimeout)
+		ragWorkerLog.Error("query search job exceeded job timeout", "job_id", job.JobID, "timeout", p.jobTimeout)
+		atomic.AddInt64(&p.failed, 1)
+		metrics.RabbitMQConsumeTotal.WithLabelValues("query_search_jobs", "timeout").Inc()
+		// ProcessQuerySearchJob keeps running in the background; its own
+		// result will be discarded once it finishes. Hold the tenant's slot
+		// until that happens instead of releasing it here, or a tenant whose
+		// jobs routinely hit jobTimeout could exceed its concurrency cap
+		// while that background work is still in flight.
+		if tenantSlotHeld {
+			go func() {
+				<-done
+				p.tenantSem.Release(context.Background(), tenantID)
+			}()
+		}
+		p.retryOrDeadLetter(d, timeoutErr)
+	}
+}
+
+// retryOrDeadLetter routes a failed query_search_jobs delivery through the
+// exponential-backoff retry ladder declared in mq.InitRabbitMQ, instead of
+// the naive Nack(false, true) that used to requeue poison messages forever.
+func (p *QueryWorkerPool) retryOrDeadLetter(d amqp.Delivery, cause error) {
+	deadLettered, err := mq.RetryOrDeadLetter(p.channel, d, "query_search_jobs", mq.DefaultRetryTiers, cause, p.instanceID)
+	if err != nil {
+		ragWorkerLog.Error("failed to route query search job through retry ladder", "error", err)
+		return
+	}
+	if deadLettered {
+		atomic.AddInt64(&p.deadLettered, 1)
+		metrics.RabbitMQConsumeTotal.WithLabelValues("query_search_jobs", "dead_letter").Inc()
+	}
+}
+
+func (p *QueryWorkerPool) trackPending(d amqp.Delivery) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending[d.DeliveryTag] = d
+}
+
+func (p *QueryWorkerPool) untrackPending(tag uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.pending, tag)
+}
+
+// Stop cancels the consumer so no new deliveries arrive, waits up to
+// gracePeriod for in-flight jobs to finish, then Nacks-with-requeue any
+// deliveries still outstanding so they aren't lost.
+func (p *QueryWorkerPool) Stop(gracePeriod time.Duration) {
+	p.mu.Lock()
+	if !p.isRunning {
+		p.mu.Unlock()
+		return
+	}
+	p.isRunning = false
+	p.mu.Unlock()
+
+	ragWorkerLog.Info("stopping query worker pool", "grace_period", gracePeriod)
+
+	if err := p.channel.Cancel(p.consumerTag, false); err != nil {
+		ragWorkerLog.Warn("failed to cancel query worker pool consumer", "error", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(gracePeriod):
+		ragWorkerLog.Warn("grace period elapsed with jobs still in flight, requeuing remaining deliveries")
+	}
+
+	p.mu.Lock()
+	remaining := make([]amqp.Delivery, 0, len(p.pending))
+	for _, d := range p.pending {
+		remaining = append(remaining, d)
+	}
+	p.mu.Unlock()
+
+	for _, d := range remaining {
+		d.Nack(false, true)
+	}
+
+	ragWorkerLog.Info("query worker pool stopped", "requeued", len(remaining))
+}
+
+// Metrics returns a snapshot of the pool's current throughput.
+func (p *QueryWorkerPool) Metrics() QueryWorkerPoolMetrics {
+	return QueryWorkerPoolMetrics{
+		Active:       atomic.LoadInt64(&p.active),
+		Queued:       atomic.LoadInt64(&p.queued),
+		Processed:    atomic.LoadInt64(&p.processed),
+		Failed:       atomic.LoadInt64(&p.failed),
+		DeadLettered: atomic.LoadInt64(&p.deadLettered),
+	}
+}
@@ -6,9 +6,8 @@ import (
 	"fmt"
 	"log"
 	"math"
-	"slices"
-	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,18 +15,50 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"rec-mind/internal/database"
+	"rec-mind/internal/metrics"
+	"rec-mind/internal/recostream"
+	"rec-mind/internal/reranker"
 	"rec-mind/internal/repository"
 	"rec-mind/mq"
 )
 
+// defaultMaxConcurrentSearches bounds how many chunk searches a single job
+// may have outstanding (published, no result yet) at once, so one job with
+// hundreds of chunks can't starve every other job's searches out of the
+// downstream search workers.
+const defaultMaxConcurrentSearches = 8
+
+// defaultSearchCollectionTimeout bounds how long a job waits for its chunk
+// searches to come back before giving up on the stragglers.
+const defaultSearchCollectionTimeout = 30 * time.Second
+
+// partialSnapshotFraction and partialSnapshotInterval bound how often a job
+// re-publishes its in-progress ranking: whichever threshold is hit first
+// triggers a new snapshot, so a UI can render early results within a second
+// without being flooded by a snapshot per single search result.
+const (
+	partialSnapshotFraction = 0.25
+	partialSnapshotInterval = 500 * time.Millisecond
+)
+
 type RAGWorker struct {
-	chunkRepo     repository.ArticleChunkRepository
-	articleRepo   repository.ArticleRepository
-	redisClient   *redis.Client
-	channel       *amqp.Channel
-	resultChannel chan SearchResultMessage
-	timeoutMap    map[string]*SearchTimeout
-	timeoutMutex  sync.RWMutex
+	chunkRepo             repository.ArticleChunkRepository
+	articleRepo           repository.ArticleRepository
+	embeddingRepo         repository.ArticleEmbeddingRepository
+	redisClient           *redis.Client
+	resultPublisher       recostream.ResultPublisher
+	rerankerClient        reranker.RerankerClient
+	channel               *amqp.Channel
+	maxConcurrentSearches int
+	searchTimeout         time.Duration
+
+	// mu guards jobResults and searchJobs, which together let the search
+	// results consumer route each incoming message to the right job's
+	// coordinator without a single shared channel becoming a bottleneck or
+	// a point where a full buffer silently drops another job's results.
+	mu         sync.Mutex
+	jobResults map[string]chan SearchResultMessage // jobID -> per-job result channel
+	searchJobs map[string]string                   // searchID -> jobID
 }
 
 type SearchResultMessage struct {
@@ -35,24 +66,23 @@ type SearchResultMessage struct {
 	Error    *database.ChunkSearchError
 }
 
-type SearchTimeout struct {
-	SearchIDs []string
-	Timer     *time.Timer
-	JobID     string
-}
-
-func NewRAGWorker(chunkRepo repository.ArticleChunkRepository, articleRepo repository.ArticleRepository, redisClient *redis.Client) (*RAGWorker, error) {
+func NewRAGWorker(chunkRepo repository.ArticleChunkRepository, articleRepo repository.ArticleRepository, embeddingRepo repository.ArticleEmbeddingRepository, redisClient *redis.Client, resultPublisher recostream.ResultPublisher, rerankerClient reranker.RerankerClient) (*RAGWorker, error) {
 	if mq.MQChannel == nil {
 		return nil, fmt.Errorf("RabbitMQ channel not initialized")
 	}
 
 	worker := &RAGWorker{
-		chunkRepo:     chunkRepo,
-		articleRepo:   articleRepo,
-		redisClient:   redisClient,
-		channel:       mq.MQChannel,
-		resultChannel: make(chan SearchResultMessage, 100),
-		timeoutMap:    make(map[string]*SearchTimeout),
+		chunkRepo:             chunkRepo,
+		articleRepo:           articleRepo,
+		embeddingRepo:         embeddingRepo,
+		redisClient:           redisClient,
+		resultPublisher:       resultPublisher,
+		rerankerClient:        rerankerClient,
+		channel:               mq.MQChannel,
+		maxConcurrentSearches: defaultMaxConcurrentSearches,
+		searchTimeout:         defaultSearchCollectionTimeout,
+		jobResults:            make(map[string]chan SearchResultMessage),
+		searchJobs:            make(map[string]string),
 	}
 
 	// Start search results consumer
@@ -61,67 +91,85 @@ func NewRAGWorker(chunkRepo repository.ArticleChunkRepository, articleRepo repos
 	return worker, nil
 }
 
-func (w *RAGWorker) ProcessRecommendationJob(job database.RecommendationJob) error {
+// ProcessRecommendationJob runs one recommendation job to completion. ctx
+// governs the whole job: its deadline bounds the chunk-search fan-out and
+// collection, and cancelling it (e.g. the HTTP caller disconnected) stops
+// outstanding searches via a search_cancel message instead of letting them
+// run to no purpose.
+func (w *RAGWorker) ProcessRecommendationJob(ctx context.Context, job database.RecommendationJob) error {
 	startTime := time.Now()
-	ctx := context.Background()
 
 	log.Printf("🚀 Processing recommendation job %s for article %s", job.JobID, job.ArticleID)
 
-	// 1. Get source article chunks
+	// 1. Get source article chunks (and their precomputed embeddings, which
+	// is what drives the "embedding" stage below — ProcessRecommendationJob
+	// searches against embeddings computed when the article was ingested
+	// rather than computing any itself).
+	w.publishStage(ctx, job.JobID, recostream.StageEmbedding)
+	embeddingStart := time.Now()
 	chunks, err := w.chunkRepo.GetByArticleID(ctx, job.ArticleID)
+	metrics.EmbeddingSeconds.Observe(time.Since(embeddingStart).Seconds())
 	if err != nil {
+		w.publishStage(ctx, job.JobID, recostream.StageFailed)
+		metrics.JobTotalSeconds.WithLabelValues("recommendation", "error").Observe(time.Since(startTime).Seconds())
 		return fmt.Errorf("failed to get chunks for article %s: %w", job.ArticleID, err)
 	}
 
 	if len(chunks) == 0 {
 		log.Printf("⚠️ No chunks found for article %s", job.ArticleID)
-		return w.storeErrorResult(job.JobID, job.ArticleID, "No chunks found for source article")
+		w.publishStage(ctx, job.JobID, recostream.StageFailed)
+		metrics.JobTotalSeconds.WithLabelValues("recommendation", "error").Observe(time.Since(startTime).Seconds())
+		return w.storeErrorResult(ctx, job.JobID, job.ArticleID, "No chunks found for source article")
 	}
 
 	log.Printf("📝 Found %d chunks for article %s", len(chunks), job.ArticleID)
 
-	// 2. Create and publish chunk search jobs
-	searchIDs := make([]string, len(chunks))
-	for i, chunk := range chunks {
-		searchID := uuid.New().String()
-		searchMsg := database.ChunkSearchMessage{
-			SearchID:        searchID,
-			JobID:           job.JobID,
-			ChunkID:         chunk.ID,
-			SourceArticleID: job.ArticleID,
-			TopK:            5,
-			ScoreThreshold:  0.7,
-		}
-
-		err = mq.PublishChunkSearch(searchMsg)
-		if err != nil {
-			log.Printf("❌ Failed to publish chunk search %s: %v", searchID, err)
-			return fmt.Errorf("failed to publish search for chunk %s: %w", chunk.ID, err)
-		}
-		searchIDs[i] = searchID
+	aggregator := resolveAggregator(job.AggregationMode)
+
+	// 2. Fan out chunk searches through a bounded, cancellable coordinator
+	// and collect the results as they arrive, publishing a partial ranking
+	// snapshot every partialSnapshotFraction of results or
+	// partialSnapshotInterval, whichever comes first, so a subscriber sees
+	// top recommendations refine in near real time instead of waiting for
+	// the whole job to finish.
+	w.publishStage(ctx, job.JobID, recostream.StageSearching)
+	searchStart := time.Now()
+	onProgress := w.newProgressPublisher(ctx, job, startTime, aggregator, len(chunks))
+	results, err := w.runSearches(ctx, job.JobID, job.ArticleID, chunks, onProgress)
+	metrics.PineconeSearchSeconds.Observe(time.Since(searchStart).Seconds())
+	if err != nil {
+		log.Printf("⚠️ Search coordinator for job %s ended early: %v", job.JobID, err)
 	}
 
-	log.Printf("📤 Published %d chunk searches for job %s", len(searchIDs), job.JobID)
+	log.Printf("📥 Collected %d/%d search results for job %s", len(results), len(chunks), job.JobID)
 
-	// 3. Collect search results with timeout
-	timeout := 30 * time.Second
-	results := w.collectSearchResults(searchIDs, timeout, job.JobID)
+	// 3. Aggregate and rank by article, using whichever strategy the job asked for
+	w.publishStage(ctx, job.JobID, recostream.StageRanking)
+	recommendations := aggregator.Aggregate(results)
 
-	log.Printf("📥 Collected %d search results for job %s", len(results), job.JobID)
+	log.Printf("🏆 Generated %d recommendations for job %s", len(recommendations), job.JobID)
 
-	// 4. Aggregate and rank by article
-	recommendations := w.aggregateAndRank(results)
+	rerankStart := time.Now()
+	// 3b. Re-rank with MMR so near-duplicate articles about the same event
+	// don't crowd out everything else.
+	recommendations = applyMMR(ctx, w.embeddingRepo, recommendations, job.MMRLambda, job.ResultCount)
 
-	log.Printf("🏆 Generated %d recommendations for job %s", len(recommendations), job.JobID)
+	// 3c. Two-stage retrieval: re-score the surviving candidates against the
+	// source article with a cross-encoder, which is far more precise than
+	// ANN cosine similarity but too slow to run on every chunk match.
+	recommendations = applyReranking(ctx, w.chunkRepo, w.rerankerClient, chunks, recommendations, job.RerankWeight, job.BypassReranking)
+	metrics.RerankSeconds.Observe(time.Since(rerankStart).Seconds())
 
-	// 5. Enrich with full article data
-	finalResults, err := w.enrichWithArticleData(recommendations)
+	// 4. Enrich with full article data
+	finalResults, err := w.enrichWithArticleData(ctx, recommendations)
 	if err != nil {
 		log.Printf("❌ Failed to enrich results for job %s: %v", job.JobID, err)
-		return w.storeErrorResult(job.JobID, job.ArticleID, fmt.Sprintf("Failed to enrich results: %v", err))
+		w.publishStage(ctx, job.JobID, recostream.StageFailed)
+		metrics.JobTotalSeconds.WithLabelValues("recommendation", "error").Observe(time.Since(startTime).Seconds())
+		return w.storeErrorResult(ctx, job.JobID, job.ArticleID, fmt.Sprintf("Failed to enrich results: %v", err))
 	}
 
-	// 6. Store results and notify completion
+	// 5. Store results and notify completion
 	processingTime := time.Since(startTime)
 	result := database.RecommendationResult{
 		JobID:           job.JobID,
@@ -133,175 +181,255 @@ func (w *RAGWorker) ProcessRecommendationJob(job database.RecommendationJob) err
 		CreatedAt:       time.Now(),
 	}
 
-	err = w.storeResult(ctx, result)
-	if err != nil {
+	if err := w.storeResult(ctx, result); err != nil {
 		log.Printf("❌ Failed to store results for job %s: %v", job.JobID, err)
+		w.publishStage(ctx, job.JobID, recostream.StageFailed)
+		metrics.JobTotalSeconds.WithLabelValues("recommendation", "error").Observe(time.Since(startTime).Seconds())
 		return fmt.Errorf("failed to store results: %w", err)
 	}
 
+	if w.resultPublisher != nil {
+		if err := w.resultPublisher.Publish(ctx, result, false); err != nil {
+			log.Printf("⚠️ Failed to publish final snapshot for job %s: %v", job.JobID, err)
+		}
+	}
+	w.publishStage(ctx, job.JobID, recostream.StageCompleted)
+	metrics.JobTotalSeconds.WithLabelValues("recommendation", "success").Observe(processingTime.Seconds())
+
 	log.Printf("✅ Completed recommendation job %s in %v", job.JobID, processingTime)
 	return nil
 }
 
-func (w *RAGWorker) collectSearchResults(searchIDs []string, timeout time.Duration, jobID string) []SearchResultMessage {
-	results := make([]SearchResultMessage, 0, len(searchIDs))
-	resultMap := make(map[string]bool)
-	
-	// Initialize result map
-	for _, id := range searchIDs {
-		resultMap[id] = false
-	}
-
-	// Set up timeout
-	w.timeoutMutex.Lock()
-	timer := time.NewTimer(timeout)
-	w.timeoutMap[jobID] = &SearchTimeout{
-		SearchIDs: searchIDs,
-		Timer:     timer,
-		JobID:     jobID,
-	}
-	w.timeoutMutex.Unlock()
-
-	defer func() {
-		w.timeoutMutex.Lock()
-		delete(w.timeoutMap, jobID)
-		w.timeoutMutex.Unlock()
-		timer.Stop()
-	}()
+// publishStage is a nil-safe, best-effort wrapper around
+// resultPublisher.PublishStage: a progress event is a convenience for
+// streaming clients, not something worth failing the job over.
+func (w *RAGWorker) publishStage(ctx context.Context, jobID string, stage recostream.Stage) {
+	if w.resultPublisher == nil {
+		return
+	}
+	if err := w.resultPublisher.PublishStage(ctx, jobID, stage, ""); err != nil {
+		log.Printf("⚠️ Failed to publish stage %s for job %s: %v", stage, jobID, err)
+	}
+}
 
-	// Collect results
-	for {
-		select {
-		case result := <-w.resultChannel:
-			var searchID string
-			if result.Response != nil {
-				searchID = result.Response.SearchID
-			} else if result.Error != nil {
-				searchID = result.Error.SearchID
-			}
+// newProgressPublisher returns a runSearches progress callback that
+// re-aggregates whatever results have arrived so far and publishes them as
+// a partial snapshot, throttled to at most once per partialSnapshotFraction
+// of expected searches or partialSnapshotInterval, whichever comes first.
+func (w *RAGWorker) newProgressPublisher(ctx context.Context, job database.RecommendationJob, startTime time.Time, aggregator Aggregator, totalSearches int) func([]SearchResultMessage) {
+	if w.resultPublisher == nil {
+		return nil
+	}
 
-			// Check if this result belongs to our job
-			if found, exists := resultMap[searchID]; exists && !found {
-				results = append(results, result)
-				resultMap[searchID] = true
-
-				// Check if we have all results
-				allReceived := true
-				for _, received := range resultMap {
-					if !received {
-						allReceived = false
-						break
-					}
-				}
-				if allReceived {
-					return results
-				}
-			}
+	threshold := int(math.Ceil(float64(totalSearches) * partialSnapshotFraction))
+	if threshold < 1 {
+		threshold = 1
+	}
 
-		case <-timer.C:
-			log.Printf("⏰ Timeout collecting search results for job %s. Got %d/%d results", jobID, len(results), len(searchIDs))
-			return results
+	var publishedCount int
+	lastPublish := time.Now()
+
+	return func(partialResults []SearchResultMessage) {
+		sinceLastPublish := time.Since(lastPublish)
+		newSinceLastPublish := len(partialResults) - publishedCount
+		if newSinceLastPublish < threshold && sinceLastPublish < partialSnapshotInterval {
+			return
+		}
+
+		recommendations := aggregator.Aggregate(partialResults)
+		snapshot := database.RecommendationResult{
+			JobID:           job.JobID,
+			SourceArticleID: job.ArticleID,
+			Recommendations: recommendations,
+			TotalFound:      len(recommendations),
+			ProcessingTime:  time.Since(startTime).String(),
+			Status:          "partial",
+			CreatedAt:       time.Now(),
+		}
+
+		if err := w.resultPublisher.Publish(ctx, snapshot, true); err != nil {
+			log.Printf("⚠️ Failed to publish partial snapshot for job %s: %v", job.JobID, err)
+			return
 		}
+
+		publishedCount = len(partialResults)
+		lastPublish = time.Now()
 	}
 }
 
-func (w *RAGWorker) aggregateAndRank(results []SearchResultMessage) []database.ArticleRecommendation {
-	articleMatches := make(map[uuid.UUID][]database.ChunkMatch)
-
-	// Group results by article
-	for _, result := range results {
-		if result.Response != nil {
-			for _, searchResult := range result.Response.Results {
-				chunkMatch := database.ChunkMatch{
-					ChunkID:        uuid.MustParse(searchResult.ChunkID),
-					Score:          searchResult.SimilarityScore,
-					ChunkIndex:     searchResult.ChunkIndex,
-					ContentPreview: searchResult.ContentPreview,
-				}
-				articleMatches[searchResult.ArticleID] = append(articleMatches[searchResult.ArticleID], chunkMatch)
+// runSearches publishes one chunk_search message per chunk, capped at
+// maxConcurrentSearches outstanding at a time, and collects results from the
+// job's own coordinator channel until every search has answered, the job
+// context is cancelled, or searchTimeout elapses. Any searches still
+// outstanding when it returns are announced via search_cancel so downstream
+// workers stop processing them. onProgress, if non-nil, is called with a
+// snapshot of the results collected so far every time a new one arrives; it
+// must return quickly since it runs on the collection loop's goroutine.
+func (w *RAGWorker) runSearches(ctx context.Context, jobID string, sourceArticleID uuid.UUID, chunks []*database.ArticleChunk, onProgress func([]SearchResultMessage)) ([]SearchResultMessage, error) {
+	resultsCh := w.registerJob(jobID, len(chunks))
+	defer w.unregisterJob(jobID)
+
+	pending := make(map[string]struct{}, len(chunks))
+	var pendingMu sync.Mutex
+
+	sem := make(chan struct{}, w.maxConcurrentSearches)
+	publishDone := make(chan error, 1)
+
+	// dropped counts chunks whose search could not be published even after
+	// retrying; it shrinks the number of results the collect loop waits for
+	// so one bad chunk doesn't block the whole job until searchTimeout.
+	var dropped int32
+
+	go func() {
+		defer close(publishDone)
+		for _, chunk := range chunks {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				publishDone <- ctx.Err()
+				return
+			}
+
+			searchID := uuid.New().String()
+			w.registerSearch(jobID, searchID)
+			pendingMu.Lock()
+			pending[searchID] = struct{}{}
+			pendingMu.Unlock()
+
+			msg := database.ChunkSearchMessage{
+				SearchID:        searchID,
+				JobID:           jobID,
+				ChunkID:         chunk.ID,
+				SourceArticleID: sourceArticleID,
+				TopK:            5,
+				ScoreThreshold:  0.7,
 			}
-		}
-	}
 
-	// Calculate hybrid scores for each article
-	recommendations := make([]database.ArticleRecommendation, 0, len(articleMatches))
-	for articleID, matches := range articleMatches {
-		hybridScore := w.calculateHybridScore(matches)
-		maxSim, avgSim := w.calculateSimilarityStats(matches)
+			if err := publishChunkSearchWithRetry(msg); err != nil {
+				log.Printf("❌ Giving up publishing chunk search %s after %d attempts: %v", searchID, maxPublishRetries, err)
+				searchResultDroppedTotal.WithLabelValues("publish_failed").Inc()
 
-		recommendation := database.ArticleRecommendation{
-			ArticleID:     articleID,
-			HybridScore:   hybridScore,
-			MaxSimilarity: maxSim,
-			AvgSimilarity: avgSim,
-			ChunkMatches:  matches,
-			MatchedChunks: len(matches),
+				pendingMu.Lock()
+				delete(pending, searchID)
+				pendingMu.Unlock()
+				atomic.AddInt32(&dropped, 1)
+
+				select {
+				case <-sem:
+				default:
+				}
+				continue
+			}
 		}
-		recommendations = append(recommendations, recommendation)
-	}
+	}()
 
-	// Sort by hybrid score (descending)
-	sort.Slice(recommendations, func(i, j int) bool {
-		return recommendations[i].HybridScore > recommendations[j].HybridScore
-	})
+	timer := time.NewTimer(w.searchTimeout)
+	defer timer.Stop()
 
-	return recommendations
-}
+	results := make([]SearchResultMessage, 0, len(chunks))
+	var loopErr error
 
-func (w *RAGWorker) calculateHybridScore(articleMatches []database.ChunkMatch) float64 {
-	if len(articleMatches) == 0 {
-		return 0
-	}
+collect:
+	for len(results)+int(atomic.LoadInt32(&dropped)) < len(chunks) {
+		select {
+		case result := <-resultsCh:
+			searchID := searchIDOf(result)
+			pendingMu.Lock()
+			if _, ok := pending[searchID]; ok {
+				delete(pending, searchID)
+				results = append(results, result)
+			}
+			pendingMu.Unlock()
 
-	// Extract similarity scores
-	scores := make([]float64, len(articleMatches))
-	for i, match := range articleMatches {
-		scores[i] = match.Score
-	}
+			if onProgress != nil {
+				onProgress(append([]SearchResultMessage(nil), results...))
+			}
 
-	// Calculate components
-	maxSimilarity := slices.Max(scores)
-	avgSimilarity := w.calculateMean(scores)
-	chunkCount := float64(len(scores))
+			select {
+			case <-sem:
+			default:
+			}
 
-	// Hybrid scoring formula
-	relevanceScore := (maxSimilarity * 0.6) + (avgSimilarity * 0.4)
-	coverageBonus := math.Min(chunkCount/3.0, 0.2)
+		case err, open := <-publishDone:
+			// publishDone is set to nil once drained so this case blocks
+			// forever afterward instead of firing on every loop iteration
+			// (a closed channel is always ready to receive its zero value).
+			publishDone = nil
+			if open && err != nil {
+				loopErr = err
+				break collect
+			}
+			// Publishing finished cleanly; keep collecting remaining results.
 
-	return relevanceScore + coverageBonus
-}
+		case <-ctx.Done():
+			loopErr = ctx.Err()
+			break collect
 
-func (w *RAGWorker) calculateSimilarityStats(matches []database.ChunkMatch) (float64, float64) {
-	if len(matches) == 0 {
-		return 0, 0
+		case <-timer.C:
+			loopErr = fmt.Errorf("timed out collecting search results after %v", w.searchTimeout)
+			break collect
+		}
 	}
 
-	scores := make([]float64, len(matches))
-	for i, match := range matches {
-		scores[i] = match.Score
+	pendingMu.Lock()
+	remaining := make([]string, 0, len(pending))
+	for searchID := range pending {
+		remaining = append(remaining, searchID)
 	}
+	pendingMu.Unlock()
 
-	maxSim := slices.Max(scores)
-	avgSim := w.calculateMean(scores)
+	if len(remaining) > 0 {
+		log.Printf("⏰ Job %s abandoning %d/%d outstanding searches", jobID, len(remaining), len(chunks))
+		if err := mq.PublishSearchCancel(database.SearchCancelMessage{JobID: jobID, SearchIDs: remaining}); err != nil {
+			log.Printf("⚠️ Failed to publish search cancel for job %s: %v", jobID, err)
+		}
+	}
 
-	return maxSim, avgSim
+	return results, loopErr
 }
 
-func (w *RAGWorker) calculateMean(scores []float64) float64 {
-	if len(scores) == 0 {
-		return 0
+func searchIDOf(result SearchResultMessage) string {
+	if result.Response != nil {
+		return result.Response.SearchID
+	}
+	if result.Error != nil {
+		return result.Error.SearchID
 	}
-	
-	sum := 0.0
-	for _, score := range scores {
-		sum += score
+	return ""
+}
+
+// registerJob creates the per-job result channel, sized to the number of
+// searches the job will publish so a slow consumer never drops a result.
+func (w *RAGWorker) registerJob(jobID string, searchCount int) chan SearchResultMessage {
+	ch := make(chan SearchResultMessage, searchCount)
+	w.mu.Lock()
+	w.jobResults[jobID] = ch
+	w.mu.Unlock()
+	return ch
+}
+
+// unregisterJob removes the job's coordinator and any of its searches still
+// pending routing, so a late or duplicate result doesn't leak a map entry.
+func (w *RAGWorker) unregisterJob(jobID string) {
+	w.mu.Lock()
+	delete(w.jobResults, jobID)
+	for searchID, owner := range w.searchJobs {
+		if owner == jobID {
+			delete(w.searchJobs, searchID)
+		}
 	}
-	return sum / float64(len(scores))
+	w.mu.Unlock()
+}
+
+func (w *RAGWorker) registerSearch(jobID, searchID string) {
+	w.mu.Lock()
+	w.searchJobs[searchID] = jobID
+	w.mu.Unlock()
 }
 
-func (w *RAGWorker) enrichWithArticleData(recommendations []database.ArticleRecommendation) ([]database.ArticleRecommendation, error) {
+func (w *RAGWorker) enrichWithArticleData(ctx context.Context, recommendations []database.ArticleRecommendation) ([]database.ArticleRecommendation, error) {
 	for i := range recommendations {
-		article, err := w.articleRepo.GetByID(context.Background(), recommendations[i].ArticleID)
+		article, err := w.articleRepo.GetByID(ctx, recommendations[i].ArticleID)
 		if err != nil {
 			log.Printf("⚠️ Failed to get article %s: %v", recommendations[i].ArticleID, err)
 			continue
@@ -332,8 +460,7 @@ func (w *RAGWorker) storeResult(ctx context.Context, result database.Recommendat
 	return nil
 }
 
-func (w *RAGWorker) storeErrorResult(jobID string, articleID uuid.UUID, errorMsg string) error {
-	ctx := context.Background()
+func (w *RAGWorker) storeErrorResult(ctx context.Context, jobID string, articleID uuid.UUID, errorMsg string) error {
 	result := database.RecommendationResult{
 		JobID:           jobID,
 		SourceArticleID: articleID,
@@ -378,30 +505,116 @@ func (w *RAGWorker) startSearchResultsConsumer() {
 
 	for d := range msgs {
 		var resultMsg SearchResultMessage
+		var searchID string
 
 		// Try to parse as response first
 		var response database.ChunkSearchResponse
 		if err := json.Unmarshal(d.Body, &response); err == nil && response.SearchID != "" {
 			resultMsg.Response = &response
+			searchID = response.SearchID
 		} else {
 			// Try to parse as error
 			var errorResp database.ChunkSearchError
 			if err := json.Unmarshal(d.Body, &errorResp); err == nil && errorResp.SearchID != "" {
 				resultMsg.Error = &errorResp
+				searchID = errorResp.SearchID
 			} else {
 				log.Printf("❌ Failed to parse search result message: %v", err)
-				d.Nack(false, false)
+				w.retryOrDeadLetter(d, "unparseable")
 				continue
 			}
 		}
 
-		// Send to result channel (non-blocking)
+		// Route to the owning job's coordinator rather than a single shared
+		// channel, so one slow job can't starve or drop another's results.
+		w.mu.Lock()
+		jobID, ok := w.searchJobs[searchID]
+		var resultsCh chan SearchResultMessage
+		if ok {
+			resultsCh = w.jobResults[jobID]
+		}
+		w.mu.Unlock()
+
+		if resultsCh == nil {
+			log.Printf("⚠️ No active coordinator for search %s; job may have already timed out or completed", searchID)
+			d.Ack(false)
+			continue
+		}
+
 		select {
-		case w.resultChannel <- resultMsg:
+		case resultsCh <- resultMsg:
 		default:
-			log.Printf("⚠️ Result channel is full, dropping message")
+			log.Printf("⚠️ Result channel for job %s is full, dropping message", jobID)
+			searchResultDroppedTotal.WithLabelValues("channel_full").Inc()
 		}
 
 		d.Ack(false)
 	}
-}
\ No newline at end of file
+}
+
+// maxSearchResultRetries bounds how many times a malformed search_results
+// message is retried (via search_results.retry's TTL + dead-letter back into
+// search_results) before it's routed to search_results.dlq for good.
+const maxSearchResultRetries = 5
+
+// retryOrDeadLetter republishes a failed search_results message to the retry
+// queue with its x-retry-count header incremented, or to the DLQ once that
+// count exceeds maxSearchResultRetries. The original delivery is acked
+// either way since a copy now lives in the retry/DLQ queue.
+func (w *RAGWorker) retryOrDeadLetter(d amqp.Delivery, reason string) {
+	retryCount := retryCountOf(d.Headers) + 1
+
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers["x-retry-count"] = retryCount
+
+	publishing := amqp.Publishing{
+		ContentType:  d.ContentType,
+		Body:         d.Body,
+		DeliveryMode: amqp.Persistent,
+		Headers:      headers,
+	}
+
+	if retryCount > maxSearchResultRetries {
+		if err := w.channel.Publish("", "search_results.dlq", false, false, publishing); err != nil {
+			log.Printf("❌ Failed to dead-letter search result message: %v", err)
+			searchResultDroppedTotal.WithLabelValues(reason).Inc()
+			d.Nack(false, false)
+			return
+		}
+		log.Printf("🪦 Search result message exceeded %d retries (%s); sent to DLQ", maxSearchResultRetries, reason)
+		searchResultDLQTotal.Inc()
+		d.Ack(false)
+		return
+	}
+
+	if err := w.channel.Publish("", "search_results.retry", false, false, publishing); err != nil {
+		log.Printf("❌ Failed to enqueue search result message for retry: %v", err)
+		searchResultDroppedTotal.WithLabelValues(reason).Inc()
+		d.Nack(false, false)
+		return
+	}
+
+	searchResultRetriesTotal.Inc()
+	d.Ack(false)
+}
+
+// retryCountOf reads the x-retry-count header, defaulting to 0 for a
+// message seeing the retry path for the first time.
+func retryCountOf(headers amqp.Table) int {
+	if headers == nil {
+		return 0
+	}
+	switch v := headers["x-retry-count"].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"rec-mind/internal/database"
+	"rec-mind/internal/reranker"
+	"rec-mind/internal/repository"
+)
+
+// defaultRerankCandidateLimit bounds how many top-ranked candidates get the
+// (comparatively expensive) cross-encoder treatment; a candidate ranked
+// below this is left to its aggregate score alone.
+const defaultRerankCandidateLimit = 50
+
+// applyReranking is the second stage of two-stage retrieval: the ANN chunk
+// search has already produced a cheaply-ranked candidate set, so spend the
+// cross-encoder pass only on the top defaultRerankCandidateLimit candidates,
+// blending its score into each candidate's HybridScore by weight and
+// re-sorting. If bypass is set, client is nil, or the reranker call fails,
+// the input is returned unchanged so a reranker outage degrades to
+// ANN-only ranking instead of failing the job.
+func applyReranking(ctx context.Context, chunkRepo repository.ArticleChunkRepository, client reranker.RerankerClient, sourceChunks []*database.ArticleChunk, recommendations []database.ArticleRecommendation, weight float64, bypass bool) []database.ArticleRecommendation {
+	if bypass || client == nil || len(recommendations) == 0 {
+		return recommendations
+	}
+	if weight <= 0 {
+		weight = database.DefaultRerankWeight
+	}
+
+	limit := defaultRerankCandidateLimit
+	if limit > len(recommendations) {
+		limit = len(recommendations)
+	}
+
+	sourceTexts := make([]string, len(sourceChunks))
+	for i, chunk := range sourceChunks {
+		sourceTexts[i] = chunk.Content
+	}
+
+	candidates := make([]reranker.RerankCandidate, 0, limit)
+	for _, rec := range recommendations[:limit] {
+		chunks, err := chunkRepo.GetByArticleID(ctx, rec.ArticleID)
+		if err != nil || len(chunks) == 0 {
+			continue
+		}
+		texts := make([]string, len(chunks))
+		for i, chunk := range chunks {
+			texts[i] = chunk.Content
+		}
+		candidates = append(candidates, reranker.RerankCandidate{ArticleID: rec.ArticleID, Texts: texts})
+	}
+
+	if len(candidates) == 0 {
+		return recommendations
+	}
+
+	scores, err := client.Rerank(ctx, reranker.RerankRequest{SourceTexts: sourceTexts, Candidates: candidates})
+	if err != nil {
+		log.Printf("⚠️ Reranker: cross-encoder scoring failed, falling back to HybridScore: %v", err)
+		return recommendations
+	}
+
+	scoreByArticle := make(map[uuid.UUID]float64, len(scores))
+	for _, s := range scores {
+		scoreByArticle[s.ArticleID] = s.Score
+	}
+
+	blended := make([]database.ArticleRecommendation, len(recommendations))
+	copy(blended, recommendations)
+	for i, rec := range blended {
+		if crossScore, ok := scoreByArticle[rec.ArticleID]; ok {
+			blended[i].HybridScore = weight*crossScore + (1-weight)*rec.HybridScore
+		}
+	}
+
+	sort.SliceStable(blended, func(i, j int) bool {
+		return blended[i].HybridScore > blended[j].HybridScore
+	})
+
+	return blended
+}
@@ -0,0 +1,142 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rec-mind/internal/database"
+	"rec-mind/internal/reranker"
+	"rec-mind/models"
+)
+
+// fakeChunkRepo returns canned chunks per article for tests, without
+// touching Postgres. Only GetByArticleID is exercised by applyReranking;
+// the rest satisfy repository.ArticleChunkRepository.
+type fakeChunkRepo struct {
+	chunksByArticle map[uuid.UUID][]*models.ArticleChunk
+	err             error
+}
+
+func (f *fakeChunkRepo) Create(ctx context.Context, req *models.CreateArticleChunkRequest) (*models.ArticleChunk, error) {
+	return nil, nil
+}
+func (f *fakeChunkRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.ArticleChunk, error) {
+	return nil, nil
+}
+func (f *fakeChunkRepo) GetByArticleID(ctx context.Context, articleID uuid.UUID) ([]*models.ArticleChunk, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.chunksByArticle[articleID], nil
+}
+func (f *fakeChunkRepo) GetByArticleIDAndIndex(ctx context.Context, articleID uuid.UUID, chunkIndex int) (*models.ArticleChunk, error) {
+	return nil, nil
+}
+func (f *fakeChunkRepo) GetByContentHash(ctx context.Context, hash string) (*models.ArticleChunk, error) {
+	return nil, nil
+}
+func (f *fakeChunkRepo) List(ctx context.Context, filter *models.ArticleChunkFilter) ([]*models.ArticleChunk, error) {
+	return nil, nil
+}
+func (f *fakeChunkRepo) Update(ctx context.Context, id uuid.UUID, req *models.UpdateArticleChunkRequest) (*models.ArticleChunk, error) {
+	return nil, nil
+}
+func (f *fakeChunkRepo) Delete(ctx context.Context, id uuid.UUID) error { return nil }
+func (f *fakeChunkRepo) DeleteByArticleID(ctx context.Context, articleID uuid.UUID) error {
+	return nil
+}
+func (f *fakeChunkRepo) Count(ctx context.Context, filter *models.ArticleChunkFilter) (int64, error) {
+	return 0, nil
+}
+func (f *fakeChunkRepo) CreateBatch(ctx context.Context, chunks []*models.CreateArticleChunkRequest) ([]*models.ArticleChunk, error) {
+	return nil, nil
+}
+func (f *fakeChunkRepo) UpsertEmbedding(ctx context.Context, id uuid.UUID, embedding []float32) error {
+	return nil
+}
+func (f *fakeChunkRepo) CreateBatchWithEmbeddings(ctx context.Context, chunks []*models.CreateArticleChunkRequest, embeddings [][]float32) ([]*models.ArticleChunk, error) {
+	return nil, nil
+}
+func (f *fakeChunkRepo) SearchByEmbedding(ctx context.Context, embedding []float32, filter *models.ArticleChunkSearchFilter) ([]*models.ArticleChunkMatch, error) {
+	return nil, nil
+}
+func (f *fakeChunkRepo) SearchByEmbeddingWithinArticles(ctx context.Context, embedding []float32, articleIDs []uuid.UUID, filter *models.ArticleChunkSearchFilter) ([]*models.ArticleChunkMatch, error) {
+	return nil, nil
+}
+func (f *fakeChunkRepo) SearchHybrid(ctx context.Context, embedding []float32, query string, filter *models.ArticleChunkSearchFilter) ([]*models.ArticleChunkMatch, error) {
+	return nil, nil
+}
+
+// fakeRerankerClient returns canned scores for tests, without making an
+// HTTP call.
+type fakeRerankerClient struct {
+	scores []reranker.RerankScore
+	err    error
+}
+
+func (f *fakeRerankerClient) Rerank(ctx context.Context, req reranker.RerankRequest) ([]reranker.RerankScore, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.scores, nil
+}
+
+func chunkFor(articleID uuid.UUID, content string) *models.ArticleChunk {
+	return &models.ArticleChunk{ArticleID: articleID, Content: content}
+}
+
+func TestApplyReranking_BlendsCrossEncoderScoreAndResorts(t *testing.T) {
+	low, high := uuid.New(), uuid.New()
+	recommendations := []database.ArticleRecommendation{
+		recommendation(low, 0.9),
+		recommendation(high, 0.1),
+	}
+
+	chunkRepo := &fakeChunkRepo{chunksByArticle: map[uuid.UUID][]*models.ArticleChunk{
+		low:  {chunkFor(low, "low relevance content")},
+		high: {chunkFor(high, "high relevance content")},
+	}}
+	client := &fakeRerankerClient{scores: []reranker.RerankScore{
+		{ArticleID: low, Score: 0.0},
+		{ArticleID: high, Score: 1.0},
+	}}
+
+	result := applyReranking(context.Background(), chunkRepo, client, []*models.ArticleChunk{chunkFor(uuid.New(), "source")}, recommendations, 1.0, false)
+
+	require.Len(t, result, 2)
+	assert.Equal(t, high, result[0].ArticleID, "cross-encoder score of 1.0 should outrank a 0.9 HybridScore once weight=1")
+}
+
+func TestApplyReranking_BypassReturnsInputUnchanged(t *testing.T) {
+	recommendations := []database.ArticleRecommendation{recommendation(uuid.New(), 0.5)}
+	client := &fakeRerankerClient{scores: []reranker.RerankScore{{Score: 1.0}}}
+
+	result := applyReranking(context.Background(), &fakeChunkRepo{}, client, nil, recommendations, 0.5, true)
+
+	assert.Equal(t, recommendations, result)
+}
+
+func TestApplyReranking_FallsBackWhenClientErrors(t *testing.T) {
+	articleID := uuid.New()
+	recommendations := []database.ArticleRecommendation{recommendation(articleID, 0.5)}
+	chunkRepo := &fakeChunkRepo{chunksByArticle: map[uuid.UUID][]*models.ArticleChunk{
+		articleID: {chunkFor(articleID, "content")},
+	}}
+	client := &fakeRerankerClient{err: assert.AnError}
+
+	result := applyReranking(context.Background(), chunkRepo, client, nil, recommendations, 0.5, false)
+
+	assert.Equal(t, recommendations, result)
+}
+
+func TestApplyReranking_NilClientReturnsInputUnchanged(t *testing.T) {
+	recommendations := []database.ArticleRecommendation{recommendation(uuid.New(), 0.5)}
+
+	result := applyReranking(context.Background(), &fakeChunkRepo{}, nil, nil, recommendations, 0.5, false)
+
+	assert.Equal(t, recommendations, result)
+}
@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// DefaultTenantConcurrencyLimit bounds how many query search jobs a single
+// tenant may have in flight at once, so one noisy TenantID can't monopolize
+// QueryWorkerPool's shared concurrency budget and starve everyone else.
+const DefaultTenantConcurrencyLimit = 5
+
+// tenantSemaphoreTTL bounds how long a held slot survives without a
+// matching Release, so a crashed worker never permanently strands a tenant
+// at its cap - the key simply expires and the count resets to zero.
+const tenantSemaphoreTTL = 5 * time.Minute
+
+// TenantSemaphore caps concurrent query search jobs per tenant using a
+// Redis INCR-based counter, so the cap holds across every QueryWorkerPool
+// instance in the fleet rather than just the process that happens to
+// receive a given tenant's jobs.
+type TenantSemaphore struct {
+	redis *goredis.Client
+	limit int
+}
+
+// NewTenantSemaphore creates a TenantSemaphore capping each tenant at
+// limit concurrent jobs; limit <= 0 falls back to
+// DefaultTenantConcurrencyLimit.
+func NewTenantSemaphore(redisClient *goredis.Client, limit int) *TenantSemaphore {
+	if limit <= 0 {
+		limit = DefaultTenantConcurrencyLimit
+	}
+	return &TenantSemaphore{redis: redisClient, limit: limit}
+}
+
+func (s *TenantSemaphore) key(tenantID string) string {
+	return fmt.Sprintf("tenant_sem:%s", tenantID)
+}
+
+// Acquire increments tenantID's in-flight counter and reports whether it
+// was at or under the limit after incrementing. A false result means the
+// caller must not proceed and must not call Release. An empty tenantID
+// always succeeds, since there's no tenant to rate-limit against.
+func (s *TenantSemaphore) Acquire(ctx context.Context, tenantID string) (bool, error) {
+	if tenantID == "" {
+		return true, nil
+	}
+
+	key := s.key(tenantID)
+	count, err := s.redis.Incr(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to increment tenant semaphore for %s: %w", tenantID, err)
+	}
+	if count == 1 {
+		s.redis.Expire(ctx, key, tenantSemaphoreTTL)
+	}
+	if count > int64(s.limit) {
+		s.redis.Decr(ctx, key)
+		return false, nil
+	}
+	return true, nil
+}
+
+// Release decrements tenantID's in-flight counter. Must be called exactly
+// once for every Acquire that returned true; a failure is logged rather
+// than returned since the job it's releasing for has already finished.
+func (s *TenantSemaphore) Release(ctx context.Context, tenantID string) {
+	if tenantID == "" {
+		return
+	}
+	if err := s.redis.Decr(ctx, s.key(tenantID)).Err(); err != nil {
+		ragWorkerLog.Error("failed to release tenant semaphore", "tenant_id", tenantID, "error", err)
+	}
+}
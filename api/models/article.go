@@ -0,0 +1,119 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Article is the core domain row backing the articles table.
+type Article struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	Title       string     `json:"title" db:"title"`
+	Content     string     `json:"content" db:"content"`
+	URL         string     `json:"url" db:"url"`
+	Category    string     `json:"category" db:"category"`
+	PublishedAt *time.Time `json:"published_at,omitempty" db:"published_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	ContentHash string     `json:"-" db:"content_hash"`
+	SimHash     uint64     `json:"-" db:"simhash"`
+	// DeletedAt is set once Delete soft-deletes the row; nil means the
+	// article is live. GetByID, GetByIDs, GetByCategory, GetByCategorySlug,
+	// GetRecent and List/Count filter WHERE deleted_at IS NULL (List/Count
+	// unless the caller opts in via ArticleFilter.IncludeDeleted); lookups
+	// by URL, content hash or SimHash do not.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Duplicate is set (not persisted) when Create reused an existing row
+	// instead of inserting a new one because its content hash already
+	// existed, so callers can skip re-embedding that article.
+	Duplicate bool `json:"duplicate,omitempty" db:"-"`
+}
+
+// ToResponse renders an Article as the API's JSON shape.
+func (a *Article) ToResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"id":           a.ID,
+		"title":        a.Title,
+		"content":      a.Content,
+		"url":          a.URL,
+		"category":     a.Category,
+		"published_at": a.PublishedAt,
+		"created_at":   a.CreatedAt,
+		"updated_at":   a.UpdatedAt,
+		"duplicate":    a.Duplicate,
+	}
+}
+
+// CreateArticleRequest is the body for creating an article.
+type CreateArticleRequest struct {
+	Title       string     `json:"title" binding:"required"`
+	Content     string     `json:"content" binding:"required"`
+	URL         string     `json:"url" binding:"required"`
+	Category    string     `json:"category" binding:"required"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+// UpdateArticleRequest is the body for a partial article update; nil
+// fields are left unchanged.
+type UpdateArticleRequest struct {
+	Title       *string    `json:"title,omitempty"`
+	Content     *string    `json:"content,omitempty"`
+	URL         *string    `json:"url,omitempty"`
+	Category    *string    `json:"category,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+// ArticleSortBy is the column List/Count order by, restricted to this enum
+// so a caller-supplied sort can't be concatenated into the query directly.
+type ArticleSortBy string
+
+const (
+	SortByPublishedAt ArticleSortBy = "published_at"
+	SortByCreatedAt   ArticleSortBy = "created_at"
+	SortByTitle       ArticleSortBy = "title"
+)
+
+// ArticleSortDir is the order by direction.
+type ArticleSortDir string
+
+const (
+	SortAsc  ArticleSortDir = "asc"
+	SortDesc ArticleSortDir = "desc"
+)
+
+// ArticleFilter scopes List/Count over articles by category, publish-date
+// range, and a title/content search term.
+type ArticleFilter struct {
+	Category   *string    `form:"category"`
+	StartDate  *time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate    *time.Time `form:"end_date" time_format:"2006-01-02"`
+	SearchTerm *string    `form:"search"`
+	// IncludeDeleted includes soft-deleted articles (deleted_at IS NOT
+	// NULL) that are otherwise excluded by default.
+	IncludeDeleted bool `form:"include_deleted"`
+	// SortBy/SortDir default to published_at/desc (the historical List
+	// order) when left unset.
+	SortBy  ArticleSortBy  `form:"sort_by"`
+	SortDir ArticleSortDir `form:"sort_dir"`
+	Limit   int            `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset  int            `form:"offset" binding:"omitempty,min=0"`
+}
+
+// SetDefaults fills in Limit/Offset/SortBy/SortDir when the caller left
+// them unset.
+func (f *ArticleFilter) SetDefaults() {
+	if f.Limit == 0 {
+		f.Limit = 20
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+	if f.SortBy == "" {
+		f.SortBy = SortByPublishedAt
+	}
+	if f.SortDir == "" {
+		f.SortDir = SortDesc
+	}
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category gives articles.category_id referential integrity and a stable
+// slug for routing (/categories/:slug), instead of matching on the free-form
+// category string that articles.category still carries for backward
+// compatibility during the migration.
+type Category struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Slug        string    `json:"slug" db:"slug"`
+	Description string    `json:"description,omitempty" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateCategoryRequest is the body for creating a category. Slug is
+// derived from Name via Slugify when left blank.
+type CreateCategoryRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Slug        string `json:"slug,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// UpdateCategoryRequest is the body for a partial category update; nil
+// fields are left unchanged. Changing Name or Slug is what triggers a
+// category.renamed event, since it's what downstream recommendation models
+// key on.
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Slug        *string `json:"slug,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// CategoryFilter paginates List.
+type CategoryFilter struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// SetDefaults fills in Limit/Offset when the caller left them unset.
+func (f *CategoryFilter) SetDefaults() {
+	if f.Limit == 0 {
+		f.Limit = 20
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+}
+
+var (
+	slugNonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+	slugTrimDashes      = regexp.MustCompile(`^-+|-+$`)
+)
+
+// Slugify lowercases name and collapses every run of non-alphanumeric
+// characters into a single hyphen, for deriving a Category's slug when the
+// caller doesn't supply one explicitly.
+func Slugify(name string) string {
+	slug := slugNonAlphanumeric.ReplaceAllString(strings.ToLower(name), "-")
+	return slugTrimDashes.ReplaceAllString(slug, "")
+}
+
+// CategoryCreatedEvent is published on recmind.events (routing key
+// "category.created") when categoryRepository.Create commits a new row.
+type CategoryCreatedEvent struct {
+	CategoryID string `json:"category_id"`
+	Slug       string `json:"slug"`
+	Name       string `json:"name"`
+}
+
+// CategoryRenamedEvent is published on recmind.events (routing key
+// "category.renamed") when categoryRepository.Update changes a category's
+// Name or Slug, so downstream recommendation models can re-key their
+// category features without a full re-embed.
+type CategoryRenamedEvent struct {
+	CategoryID string `json:"category_id"`
+	OldSlug    string `json:"old_slug"`
+	NewSlug    string `json:"new_slug"`
+	Name       string `json:"name"`
+}
+
+// ToResponse renders a Category as the API's JSON shape.
+func (c *Category) ToResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          c.ID,
+		"name":        c.Name,
+		"slug":        c.Slug,
+		"description": c.Description,
+		"created_at":  c.CreatedAt,
+	}
+}
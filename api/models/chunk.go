@@ -14,7 +14,14 @@ type ArticleChunk struct {
 	TokenCount     *int      `json:"token_count" db:"token_count"`
 	CharacterCount *int      `json:"character_count" db:"character_count"`
 	PineconeID     *string   `json:"pinecone_id" db:"pinecone_id"`
+	ContentHash    string    `json:"-" db:"content_hash"`
+	Embedding      []float32 `json:"-" db:"embedding"`
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+
+	// Duplicate is set (not persisted) when Create/CreateBatch reused an
+	// existing row instead of inserting a new one because its content hash
+	// already existed, so callers can skip re-embedding that chunk.
+	Duplicate bool `json:"duplicate,omitempty" db:"-"`
 }
 
 type CreateArticleChunkRequest struct {
@@ -49,6 +56,7 @@ func (a *ArticleChunk) ToResponse() map[string]interface{} {
 		"character_count": a.CharacterCount,
 		"pinecone_id":     a.PineconeID,
 		"created_at":      a.CreatedAt,
+		"duplicate":       a.Duplicate,
 	}
 }
 
@@ -59,4 +67,49 @@ func (f *ArticleChunkFilter) SetDefaults() {
 	if f.Offset < 0 {
 		f.Offset = 0
 	}
+}
+
+// DefaultSearchTopK and DefaultHybridSearchAlpha are used when an
+// ArticleChunkSearchFilter doesn't set TopK/Alpha explicitly.
+const (
+	DefaultSearchTopK        = 10
+	DefaultHybridSearchAlpha = 0.7
+)
+
+// ArticleChunkSearchFilter bounds and scopes a pgvector similarity search
+// over article_chunks.
+type ArticleChunkSearchFilter struct {
+	// TopK caps how many matches are returned. Zero value defaults to
+	// DefaultSearchTopK.
+	TopK int `json:"top_k,omitempty"`
+	// ScoreThreshold drops matches below this cosine similarity (or, for
+	// SearchHybrid, blended score).
+	ScoreThreshold float64 `json:"score_threshold,omitempty"`
+	// ExcludeArticleID omits chunks belonging to this article, so a
+	// recommendation search doesn't recommend the article it started from.
+	ExcludeArticleID *uuid.UUID `json:"exclude_article_id,omitempty"`
+	// Alpha weighs cosine similarity against full-text rank in SearchHybrid:
+	// 1 is pure cosine, 0 is pure ts_rank. Zero value defaults to
+	// DefaultHybridSearchAlpha.
+	Alpha float64 `json:"alpha,omitempty"`
+}
+
+func (f *ArticleChunkSearchFilter) SetDefaults() {
+	if f.TopK <= 0 {
+		f.TopK = DefaultSearchTopK
+	}
+	if f.Alpha <= 0 {
+		f.Alpha = DefaultHybridSearchAlpha
+	}
+}
+
+// ArticleChunkMatch is one chunk-level hit from a pgvector similarity
+// search, with enough article context for a caller to aggregate matches by
+// article without a second round trip.
+type ArticleChunkMatch struct {
+	ChunkID        uuid.UUID `json:"chunk_id"`
+	ArticleID      uuid.UUID `json:"article_id"`
+	ChunkIndex     int       `json:"chunk_index"`
+	ContentPreview string    `json:"content_preview"`
+	Score          float64   `json:"score"`
 }
\ No newline at end of file
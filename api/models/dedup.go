@@ -0,0 +1,109 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"strings"
+)
+
+var collapseWhitespace = regexp.MustCompile(`\s+`)
+
+// NormalizeContent collapses runs of whitespace and lowercases content so
+// near-identical copies (re-flowed paragraphs, different casing) hash to the
+// same value instead of being treated as distinct content.
+func NormalizeContent(content string) string {
+	return collapseWhitespace.ReplaceAllString(strings.ToLower(strings.TrimSpace(content)), " ")
+}
+
+// ContentHash returns the hex-encoded SHA-256 of the normalized content. It
+// is the uniqueness key behind content-addressable article deduplication.
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(NormalizeContent(content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// ChunkContentHash scopes a chunk's hash to its owning article, since
+// identical chunk text appearing in two different articles should not be
+// treated as a collision.
+func ChunkContentHash(articleID, content string) string {
+	sum := sha256.Sum256([]byte(articleID + ":" + NormalizeContent(content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// shingleSize is the number of consecutive words per shingle fed into
+// SimHash.
+const shingleSize = 4
+
+// SimHash computes a 64-bit locality-sensitive hash of content: unlike
+// ContentHash's all-or-nothing SHA-256 comparison, near-duplicate text
+// (re-flowed paragraphs, a syndicated story with minor edits) hashes to a
+// value only a small Hamming distance away from the original.
+//
+// It tokenizes the normalized content into word shingles, hashes each
+// shingle with FNV-64, and sums a +1/-1 contribution per bit across every
+// shingle; the final bit is 1 wherever that column's sum is positive.
+func SimHash(content string) uint64 {
+	words := strings.Fields(NormalizeContent(content))
+	if len(words) == 0 {
+		return 0
+	}
+
+	var bitSums [64]int
+	for _, shingle := range shingles(words, shingleSize) {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(shingle))
+		sum := h.Sum64()
+
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<uint(bit)) != 0 {
+				bitSums[bit]++
+			} else {
+				bitSums[bit]--
+			}
+		}
+	}
+
+	var result uint64
+	for bit := 0; bit < 64; bit++ {
+		if bitSums[bit] > 0 {
+			result |= 1 << uint(bit)
+		}
+	}
+	return result
+}
+
+// shingles slices words into overlapping windows of n words each, joined
+// back into strings for hashing. Content shorter than n words becomes a
+// single shingle of everything it has.
+func shingles(words []string, n int) []string {
+	if len(words) < n {
+		return []string{strings.Join(words, " ")}
+	}
+
+	out := make([]string, 0, len(words)-n+1)
+	for i := 0; i+n <= len(words); i++ {
+		out = append(out, strings.Join(words[i:i+n], " "))
+	}
+	return out
+}
+
+// HammingDistance counts the differing bits between two SimHash values.
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// SimHashBands splits a 64-bit SimHash into four 16-bit bands for banded
+// lookup. Two values within a small Hamming distance can't differ in
+// every band at once, so indexing each band lets FindNearDuplicates find
+// candidates with an index lookup instead of scanning every row.
+func SimHashBands(simhash uint64) [4]uint16 {
+	return [4]uint16{
+		uint16(simhash),
+		uint16(simhash >> 16),
+		uint16(simhash >> 32),
+		uint16(simhash >> 48),
+	}
+}
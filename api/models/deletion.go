@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// DeleteArticlesBulkRequest is the body for a bulk article deletion. Either
+// ArticleIDs or Filter should be set; Filter reuses ArticleFilter's
+// category/date-range/search-term conditions used elsewhere for listing, so
+// "delete everything matching this filter" and "list everything matching
+// this filter" stay in sync. The dry_run query parameter, not this body,
+// reports counts without deleting anything.
+type DeleteArticlesBulkRequest struct {
+	ArticleIDs []string       `json:"article_ids,omitempty"`
+	Filter     *ArticleFilter `json:"filter,omitempty"`
+}
+
+// DeleteArticlesBulkResponse reports what was (or, for a dry run, would be)
+// removed across each store.
+type DeleteArticlesBulkResponse struct {
+	JobID           string `json:"job_id"`
+	DryRun          bool   `json:"dry_run"`
+	ArticlesMatched int    `json:"articles_matched"`
+	ChunksMatched   int    `json:"chunks_matched"`
+	Idempotent      bool   `json:"idempotent,omitempty"`
+}
+
+// ArticleDeletionJob is published to the article_deletion_jobs queue so a
+// worker can cascade-delete chunks, invalidate Redis keys, and issue vector
+// deletes to the Python service outside the request/response cycle.
+type ArticleDeletionJob struct {
+	JobID          string    `json:"job_id"`
+	ArticleIDs     []string  `json:"article_ids"`
+	IdempotencyKey string    `json:"idempotency_key,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ArticleDeletedEvent is published on the recmind.events topic exchange
+// (routing key "article.deleted") when articleRepository.Delete
+// soft-deletes a single article, so the chunk/embedding worker can purge
+// that article's vectors from pgvector and its document from any secondary
+// index without those stores still reporting it in recommendations.
+type ArticleDeletedEvent struct {
+	ArticleID string    `json:"article_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// ArticleCreatedEvent is published on the recmind.events topic exchange
+// (routing key "article.created") after articleRepository.Create persists a
+// new, non-duplicate article, so ArticleEventsWorker can enqueue its
+// embedding job the same way CreateArticleWithAsyncEmbedding does, without
+// the publishing scraper having to know about embeddings at all.
+type ArticleCreatedEvent struct {
+	ArticleID string    `json:"article_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ArticleUpdatedEvent is published on the recmind.events topic exchange
+// (routing key "article.updated") when an article's content changes in a
+// way that invalidates its existing embedding, so ArticleEventsWorker can
+// re-enqueue embedding generation for it.
+type ArticleUpdatedEvent struct {
+	ArticleID string    `json:"article_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ArticleRescrapeEvent is published on the recmind.events topic exchange
+// (routing key "article.rescrape") to ask the scraper to re-fetch an
+// already-ingested article's source URL, e.g. when a feed reports new
+// content at a URL recmind already has an article for.
+type ArticleRescrapeEvent struct {
+	ArticleID string `json:"article_id"`
+	SourceURL string `json:"source_url"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// FeedConfig is one RSS feed's dynamic configuration, persisted in Redis
+// under feeds:<slug> instead of config.ScraperConfig's static feed list, so
+// adding, disabling, or re-scheduling a source doesn't require a redeploy.
+// Conditional-GET state (ETag, Last-Modified, backoff) stays owned by
+// FeedStateRepository - scrapeFeedConcurrent already tracks that per URL -
+// rather than duplicating it here.
+type FeedConfig struct {
+	Slug         string    `json:"slug"`
+	Name         string    `json:"name"`
+	URL          string    `json:"url"`
+	Category     string    `json:"category"`
+	Enabled      bool      `json:"enabled"`
+	PollInterval int       `json:"poll_interval_seconds"`
+	MaxArticles  int       `json:"max_articles,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// DefaultFeedPollInterval is used when a CreateFeedRequest/UpdateFeedRequest
+// leaves PollInterval unset.
+const DefaultFeedPollInterval = 300
+
+// CreateFeedRequest is the body for registering a new RSS feed. Slug is
+// derived from Name via Slugify when left blank, the same convention
+// CreateCategoryRequest uses.
+type CreateFeedRequest struct {
+	Name         string `json:"name" binding:"required"`
+	Slug         string `json:"slug,omitempty"`
+	URL          string `json:"url" binding:"required"`
+	Category     string `json:"category" binding:"required"`
+	Enabled      *bool  `json:"enabled,omitempty"`
+	PollInterval int    `json:"poll_interval_seconds,omitempty"`
+	MaxArticles  int    `json:"max_articles,omitempty"`
+}
+
+// UpdateFeedRequest is the body for a partial feed update; nil fields are
+// left unchanged.
+type UpdateFeedRequest struct {
+	Name         *string `json:"name,omitempty"`
+	URL          *string `json:"url,omitempty"`
+	Category     *string `json:"category,omitempty"`
+	Enabled      *bool   `json:"enabled,omitempty"`
+	PollInterval *int    `json:"poll_interval_seconds,omitempty"`
+	MaxArticles  *int    `json:"max_articles,omitempty"`
+}
@@ -12,8 +12,56 @@ type RecommendationJob struct {
 	SessionID     string    `json:"session_id"`
 	CreatedAt     time.Time `json:"created_at"`
 	CorrelationID string    `json:"correlation_id"`
+
+	// AggregationMode selects how the job's chunk-search results are
+	// combined into article rankings. Empty defaults to AggregationHybrid.
+	AggregationMode AggregationMode `json:"aggregation_mode,omitempty"`
+
+	// MMRLambda trades relevance against diversity when re-ranking
+	// recommendations: 1 ranks purely by score, 0 ranks purely to minimize
+	// redundancy with articles already selected. Zero value defaults to
+	// DefaultMMRLambda.
+	MMRLambda float64 `json:"mmr_lambda,omitempty"`
+	// ResultCount caps how many recommendations the MMR pass keeps. Zero
+	// value defaults to DefaultMMRResultCount.
+	ResultCount int `json:"result_count,omitempty"`
+
+	// BypassReranking skips the cross-encoder re-ranking stage, trading
+	// precision for latency when a caller needs the fastest possible
+	// response.
+	BypassReranking bool `json:"bypass_reranking,omitempty"`
+	// RerankWeight blends the cross-encoder relevance score with the
+	// existing HybridScore: 1 ranks purely by the cross-encoder, 0 leaves
+	// HybridScore untouched. Zero value defaults to DefaultRerankWeight.
+	RerankWeight float64 `json:"rerank_weight,omitempty"`
 }
 
+// AggregationMode selects the strategy RAGWorker uses to combine per-chunk
+// search results into article-level rankings, so callers can A/B test
+// ranking strategies without a code change.
+type AggregationMode string
+
+const (
+	// AggregationHybrid is the weighted max/avg-similarity-plus-coverage-bonus
+	// scorer.
+	AggregationHybrid AggregationMode = "hybrid"
+	// AggregationRRF is Reciprocal Rank Fusion: it rewards articles that
+	// place well across many chunk searches over one-hit-wonders with a
+	// single very high similarity score.
+	AggregationRRF AggregationMode = "rrf"
+)
+
+// DefaultMMRLambda and DefaultMMRResultCount are used when a
+// RecommendationJob doesn't set MMRLambda/ResultCount explicitly.
+const (
+	DefaultMMRLambda      = 0.7
+	DefaultMMRResultCount = 10
+)
+
+// DefaultRerankWeight is used when a RecommendationJob doesn't set
+// RerankWeight explicitly.
+const DefaultRerankWeight = 0.5
+
 type ChunkSearchMessage struct {
 	SearchID        string    `json:"search_id"`
 	JobID           string    `json:"job_id"`
@@ -42,6 +90,13 @@ type ChunkSearchResponse struct {
 	ServiceInstanceID string              `json:"service_instance_id"`
 }
 
+// SearchCancelMessage tells chunk-search workers to abandon the listed
+// searches, published when a job's coordinator gives up waiting on them.
+type SearchCancelMessage struct {
+	JobID     string   `json:"job_id"`
+	SearchIDs []string `json:"search_ids"`
+}
+
 type ChunkSearchError struct {
 	SearchID          string `json:"search_id"`
 	ErrorMessage      string `json:"error_message"`
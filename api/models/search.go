@@ -14,6 +14,63 @@ type QuerySearchJob struct {
 	ScoreThreshold float64   `json:"score_threshold"`
 	CreatedAt      time.Time `json:"created_at"`
 	CorrelationID  string    `json:"correlation_id"`
+	// TenantID scopes this job for fair-scheduling purposes - a noisy
+	// tenant's jobs are capped by worker.TenantSemaphore rather than being
+	// able to exhaust query_search_jobs for everyone else. Defaults to
+	// SessionID when the caller doesn't set it explicitly.
+	TenantID string `json:"tenant_id"`
+	// Priority is an AMQP priority 0 (lowest) to mq.QuerySearchJobsMaxPriority
+	// (highest); RabbitMQ delivers higher-priority messages first within
+	// the query_search_jobs queue.
+	Priority int `json:"priority"`
+}
+
+// Job status values for a search_jobs row, tracking the lifecycle around a
+// QuerySearchJob independently of whether a query_results row exists yet.
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+// SearchJobRecord is a search_jobs row: the durable lifecycle state for one
+// QuerySearchJob, from submission through its terminal status.
+type SearchJobRecord struct {
+	ID            string     `json:"id"`
+	JobID         string     `json:"job_id"`
+	TenantID      string     `json:"tenant_id"`
+	SessionID     string     `json:"session_id"`
+	Query         string     `json:"query"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	CorrelationID string     `json:"correlation_id"`
+	Error         string     `json:"error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// SearchJobFilter scopes JobRepository.List over search_jobs by status,
+// tenant, and submission date, mirroring ArticleFilter's shape.
+type SearchJobFilter struct {
+	Status   *string    `form:"status"`
+	TenantID *string    `form:"tenant"`
+	Since    *time.Time `form:"since" time_format:"2006-01-02"`
+	Limit    int        `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset   int        `form:"offset" binding:"omitempty,min=0"`
+}
+
+// SetDefaults fills in Limit/Offset when the caller left them unset.
+func (f *SearchJobFilter) SetDefaults() {
+	if f.Limit == 0 {
+		f.Limit = 20
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
 }
 
 type QuerySearchMessage struct {
@@ -24,6 +81,21 @@ type QuerySearchMessage struct {
 	ScoreThreshold float64 `json:"score_threshold"`
 }
 
+// HybridSearchMessage requests a keyword+vector fused search over
+// articles, published to hybrid_search_jobs and consumed by
+// services.HybridSearchService. Unlike QuerySearchMessage (pure vector,
+// fanned out chunk-by-chunk to the Python ML service), hybrid search
+// resolves entirely in Go: Bleve supplies the keyword leg and
+// QueryEmbedding the vector leg, so both legs run against data already
+// local to this process.
+type HybridSearchMessage struct {
+	SearchID       string    `json:"search_id"`
+	JobID          string    `json:"job_id"`
+	Query          string    `json:"query"`
+	QueryEmbedding []float32 `json:"query_embedding"`
+	TopK           int       `json:"top_k"`
+}
+
 type QuerySearchResult struct {
 	ChunkID         string    `json:"chunk_id"`
 	SimilarityScore float64   `json:"similarity_score"`
@@ -54,6 +126,7 @@ type QuerySearchError struct {
 
 type QueryRecommendationResult struct {
 	JobID           string                  `json:"job_id"`
+	SessionID       string                  `json:"session_id,omitempty"`
 	Query           string                  `json:"query"`
 	Recommendations []ArticleRecommendation `json:"recommendations"`
 	TotalFound      int                     `json:"total_found"`
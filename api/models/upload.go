@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// UploadSessionStatus tracks the lifecycle of a resumable upload.
+type UploadSessionStatus string
+
+const (
+	UploadSessionActive    UploadSessionStatus = "active"
+	UploadSessionFinalized UploadSessionStatus = "finalized"
+	UploadSessionAborted   UploadSessionStatus = "aborted"
+)
+
+// UploadSession is the Redis-persisted state of an in-progress resumable
+// article upload. It tracks the underlying S3 multipart upload alongside
+// the last committed byte offset so a client that disconnects can resume
+// from exactly where it left off.
+type UploadSession struct {
+	UploadID        string              `json:"upload_id"`
+	ObjectKey       string              `json:"object_key"`
+	S3UploadID      string              `json:"s3_upload_id"`
+	Title           string              `json:"title"`
+	Category        string              `json:"category"`
+	URL             string              `json:"url"`
+	TotalSize       int64               `json:"total_size"`
+	CommittedOffset int64               `json:"committed_offset"`
+	Parts           []UploadPart        `json:"parts"`
+	Status          UploadSessionStatus `json:"status"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+}
+
+// UploadPart records one committed S3 multipart part so CompleteMultipartUpload
+// can be called with the full, ordered part list.
+type UploadPart struct {
+	PartNumber int32  `json:"part_number"`
+	ETag       string `json:"etag"`
+	Size       int64  `json:"size"`
+}
+
+// CreateUploadSessionRequest starts a new resumable upload.
+type CreateUploadSessionRequest struct {
+	Title     string `json:"title" binding:"required"`
+	Category  string `json:"category" binding:"required"`
+	URL       string `json:"url"`
+	TotalSize int64  `json:"total_size" binding:"required,min=1"`
+}
+
+// CreateUploadSessionResponse is returned when a session is created.
+type CreateUploadSessionResponse struct {
+	UploadID  string `json:"upload_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// AppendUploadChunkResponse reports the session's new committed offset.
+type AppendUploadChunkResponse struct {
+	UploadID        string `json:"upload_id"`
+	CommittedOffset int64  `json:"committed_offset"`
+	TotalSize       int64  `json:"total_size"`
+}
+
+// FinalizeUploadResponse is returned once the article has been assembled
+// and queued for chunking + embedding.
+type FinalizeUploadResponse struct {
+	UploadID  string `json:"upload_id"`
+	ArticleID string `json:"article_id"`
+	Status    string `json:"status"`
+}
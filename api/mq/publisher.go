@@ -8,12 +8,44 @@ import (
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/joho/godotenv"
+	"rec-mind/config"
+	"rec-mind/internal/metrics"
 	"rec-mind/models"
 )
 
 var MQConn *amqp.Connection
 var MQChannel *amqp.Channel
 
+// eventsExchange is the topic exchange publishers route typed events
+// through (e.g. "article.processing", "recommendation.job.new"), layered
+// additively on top of the queues' existing default-exchange bindings so
+// a queue is still reachable by publishing directly to it by name.
+const eventsExchange = "recmind.events"
+
+// QuerySearchJobsMaxPriority bounds the AMQP priority of query_search_jobs
+// messages (0 lowest, QuerySearchJobsMaxPriority highest). Declaring the
+// queue with x-max-priority is what makes RabbitMQ actually reorder
+// delivery by Publishing.Priority instead of silently ignoring it.
+const QuerySearchJobsMaxPriority = 9
+
+// QuerySearchJobsQueueArgs is the queue.declare argument table every
+// declarer of query_search_jobs (InitRabbitMQ, JobConsumer.Start,
+// QueryWorkerPool.Start) must pass identically, since RabbitMQ rejects a
+// redeclare whose arguments don't match the queue's existing ones.
+func QuerySearchJobsQueueArgs() amqp.Table {
+	return amqp.Table{"x-max-priority": QuerySearchJobsMaxPriority}
+}
+
+// recordPublish records a publish attempt's outcome on
+// rec_mind_rabbitmq_publish_total, labeled by queue name.
+func recordPublish(queue string, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	metrics.RabbitMQPublishTotal.WithLabelValues(queue, outcome).Inc()
+}
+
 func InitRabbitMQ() {
 	err := godotenv.Load()
 
@@ -36,7 +68,27 @@ func InitRabbitMQ() {
 		log.Fatalf("Failed to open a channel: %v", err)
 	}
 
-	// Declare article_events queue
+	// Declare recmind.events, a topic exchange for publishers that want to
+	// route by a typed key instead of a hardcoded queue name. Queues bound
+	// to it keep their default-exchange binding too, so existing publish
+	// calls that target a queue by name are unaffected.
+	err = ch.ExchangeDeclare(
+		eventsExchange, // name
+		"topic",        // kind
+		true,           // durable
+		false,          // auto-delete
+		false,          // internal
+		false,          // no-wait
+		nil,            // arguments
+	)
+	if err != nil {
+		log.Fatalf("Failed to declare %s exchange: %v", eventsExchange, err)
+	}
+
+	// Declare article_events queue: bound to recmind.events for
+	// article.created/updated/deleted/rescrape so ArticleEventsWorker can
+	// dispatch each to its own handler instead of the old unrouted
+	// auto-ack consumer that just logged every message.
 	_, err = ch.QueueDeclare(
 		"article_events", // queue name
 		true,             // durable
@@ -48,6 +100,11 @@ func InitRabbitMQ() {
 	if err != nil {
 		log.Fatalf("Failed to declare article_events queue: %v", err)
 	}
+	for _, routingKey := range []string{"article.created", "article.updated", "article.deleted", "article.rescrape"} {
+		if err := ch.QueueBind("article_events", routingKey, eventsExchange, false, nil); err != nil {
+			log.Fatalf("Failed to bind article_events to %s for %s: %v", eventsExchange, routingKey, err)
+		}
+	}
 
 	// Declare article_processing queue
 	_, err = ch.QueueDeclare(
@@ -61,6 +118,27 @@ func InitRabbitMQ() {
 	if err != nil {
 		log.Fatalf("Failed to declare article_processing queue: %v", err)
 	}
+	err = ch.QueueBind("article_processing", "article.processing", eventsExchange, false, nil)
+	if err != nil {
+		log.Fatalf("Failed to bind article_processing to %s: %v", eventsExchange, err)
+	}
+
+	// Declare articles.batch queue: BulkArticleIngestor publishes one
+	// manifest message here per flushed batch; ArticleBatchWorker consumes
+	// it to durably enqueue chunked batch embedding for the whole batch.
+	// Declared here rather than via BulkArticleIngestor.DeclareQueue (which
+	// nothing currently calls) so the queue exists as soon as RabbitMQ does.
+	_, err = ch.QueueDeclare(
+		"articles.batch", // queue name
+		true,              // durable
+		false,             // auto-delete
+		false,             // exclusive
+		false,             // no-wait
+		nil,               // arguments
+	)
+	if err != nil {
+		log.Fatalf("Failed to declare articles.batch queue: %v", err)
+	}
 
 	// Declare recommendation_jobs queue
 	_, err = ch.QueueDeclare(
@@ -74,6 +152,10 @@ func InitRabbitMQ() {
 	if err != nil {
 		log.Fatalf("Failed to declare recommendation_jobs queue: %v", err)
 	}
+	err = ch.QueueBind("recommendation_jobs", "recommendation.job.new", eventsExchange, false, nil)
+	if err != nil {
+		log.Fatalf("Failed to bind recommendation_jobs to %s: %v", eventsExchange, err)
+	}
 
 	// Declare chunk_search queue
 	_, err = ch.QueueDeclare(
@@ -88,6 +170,61 @@ func InitRabbitMQ() {
 		log.Fatalf("Failed to declare chunk_search queue: %v", err)
 	}
 
+	// Declare hybrid_search_jobs queue
+	_, err = ch.QueueDeclare(
+		"hybrid_search_jobs", // queue name
+		true,                 // durable
+		false,                // auto-delete
+		false,                // exclusive
+		false,                // no-wait
+		nil,                  // arguments
+	)
+	if err != nil {
+		log.Fatalf("Failed to declare hybrid_search_jobs queue: %v", err)
+	}
+
+	// Declare article_deleted_events queue: bound to recmind.events so the
+	// chunk/embedding worker can purge a soft-deleted article's vectors and
+	// secondary-index document without polling for deletions.
+	_, err = ch.QueueDeclare(
+		"article_deleted_events", // queue name
+		true,                     // durable
+		false,                    // auto-delete
+		false,                    // exclusive
+		false,                    // no-wait
+		nil,                      // arguments
+	)
+	if err != nil {
+		log.Fatalf("Failed to declare article_deleted_events queue: %v", err)
+	}
+	err = ch.QueueBind("article_deleted_events", "article.deleted", eventsExchange, false, nil)
+	if err != nil {
+		log.Fatalf("Failed to bind article_deleted_events to %s: %v", eventsExchange, err)
+	}
+
+	// Declare category_events queue: bound to recmind.events so a
+	// recommendation-model worker can re-key on category.created/renamed
+	// without a full re-embed.
+	_, err = ch.QueueDeclare(
+		"category_events", // queue name
+		true,               // durable
+		false,              // auto-delete
+		false,              // exclusive
+		false,              // no-wait
+		nil,                // arguments
+	)
+	if err != nil {
+		log.Fatalf("Failed to declare category_events queue: %v", err)
+	}
+	err = ch.QueueBind("category_events", "category.created", eventsExchange, false, nil)
+	if err != nil {
+		log.Fatalf("Failed to bind category_events to %s for category.created: %v", eventsExchange, err)
+	}
+	err = ch.QueueBind("category_events", "category.renamed", eventsExchange, false, nil)
+	if err != nil {
+		log.Fatalf("Failed to bind category_events to %s for category.renamed: %v", eventsExchange, err)
+	}
+
 	// Declare search_results queue
 	_, err = ch.QueueDeclare(
 		"search_results", // queue name
@@ -101,14 +238,16 @@ func InitRabbitMQ() {
 		log.Fatalf("Failed to declare search_results queue: %v", err)
 	}
 
-	// Declare query_search_jobs queue
+	// Declare query_search_jobs queue as a priority queue, so Priority and
+	// TenantID on QuerySearchJob can actually prevent a burst of
+	// low-priority jobs from delaying higher-priority ones.
 	_, err = ch.QueueDeclare(
-		"query_search_jobs", // queue name
-		true,                // durable
-		false,               // auto-delete
-		false,               // exclusive
-		false,               // no-wait
-		nil,                 // arguments
+		"query_search_jobs",        // queue name
+		true,                       // durable
+		false,                      // auto-delete
+		false,                      // exclusive
+		false,                      // no-wait
+		QuerySearchJobsQueueArgs(), // arguments
 	)
 	if err != nil {
 		log.Fatalf("Failed to declare query_search_jobs queue: %v", err)
@@ -127,6 +266,87 @@ func InitRabbitMQ() {
 		log.Fatalf("Failed to declare query_search queue: %v", err)
 	}
 
+	// Declare article_deletion_jobs queue
+	_, err = ch.QueueDeclare(
+		"article_deletion_jobs", // queue name
+		true,                    // durable
+		false,                   // auto-delete
+		false,                   // exclusive
+		false,                   // no-wait
+		nil,                     // arguments
+	)
+	if err != nil {
+		log.Fatalf("Failed to declare article_deletion_jobs queue: %v", err)
+	}
+
+	// Declare search_cancel queue, used to tell chunk-search workers to stop
+	// processing a job whose coordinator has already given up on it.
+	_, err = ch.QueueDeclare(
+		"search_cancel", // queue name
+		true,            // durable
+		false,           // auto-delete
+		false,           // exclusive
+		false,           // no-wait
+		nil,             // arguments
+	)
+	if err != nil {
+		log.Fatalf("Failed to declare search_cancel queue: %v", err)
+	}
+
+	// Declare search_results.retry queue: holds search result messages that
+	// failed to parse/process for searchResultRetryTTL before dead-lettering
+	// them back into search_results for another attempt.
+	_, err = ch.QueueDeclare(
+		"search_results.retry", // queue name
+		true,                   // durable
+		false,                  // auto-delete
+		false,                  // exclusive
+		false,                  // no-wait
+		amqp.Table{
+			"x-message-ttl":             int32(5000),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": "search_results",
+		},
+	)
+	if err != nil {
+		log.Fatalf("Failed to declare search_results.retry queue: %v", err)
+	}
+
+	// Declare search_results.dlq queue: terminal home for search result
+	// messages that exceeded their retry count.
+	_, err = ch.QueueDeclare(
+		"search_results.dlq", // queue name
+		true,                 // durable
+		false,                // auto-delete
+		false,                // exclusive
+		false,                // no-wait
+		nil,                  // arguments
+	)
+	if err != nil {
+		log.Fatalf("Failed to declare search_results.dlq queue: %v", err)
+	}
+
+	// Declare the exponential-backoff retry ladder and terminal dead queue
+	// for query_search_jobs and recommendation_jobs, so a poison message
+	// gets a few widening-delay attempts instead of an infinite immediate
+	// requeue loop.
+	if err := DeclareRetryTopology(ch, "query_search_jobs", DefaultRetryTiers); err != nil {
+		log.Fatalf("Failed to declare query_search_jobs retry topology: %v", err)
+	}
+	if err := DeclareRetryTopology(ch, "recommendation_jobs", DefaultRetryTiers); err != nil {
+		log.Fatalf("Failed to declare recommendation_jobs retry topology: %v", err)
+	}
+
+	// Declare the same retry ladder, sized from RetryPolicy instead of the
+	// hardcoded DefaultRetryTiers, for queues with no pre-existing retry
+	// topology or hardcoded tier-name dependents elsewhere.
+	retryTiers := TiersFromPolicy(config.LoadRetryPolicy())
+	for _, queue := range []string{"chunk_search", "article_deletion_jobs", "search_cancel", "hybrid_search_jobs", "article_events", "articles.batch"} {
+		if err := DeclareRetryTopology(ch, queue, retryTiers); err != nil {
+			log.Fatalf("Failed to declare %s retry topology: %v", queue, err)
+		}
+	}
+
 	MQConn = conn
 	MQChannel = ch
 }
@@ -142,16 +362,26 @@ func PublishEvent(body string) error {
 			Body:        []byte(body),
 		},
 	)
+	recordPublish("article_events", err)
 	return err
 }
 
-// PublishQuerySearchJob publishes a query search job to the jobs queue
+// PublishQuerySearchJob publishes a query search job to the jobs queue.
+// job.Priority is clamped into [0, QuerySearchJobsMaxPriority] so a caller
+// passing an out-of-range value can't be rejected by RabbitMQ.
 func PublishQuerySearchJob(job models.QuerySearchJob) error {
 	messageBytes, err := json.Marshal(job)
 	if err != nil {
 		return fmt.Errorf("failed to marshal query search job: %w", err)
 	}
 
+	priority := job.Priority
+	if priority < 0 {
+		priority = 0
+	} else if priority > QuerySearchJobsMaxPriority {
+		priority = QuerySearchJobsMaxPriority
+	}
+
 	err = MQChannel.Publish(
 		"",                  // exchange
 		"query_search_jobs", // routing key (queue name)
@@ -161,8 +391,11 @@ func PublishQuerySearchJob(job models.QuerySearchJob) error {
 			ContentType:  "application/json",
 			Body:         messageBytes,
 			DeliveryMode: 2, // persistent
+			Priority:     uint8(priority),
+			Headers:      amqp.Table{"correlation_id": job.CorrelationID},
 		},
 	)
+	recordPublish("query_search_jobs", err)
 
 	if err != nil {
 		return fmt.Errorf("failed to publish query search job: %w", err)
@@ -190,6 +423,7 @@ func PublishQuerySearch(message models.QuerySearchMessage) error {
 			DeliveryMode: 2, // persistent
 		},
 	)
+	recordPublish("query_search", err)
 
 	if err != nil {
 		return fmt.Errorf("failed to publish query search message: %w", err)
@@ -199,14 +433,48 @@ func PublishQuerySearch(message models.QuerySearchMessage) error {
 	return nil
 }
 
-// PublishArticleProcessing publishes an article to the processing queue for chunking and embedding
-func PublishArticleProcessing(articleID, title, content, category string, createdAt string) error {
+// PublishHybridSearch publishes a hybrid (Bleve keyword + pgvector)
+// search job to hybrid_search_jobs.
+func PublishHybridSearch(message models.HybridSearchMessage) error {
+	messageBytes, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hybrid search message: %w", err)
+	}
+
+	err = MQChannel.Publish(
+		"",                   // exchange
+		"hybrid_search_jobs", // routing key (queue name)
+		false,                // mandatory
+		false,                // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         messageBytes,
+			DeliveryMode: 2, // persistent
+		},
+	)
+	recordPublish("hybrid_search_jobs", err)
+
+	if err != nil {
+		return fmt.Errorf("failed to publish hybrid search job: %w", err)
+	}
+
+	log.Printf("📨 Published hybrid search job %s for query: %s", message.SearchID, message.Query)
+	return nil
+}
+
+// PublishArticleProcessing publishes an article to the processing queue for
+// chunking and embedding. categoryID/categorySlug are included alongside
+// the legacy category string so a consumer can key on either during the
+// articles.category -> articles.category_id migration.
+func PublishArticleProcessing(articleID, title, content, category, categoryID, categorySlug string, createdAt string) error {
 	message := map[string]interface{}{
-		"article_id": articleID,
-		"title":      title,
-		"content":    content,
-		"category":   category,
-		"created_at": createdAt,
+		"article_id":    articleID,
+		"title":         title,
+		"content":       content,
+		"category":      category,
+		"category_id":   categoryID,
+		"category_slug": categorySlug,
+		"created_at":    createdAt,
 	}
 
 	messageBytes, err := json.Marshal(message)
@@ -215,8 +483,8 @@ func PublishArticleProcessing(articleID, title, content, category string, create
 	}
 
 	err = MQChannel.Publish(
-		"",                   // exchange
-		"article_processing", // routing key (queue name)
+		eventsExchange,       // exchange
+		"article.processing", // routing key
 		false,                // mandatory
 		false,                // immediate
 		amqp.Publishing{
@@ -225,11 +493,12 @@ func PublishArticleProcessing(articleID, title, content, category string, create
 			DeliveryMode: 2, // Make message persistent (2 = persistent, 1 = transient)
 		},
 	)
-	
+	recordPublish("article_processing", err)
+
 	if err != nil {
 		return fmt.Errorf("failed to publish article processing message: %w", err)
 	}
-	
+
 	log.Printf("📨 Published article %s to processing queue", articleID)
 	return nil
 }
@@ -242,17 +511,19 @@ func PublishRecommendationJob(job models.RecommendationJob) error {
 	}
 
 	err = MQChannel.Publish(
-		"",                    // exchange
-		"recommendation_jobs", // routing key (queue name)
-		false,                 // mandatory
-		false,                 // immediate
+		eventsExchange,           // exchange
+		"recommendation.job.new", // routing key
+		false,                    // mandatory
+		false,                    // immediate
 		amqp.Publishing{
 			ContentType:  "application/json",
 			Body:         messageBytes,
 			DeliveryMode: 2, // persistent
+			Headers:      amqp.Table{"correlation_id": job.CorrelationID},
 		},
 	)
-	
+	recordPublish("recommendation_jobs", err)
+
 	if err != nil {
 		return fmt.Errorf("failed to publish recommendation job: %w", err)
 	}
@@ -261,6 +532,243 @@ func PublishRecommendationJob(job models.RecommendationJob) error {
 	return nil
 }
 
+// PublishArticleDeletionJob publishes a bulk deletion job so a worker can
+// cascade-delete chunks, invalidate Redis keys, and issue vector deletes
+// without blocking the originating request.
+func PublishArticleDeletionJob(job models.ArticleDeletionJob) error {
+	messageBytes, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal article deletion job: %w", err)
+	}
+
+	err = MQChannel.Publish(
+		"",                      // exchange
+		"article_deletion_jobs", // routing key (queue name)
+		false,                   // mandatory
+		false,                   // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         messageBytes,
+			DeliveryMode: 2, // persistent
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to publish article deletion job: %w", err)
+	}
+
+	log.Printf("📨 Published article deletion job %s for %d article(s)", job.JobID, len(job.ArticleIDs))
+	return nil
+}
+
+// PublishArticleDeleted publishes an article.deleted event on the
+// recmind.events exchange when a single article is soft-deleted, so the
+// chunk/embedding worker can purge its vectors and secondary-index document.
+// Unlike PublishArticleDeletionJob (bulk, explicit cascade job), this is a
+// lightweight best-effort notification: article_repository.Delete logs
+// rather than propagates a failure here.
+func PublishArticleDeleted(event models.ArticleDeletedEvent) error {
+	if MQChannel == nil {
+		return fmt.Errorf("rabbitmq channel not initialized")
+	}
+
+	messageBytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal article deleted event: %w", err)
+	}
+
+	err = MQChannel.Publish(
+		eventsExchange,     // exchange
+		"article.deleted",  // routing key
+		false,              // mandatory
+		false,              // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         messageBytes,
+			DeliveryMode: 2, // persistent
+		},
+	)
+	recordPublish("article_deleted_events", err)
+
+	if err != nil {
+		return fmt.Errorf("failed to publish article deleted event: %w", err)
+	}
+
+	log.Printf("🗑️ Published article.deleted event for article %s", event.ArticleID)
+	return nil
+}
+
+// PublishArticleCreated publishes an article.created event on the
+// recmind.events exchange after a new, non-duplicate article is persisted,
+// so ArticleEventsWorker can enqueue its embedding job.
+func PublishArticleCreated(event models.ArticleCreatedEvent) error {
+	if MQChannel == nil {
+		return fmt.Errorf("rabbitmq channel not initialized")
+	}
+
+	messageBytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal article created event: %w", err)
+	}
+
+	err = MQChannel.Publish(
+		eventsExchange,    // exchange
+		"article.created", // routing key
+		false,              // mandatory
+		false,              // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         messageBytes,
+			DeliveryMode: 2, // persistent
+		},
+	)
+	recordPublish("article_events", err)
+
+	if err != nil {
+		return fmt.Errorf("failed to publish article created event: %w", err)
+	}
+
+	log.Printf("📨 Published article.created event for article %s", event.ArticleID)
+	return nil
+}
+
+// PublishArticleUpdated publishes an article.updated event on the
+// recmind.events exchange when an article's content changes in a way that
+// invalidates its existing embedding, so ArticleEventsWorker can re-enqueue
+// embedding generation for it.
+func PublishArticleUpdated(event models.ArticleUpdatedEvent) error {
+	if MQChannel == nil {
+		return fmt.Errorf("rabbitmq channel not initialized")
+	}
+
+	messageBytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal article updated event: %w", err)
+	}
+
+	err = MQChannel.Publish(
+		eventsExchange,    // exchange
+		"article.updated", // routing key
+		false,              // mandatory
+		false,              // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         messageBytes,
+			DeliveryMode: 2, // persistent
+		},
+	)
+	recordPublish("article_events", err)
+
+	if err != nil {
+		return fmt.Errorf("failed to publish article updated event: %w", err)
+	}
+
+	log.Printf("📨 Published article.updated event for article %s", event.ArticleID)
+	return nil
+}
+
+// PublishArticleRescrape publishes an article.rescrape event on the
+// recmind.events exchange to ask the scraper to re-fetch an
+// already-ingested article's source URL.
+func PublishArticleRescrape(event models.ArticleRescrapeEvent) error {
+	if MQChannel == nil {
+		return fmt.Errorf("rabbitmq channel not initialized")
+	}
+
+	messageBytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal article rescrape event: %w", err)
+	}
+
+	err = MQChannel.Publish(
+		eventsExchange,     // exchange
+		"article.rescrape", // routing key
+		false,              // mandatory
+		false,              // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         messageBytes,
+			DeliveryMode: 2, // persistent
+		},
+	)
+	recordPublish("article_events", err)
+
+	if err != nil {
+		return fmt.Errorf("failed to publish article rescrape event: %w", err)
+	}
+
+	log.Printf("📨 Published article.rescrape event for article %s", event.ArticleID)
+	return nil
+}
+
+// PublishCategoryCreated publishes a category.created event on the
+// recmind.events exchange when a new category is created.
+func PublishCategoryCreated(event models.CategoryCreatedEvent) error {
+	if MQChannel == nil {
+		return fmt.Errorf("rabbitmq channel not initialized")
+	}
+
+	messageBytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal category created event: %w", err)
+	}
+
+	err = MQChannel.Publish(
+		eventsExchange,     // exchange
+		"category.created", // routing key
+		false,              // mandatory
+		false,              // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         messageBytes,
+			DeliveryMode: 2, // persistent
+		},
+	)
+	recordPublish("category_events", err)
+
+	if err != nil {
+		return fmt.Errorf("failed to publish category created event: %w", err)
+	}
+
+	log.Printf("📨 Published category.created event for %s", event.Slug)
+	return nil
+}
+
+// PublishCategoryRenamed publishes a category.renamed event on the
+// recmind.events exchange when a category's name or slug changes, so
+// downstream recommendation models can re-key their category features
+// without a full re-embed.
+func PublishCategoryRenamed(event models.CategoryRenamedEvent) error {
+	if MQChannel == nil {
+		return fmt.Errorf("rabbitmq channel not initialized")
+	}
+
+	messageBytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal category renamed event: %w", err)
+	}
+
+	err = MQChannel.Publish(
+		eventsExchange,     // exchange
+		"category.renamed", // routing key
+		false,              // mandatory
+		false,              // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         messageBytes,
+			DeliveryMode: 2, // persistent
+		},
+	)
+	recordPublish("category_events", err)
+
+	if err != nil {
+		return fmt.Errorf("failed to publish category renamed event: %w", err)
+	}
+
+	log.Printf("📨 Published category.renamed event %s -> %s", event.OldSlug, event.NewSlug)
+	return nil
+}
+
 // PublishChunkSearch publishes a chunk search message to the search queue
 func PublishChunkSearch(message models.ChunkSearchMessage) error {
 	messageBytes, err := json.Marshal(message)
@@ -287,3 +795,32 @@ func PublishChunkSearch(message models.ChunkSearchMessage) error {
 	log.Printf("📨 Published chunk search %s for job %s", message.SearchID, message.JobID)
 	return nil
 }
+
+// PublishSearchCancel tells chunk-search workers to stop processing the
+// given searches: published when a job's coordinator times out, errors, or
+// its HTTP caller disconnects, so abandoned work doesn't keep running.
+func PublishSearchCancel(message models.SearchCancelMessage) error {
+	messageBytes, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search cancel message: %w", err)
+	}
+
+	err = MQChannel.Publish(
+		"",              // exchange
+		"search_cancel", // routing key (queue name)
+		false,           // mandatory
+		false,           // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Body:         messageBytes,
+			DeliveryMode: 2, // persistent
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to publish search cancel: %w", err)
+	}
+
+	log.Printf("🚫 Published search cancel for job %s (%d search(es))", message.JobID, len(message.SearchIDs))
+	return nil
+}
@@ -0,0 +1,293 @@
+package mq
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"rec-mind/config"
+	"rec-mind/models"
+)
+
+// RetryTier is one delay queue in an exponential-backoff retry ladder: a
+// message dead-lettered into this tier's queue sits for Delay, then the
+// broker dead-letters it back onto the origin queue for another attempt.
+type RetryTier struct {
+	Suffix string
+	Delay  time.Duration
+}
+
+// DefaultRetryTiers is the standard 5s/30s/5m backoff ladder used for
+// query_search_jobs and recommendation_jobs, matching the fixed-delay
+// search_results.retry queue already used by RAGWorker but with multiple
+// widening steps instead of one.
+var DefaultRetryTiers = []RetryTier{
+	{Suffix: "retry.5s", Delay: 5 * time.Second},
+	{Suffix: "retry.30s", Delay: 30 * time.Second},
+	{Suffix: "retry.5m", Delay: 5 * time.Minute},
+}
+
+// TiersFromPolicy generates policy.MaxAttempts RetryTiers with widening
+// delays (BaseDelay * Multiplier^i), for queues that want a config-driven
+// ladder instead of the hardcoded DefaultRetryTiers.
+func TiersFromPolicy(policy config.RetryPolicy) []RetryTier {
+	tiers := make([]RetryTier, policy.MaxAttempts)
+	delay := policy.BaseDelay
+	for i := range tiers {
+		tiers[i] = RetryTier{Suffix: fmt.Sprintf("retry.%d", i+1), Delay: delay}
+		delay = time.Duration(float64(delay) * policy.Multiplier)
+	}
+	return tiers
+}
+
+// DeclareRetryTopology declares, for originQueue, one TTL-expiring delay
+// queue per tier plus a terminal originQueue.dead queue that messages land
+// in once they've exhausted every tier.
+func DeclareRetryTopology(ch *amqp.Channel, originQueue string, tiers []RetryTier) error {
+	for _, tier := range tiers {
+		name := originQueue + "." + tier.Suffix
+		_, err := ch.QueueDeclare(
+			name,  // queue name
+			true,  // durable
+			false, // auto-delete
+			false, // exclusive
+			false, // no-wait
+			amqp.Table{
+				"x-message-ttl":             int32(tier.Delay.Milliseconds()),
+				"x-dead-letter-exchange":    "",
+				"x-dead-letter-routing-key": originQueue,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to declare %s queue: %w", name, err)
+		}
+	}
+
+	deadQueue := originQueue + ".dead"
+	_, err := ch.QueueDeclare(
+		deadQueue, // queue name
+		true,      // durable
+		false,     // auto-delete
+		false,     // exclusive
+		false,     // no-wait
+		nil,       // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare %s queue: %w", deadQueue, err)
+	}
+
+	return nil
+}
+
+// retryCountOf reads the x-retry-count header, defaulting to 0 for a
+// message seeing the retry path for the first time. Mirrors
+// retryCountOf in internal/worker/rag_worker.go; kept as a
+// separate copy since the two retry paths live in different packages.
+func retryCountOf(headers amqp.Table) int {
+	if headers == nil {
+		return 0
+	}
+	switch v := headers["x-retry-count"].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
+
+// RetryOrDeadLetter republishes a failed delivery from originQueue onto the
+// next tier in tiers (by x-retry-count), or to originQueue.dead with the
+// triggering error and worker instance recorded once every tier is
+// exhausted. The original delivery is acked either way since a copy now
+// lives in the retry ladder or the dead queue. Returns whether the message
+// was sent to the dead queue.
+func RetryOrDeadLetter(ch *amqp.Channel, d amqp.Delivery, originQueue string, tiers []RetryTier, cause error, workerInstanceID string) (deadLettered bool, err error) {
+	attempt := retryCountOf(d.Headers) + 1
+
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers["x-retry-count"] = int32(attempt)
+
+	if attempt > len(tiers) {
+		if cause != nil {
+			headers["x-last-error"] = cause.Error()
+		}
+		headers["x-last-worker-instance"] = workerInstanceID
+
+		deadQueue := originQueue + ".dead"
+		publishErr := ch.Publish("", deadQueue, false, false, amqp.Publishing{
+			ContentType:  d.ContentType,
+			Body:         d.Body,
+			DeliveryMode: amqp.Persistent,
+			Headers:      headers,
+		})
+		if publishErr != nil {
+			d.Nack(false, false)
+			return false, fmt.Errorf("failed to dead-letter message from %s: %w", originQueue, publishErr)
+		}
+
+		log.Printf("🪦 Message from %s exceeded %d retries; sent to %s", originQueue, len(tiers), deadQueue)
+		d.Ack(false)
+		return true, nil
+	}
+
+	tierQueue := originQueue + "." + tiers[attempt-1].Suffix
+	publishErr := ch.Publish("", tierQueue, false, false, amqp.Publishing{
+		ContentType:  d.ContentType,
+		Body:         d.Body,
+		DeliveryMode: amqp.Persistent,
+		Headers:      headers,
+	})
+	if publishErr != nil {
+		d.Nack(false, false)
+		return false, fmt.Errorf("failed to enqueue message from %s for retry: %w", originQueue, publishErr)
+	}
+
+	d.Ack(false)
+	return false, nil
+}
+
+// DeadLetterMessage is a lightweight view of a message sitting in a
+// <queue>.dead queue, returned by the admin dead-letter endpoints.
+type DeadLetterMessage struct {
+	Body         string `json:"body"`
+	RetryCount   int    `json:"retry_count"`
+	LastError    string `json:"last_error,omitempty"`
+	LastWorkerID string `json:"last_worker_instance,omitempty"`
+}
+
+// ListDeadLetters peeks at up to limit messages in deadQueue without
+// removing them: each is fetched with Get and held unacked until the scan
+// ends, so the next Get reaches the following message, and all of them are
+// then Nacked with requeue=true, which puts them back in their places.
+func ListDeadLetters(ch *amqp.Channel, deadQueue string, limit int) ([]DeadLetterMessage, error) {
+	messages := make([]DeadLetterMessage, 0, limit)
+	var peeked []amqp.Delivery
+	defer func() {
+		for _, d := range peeked {
+			d.Nack(false, true)
+		}
+	}()
+
+	for i := 0; i < limit; i++ {
+		d, ok, err := ch.Get(deadQueue, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read from %s: %w", deadQueue, err)
+		}
+		if !ok {
+			break
+		}
+
+		messages = append(messages, DeadLetterMessage{
+			Body:         string(d.Body),
+			RetryCount:   retryCountOf(d.Headers),
+			LastError:    stringHeader(d.Headers, "x-last-error"),
+			LastWorkerID: stringHeader(d.Headers, "x-last-worker-instance"),
+		})
+		peeked = append(peeked, d)
+	}
+
+	return messages, nil
+}
+
+// RequeueDeadLetter pops one message off deadQueue and republishes it to
+// originQueue with its retry count reset, so it gets a fresh pass through
+// the retry ladder. Returns false if deadQueue was empty.
+func RequeueDeadLetter(ch *amqp.Channel, deadQueue, originQueue string) (bool, error) {
+	d, ok, err := ch.Get(deadQueue, false)
+	if err != nil {
+		return false, fmt.Errorf("failed to read from %s: %w", deadQueue, err)
+	}
+	if !ok {
+		return false, nil
+	}
+
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers["x-retry-count"] = int32(0)
+
+	publishErr := ch.Publish("", originQueue, false, false, amqp.Publishing{
+		ContentType:  d.ContentType,
+		Body:         d.Body,
+		DeliveryMode: amqp.Persistent,
+		Headers:      headers,
+	})
+	if publishErr != nil {
+		d.Nack(false, true)
+		return false, fmt.Errorf("failed to requeue message onto %s: %w", originQueue, publishErr)
+	}
+
+	d.Ack(false)
+	return true, nil
+}
+
+// ReplayDeadLetterByJobID scans up to scanLimit messages in deadQueue for
+// one whose body decodes to a models.QuerySearchJob with JobID == jobID,
+// republishing just that message onto originQueue with its retry count
+// reset (like RequeueDeadLetter, but targeted at one job instead of
+// whichever message happens to be next). Every other message it peeks at
+// along the way is held unacked until the scan ends and then Nacked with
+// requeue=true, same as ListDeadLetters, so scanning never loses a message.
+// Returns false if jobID isn't found within scanLimit messages.
+func ReplayDeadLetterByJobID(ch *amqp.Channel, deadQueue, originQueue, jobID string, scanLimit int) (bool, error) {
+	var peeked []amqp.Delivery
+	defer func() {
+		for _, d := range peeked {
+			d.Nack(false, true)
+		}
+	}()
+	for i := 0; i < scanLimit; i++ {
+		d, ok, err := ch.Get(deadQueue, false)
+		if err != nil {
+			return false, fmt.Errorf("failed to read from %s: %w", deadQueue, err)
+		}
+		if !ok {
+			return false, nil
+		}
+
+		var job models.QuerySearchJob
+		if err := json.Unmarshal(d.Body, &job); err == nil && job.JobID == jobID {
+			headers := amqp.Table{}
+			for k, v := range d.Headers {
+				headers[k] = v
+			}
+			headers["x-retry-count"] = int32(0)
+
+			publishErr := ch.Publish("", originQueue, false, false, amqp.Publishing{
+				ContentType:  d.ContentType,
+				Body:         d.Body,
+				DeliveryMode: amqp.Persistent,
+				Headers:      headers,
+			})
+			if publishErr != nil {
+				d.Nack(false, true)
+				return false, fmt.Errorf("failed to replay message onto %s: %w", originQueue, publishErr)
+			}
+
+			d.Ack(false)
+			return true, nil
+		}
+
+		peeked = append(peeked, d)
+	}
+
+	return false, nil
+}
+
+func stringHeader(headers amqp.Table, key string) string {
+	if v, ok := headers[key].(string); ok {
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,195 @@
+package pythonclient
+
+import (
+	"sync"
+	"time"
+)
+
+// State is one of the three circuit breaker states.
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half_open"
+)
+
+const (
+	// failureThreshold is how many consecutive failures in the closed state
+	// trip the breaker open.
+	failureThreshold = 5
+	// cooldown is how long the breaker stays open before allowing a single
+	// half-open probe request through.
+	cooldown = 30 * time.Second
+)
+
+// Stats is the breaker state surfaced in health checks.
+type Stats struct {
+	State               State     `json:"state"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastTransition      time.Time `json:"last_transition"`
+}
+
+// stateRank orders State for "worst of" aggregation across endpoints: open
+// is worse than half-open, which is worse than closed.
+var stateRank = map[State]int{
+	StateClosed:   0,
+	StateHalfOpen: 1,
+	StateOpen:     2,
+}
+
+// breaker is a three-state circuit breaker: closed (requests flow normally),
+// open (requests are rejected without attempting the call), and half-open
+// (a single probe is allowed through after the cooldown to test recovery).
+// It is process-wide (see sharedBreaker) since breaker state describes a
+// fact about the downstream Python service, not about any one caller.
+type breaker struct {
+	mu                    sync.Mutex
+	state                 State
+	consecutiveFailures   int
+	openedAt              time.Time
+	lastTransition        time.Time
+	halfOpenProbeInFlight bool
+}
+
+func newBreaker() *breaker {
+	return &breaker{
+		state:          StateClosed,
+		lastTransition: time.Now(),
+	}
+}
+
+// Allow reports whether a request should be attempted. A half-open probe
+// counts as an attempt, so only one caller sees true while the breaker is
+// open or half-open - every other concurrent caller is rejected until that
+// probe reports back via RecordSuccess/RecordFailure.
+func (b *breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < cooldown {
+			return false
+		}
+		b.transitionLocked(StateHalfOpen)
+		b.halfOpenProbeInFlight = true
+		return true
+	case StateHalfOpen:
+		if b.halfOpenProbeInFlight {
+			return false
+		}
+		b.halfOpenProbeInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the breaker and resets the failure count.
+func (b *breaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFailures = 0
+	b.halfOpenProbeInFlight = false
+	if b.state != StateClosed {
+		b.transitionLocked(StateClosed)
+	}
+}
+
+// RecordFailure increments the consecutive failure count and opens the
+// breaker if it crosses failureThreshold, or immediately re-opens it if a
+// half-open probe failed.
+func (b *breaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFailures++
+	b.halfOpenProbeInFlight = false
+
+	switch b.state {
+	case StateHalfOpen:
+		b.openedAt = time.Now()
+		b.transitionLocked(StateOpen)
+	case StateClosed:
+		if b.consecutiveFailures >= failureThreshold {
+			b.openedAt = time.Now()
+			b.transitionLocked(StateOpen)
+		}
+	}
+}
+
+func (b *breaker) transitionLocked(s State) {
+	b.state = s
+	b.lastTransition = time.Now()
+}
+
+func (b *breaker) stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return Stats{
+		State:               b.state,
+		ConsecutiveFailures: b.consecutiveFailures,
+		LastTransition:      b.lastTransition,
+	}
+}
+
+// breakerRegistry holds one breaker per endpoint, so a failing embedding
+// upload doesn't trip the breaker for, say, health checks against the same
+// Python service. Endpoints are created lazily on first use.
+type breakerRegistry struct {
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+func newBreakerRegistry() *breakerRegistry {
+	return &breakerRegistry{breakers: make(map[string]*breaker)}
+}
+
+// get returns endpoint's breaker, creating it on first access.
+func (r *breakerRegistry) get(endpoint string) *breaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.breakers[endpoint]
+	if !ok {
+		b = newBreaker()
+		r.breakers[endpoint] = b
+	}
+	return b
+}
+
+// aggregate reports the worst state across every endpoint seen so far, and
+// the highest consecutive-failure count, as a single Stats summary for a
+// top-level /health view.
+func (r *breakerRegistry) aggregate() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	worst := Stats{State: StateClosed}
+	for _, b := range r.breakers {
+		s := b.stats()
+		if stateRank[s.State] > stateRank[worst.State] {
+			worst = s
+		}
+		if s.ConsecutiveFailures > worst.ConsecutiveFailures {
+			worst.ConsecutiveFailures = s.ConsecutiveFailures
+		}
+	}
+	return worst
+}
+
+// byEndpoint returns every endpoint's current Stats, for a detailed
+// breakdown in /health.
+func (r *breakerRegistry) byEndpoint() map[string]Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make(map[string]Stats, len(r.breakers))
+	for endpoint, b := range r.breakers {
+		out[endpoint] = b.stats()
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package pythonclient
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreakerAllowClosedAlwaysTrue(t *testing.T) {
+	b := newBreaker()
+	assert.True(t, b.Allow())
+	assert.True(t, b.Allow())
+}
+
+func TestBreakerAllowOpenBlocksUntilCooldown(t *testing.T) {
+	b := newBreaker()
+	for i := 0; i < failureThreshold; i++ {
+		b.RecordFailure()
+	}
+	assert.Equal(t, StateOpen, b.stats().State)
+	assert.False(t, b.Allow(), "caller should be rejected while still within cooldown")
+}
+
+func TestBreakerAllowHalfOpenAdmitsOnlyOneProbe(t *testing.T) {
+	b := newBreaker()
+	for i := 0; i < failureThreshold; i++ {
+		b.RecordFailure()
+	}
+	b.openedAt = time.Now().Add(-2 * cooldown)
+
+	const callers = 20
+	var admitted int32
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			if b.Allow() {
+				mu.Lock()
+				admitted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), admitted, "exactly one caller should see the half-open probe through")
+	assert.Equal(t, StateHalfOpen, b.stats().State)
+}
+
+func TestBreakerRecordSuccessClearsHalfOpenProbe(t *testing.T) {
+	b := newBreaker()
+	for i := 0; i < failureThreshold; i++ {
+		b.RecordFailure()
+	}
+	b.openedAt = time.Now().Add(-2 * cooldown)
+
+	assert.True(t, b.Allow())
+	b.RecordSuccess()
+
+	assert.Equal(t, StateClosed, b.stats().State)
+	assert.True(t, b.Allow(), "a new probe window should open normally once closed again")
+}
+
+func TestBreakerRecordFailureReopensAndClearsProbe(t *testing.T) {
+	b := newBreaker()
+	for i := 0; i < failureThreshold; i++ {
+		b.RecordFailure()
+	}
+	b.openedAt = time.Now().Add(-2 * cooldown)
+
+	assert.True(t, b.Allow())
+	b.RecordFailure()
+
+	assert.Equal(t, StateOpen, b.stats().State)
+	assert.False(t, b.Allow(), "reopened breaker should reject until cooldown elapses again")
+}
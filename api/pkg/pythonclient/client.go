@@ -0,0 +1,163 @@
+// Package pythonclient wraps calls to the Python ML service with a circuit
+// breaker, retry with exponential backoff and jitter, and a bounded
+// in-flight semaphore, so a slow or failing downstream doesn't cascade into
+// every request path that touches embeddings.
+package pythonclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	neturl "net/url"
+	"time"
+
+	"rec-mind/config"
+)
+
+const maxInFlight = 10
+
+// ErrBreakerOpen is returned without attempting a call when the breaker is
+// open, so callers can defer the work instead of waiting on a timeout.
+var ErrBreakerOpen = errors.New("python ml service circuit breaker is open")
+
+// ErrTooManyInFlight is returned when the bounded semaphore is full,
+// shedding load rather than queuing requests behind a struggling service.
+var ErrTooManyInFlight = errors.New("too many in-flight python ml service requests")
+
+var retryableStatuses = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// sharedBreakers and sharedSemaphore are process-wide: breaker/load-shedding
+// state describes the health of the single downstream Python service, not
+// any one Client instance, so every Client constructed by this process
+// shares them (mirroring the package-level MQChannel/RedisClient singletons
+// used elsewhere in this codebase). sharedBreakers is keyed per endpoint so
+// a struggling embeddings upload doesn't trip the breaker for health
+// checks against the same service.
+var (
+	sharedBreakers  = newBreakerRegistry()
+	sharedSemaphore = make(chan struct{}, maxInFlight)
+)
+
+// Client issues HTTP requests to the Python ML service through the shared
+// per-endpoint breaker, retry, and load-shedding policy.
+type Client struct {
+	httpClient *http.Client
+	retry      config.MLClientRetryPolicy
+}
+
+// NewClient creates a Client with the given per-attempt timeout. Retry
+// behavior (attempt count, initial backoff, max elapsed time) is loaded
+// from config.LoadMLClientRetryPolicy (ML_MAX_RETRIES/ML_INITIAL_INTERVAL/
+// ML_MAX_ELAPSED), the same env-driven knob shape as config.LoadRetryPolicy.
+func NewClient(timeout time.Duration) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: timeout},
+		retry:      config.LoadMLClientRetryPolicy(),
+	}
+}
+
+// endpointKey extracts a breaker key from url's path, so every call to the
+// same route (e.g. /embeddings/batch-and-upload) shares one breaker
+// regardless of query string or host.
+func endpointKey(url string) string {
+	parsed, err := neturl.Parse(url)
+	if err != nil {
+		return url
+	}
+	return parsed.Path
+}
+
+// Do sends body to url with method, retrying retryable failures (network
+// errors, 429, 502, 503 and 504) with exponential backoff and jitter up to
+// retry.MaxRetries attempts or retry.MaxElapsed total time, whichever comes
+// first. A 4xx response other than 429 is returned immediately as a
+// permanent failure without retrying or tripping the breaker. Calls
+// short-circuit entirely when url's endpoint breaker is open.
+func (c *Client) Do(ctx context.Context, method, url string, body []byte, headers map[string]string) (*http.Response, error) {
+	breaker := sharedBreakers.get(endpointKey(url))
+
+	if !breaker.Allow() {
+		return nil, ErrBreakerOpen
+	}
+
+	select {
+	case sharedSemaphore <- struct{}{}:
+		defer func() { <-sharedSemaphore }()
+	default:
+		return nil, ErrTooManyInFlight
+	}
+
+	start := time.Now()
+	var lastErr error
+	for attempt := 0; attempt < c.retry.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if time.Since(start) >= c.retry.MaxElapsed {
+				break
+			}
+			c.sleepWithJitter(attempt)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build request: %w", err)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if retryableStatuses[resp.StatusCode] && attempt < c.retry.MaxRetries-1 {
+			resp.Body.Close()
+			lastErr = fmt.Errorf("python ml service returned status %d", resp.StatusCode)
+			continue
+		}
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			breaker.RecordFailure()
+		} else {
+			breaker.RecordSuccess()
+		}
+		return resp, nil
+	}
+
+	breaker.RecordFailure()
+	return nil, fmt.Errorf("python ml service request failed after %d attempts: %w", c.retry.MaxRetries, lastErr)
+}
+
+func (c *Client) sleepWithJitter(attempt int) {
+	backoff := c.retry.InitialInterval << uint(attempt-1)
+	jitter := time.Duration(rand.Int63n(int64(backoff) + 1))
+	time.Sleep(backoff/2 + jitter/2)
+}
+
+// AggregateStats returns the worst-state breaker across every endpoint seen
+// so far, for a top-level health summary.
+func AggregateStats() Stats {
+	return sharedBreakers.aggregate()
+}
+
+// StatsByEndpoint returns every endpoint's current breaker Stats, for a
+// detailed breakdown in /health.
+func StatsByEndpoint() map[string]Stats {
+	return sharedBreakers.byEndpoint()
+}
+
+// BreakerOpen reports whether any endpoint's breaker is open, so callers
+// that don't target one specific route can still fast-fail while the
+// Python service is down.
+func BreakerOpen() bool {
+	return sharedBreakers.aggregate().State == StateOpen
+}
@@ -1,18 +1,35 @@
 package routes
 
 import (
+	"context"
+	"log"
+	"os"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
+	appconfig "rec-mind/config"
 	"rec-mind/controllers"
 	"rec-mind/handlers"
+	"rec-mind/internal/analytics"
 	"rec-mind/internal/database"
+	"rec-mind/internal/events"
+	"rec-mind/internal/feeds"
+	"rec-mind/internal/health"
+	"rec-mind/internal/logging"
+	"rec-mind/internal/metrics"
+	"rec-mind/internal/outbox"
+	"rec-mind/internal/redis"
 	"rec-mind/internal/repository"
+	searchindex "rec-mind/internal/search"
 	"rec-mind/internal/services"
+	"rec-mind/internal/storage"
+	"rec-mind/internal/uploads"
+	"rec-mind/mq"
 )
 
 func SetupRoutes(r *gin.Engine, db *database.DB, articleService *services.ArticleService) {
@@ -20,37 +37,139 @@ func SetupRoutes(r *gin.Engine, db *database.DB, articleService *services.Articl
 	config := cors.DefaultConfig()
 	config.AllowOrigins = []string{"http://localhost:3000"}
 	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
+	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", logging.CorrelationIDHeader}
 	config.AllowCredentials = true
 	r.Use(cors.New(config))
+
+	// Reads/generates X-Correlation-ID so every handler, and anything it
+	// publishes to RabbitMQ, can be traced under the same ID end-to-end.
+	r.Use(logging.CorrelationMiddleware())
+	// Records HTTPRequestDuration for every request; served on /metrics below.
+	r.Use(metrics.HTTPMiddleware())
 	// Swagger
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Health
 	r.GET("/health", handlers.SystemHealth(db, time.Now()))
 
+	// Kubernetes-style probes, backed by a shared dependency registry with
+	// per-check TTL caching so frequent polling doesn't hammer downstreams.
+	healthRegistry := handlers.NewDependencyRegistry(db)
+	r.GET("/healthz", handlers.Livez())
+	r.GET("/readyz", handlers.Readyz(healthRegistry))
+	r.GET("/startupz", handlers.Startupz(healthRegistry))
+
+	// Prometheus metrics (health check pass/fail counters and friends)
+	metrics.RegisterDBPoolStats(db.Pool)
+	metrics.RegisterRedisPoolStats(redis.RedisClient)
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// Full-text search index: optional, so a missing/unwritable index path
+	// disables keyword search rather than failing the whole server to start.
+	var searchIndex searchindex.SearchIndex
+	if idx, err := searchindex.NewIndex(searchIndexPath()); err != nil {
+		log.Printf("⚠️ Full-text search disabled: %v", err)
+	} else {
+		searchIndex = idx
+	}
+	r.GET("/search", controllers.FullTextSearch(searchIndex))
+
 	// Repositories
-	articleRepo := repository.NewArticleRepository(db.Pool)
-	chunkRepo := repository.NewArticleChunkRepository(db.Pool)
+	dbConfig, err := appconfig.LoadDatabaseConfig()
+	if err != nil {
+		log.Fatalf("Failed to load database config: %v", err)
+	}
+	articleRepo := repository.NewArticleRepository(db.Pool, searchIndex, dbConfig.ArticleCacheMaxBytes)
+	chunkRepo := repository.NewArticleChunkRepository(db.Pool, searchIndex)
+	categoryRepo := repository.NewCategoryRepository(db.Pool)
+	jobRepo := repository.NewJobRepository(db.Pool)
+
+	// Article cache hit/miss counters, surfaced on health.DatabaseHealth.
+	health.RegisterArticleCacheStatsProvider(func() interface{} { return articleRepo.CacheStats() })
+
+	eventPublisher := events.NewPublisher(redis.RedisClient, "article_events")
+	searchEventPublisher := events.NewPublisher(redis.RedisClient, "search_jobs")
+	articleReaper := services.NewArticleReaper(articleRepo, chunkRepo, redis.RedisClient)
+
+	// Query analytics: a no-op backend unless ANALYTICS_BACKEND is set, so
+	// /api/analytics/queries always exists but returns an empty window by
+	// default.
+	analyticsClient := analytics.New(appconfig.LoadAnalyticsConfig(), db.Pool)
 
 	// Controllers
-	searchController := controllers.NewSearchController()
+	searchController := controllers.NewSearchController(searchEventPublisher, jobRepo)
+
+	// Dynamic RSS feed scraping: feeds live in Redis (internal/feeds.Store)
+	// instead of config.ScraperConfig's static list, and GlobalScraperManager
+	// polls each one on its own schedule. Requires RabbitMQ and the durable
+	// outbox publisher built on it; skip starting it if either isn't
+	// available rather than failing the whole server to start.
+	feedStore := feeds.NewStore(redis.RedisClient)
+	var scraperManager *services.GlobalScraperManager
+	if mq.MQChannel == nil {
+		log.Printf("⚠️ Dynamic feed scraping disabled: RabbitMQ not initialized")
+	} else {
+		feedStateRepo := repository.NewFeedStateRepository(db.Pool)
+		outboxStore := outbox.NewPostgresStore(db.Pool)
+		scraperPublisher, err := outbox.NewPublisher(mq.MQChannel, outboxStore)
+		if err != nil {
+			log.Printf("⚠️ Dynamic feed scraping disabled: %v", err)
+		} else {
+			scraperPublisher.StartDrainer(context.Background(), 30*time.Second)
+			extractor := services.NewHTTPContentExtractor(nil, 0)
+			scraperService := services.NewScraperService(articleRepo, mq.MQChannel, extractor, scraperPublisher, feedStateRepo, analyticsClient)
+			scraperManager = services.NewGlobalScraperManager(scraperService, feedStore, redis.RedisClient)
+			scraperManager.Start(context.Background())
+		}
+	}
 
 	// API routes
 	api := r.Group("/api")
 	{
 		api.POST("/upload", controllers.UploadArticle(articleService))
 
+		api.GET("/analytics/queries", controllers.GetQueryAnalytics(analyticsClient))
+
+		// Resumable upload session endpoints require an S3-compatible
+		// object store; skip registering them if one isn't configured
+		// rather than failing the whole server to start.
+		if objectStore, err := storage.NewObjectStore(context.Background()); err != nil {
+			log.Printf("⚠️ Resumable article uploads disabled: %v", err)
+		} else {
+			uploadController := controllers.NewUploadController(uploads.NewSessionStore(redis.RedisClient), objectStore, articleService)
+			articleUploads := api.Group("/articles/uploads")
+			{
+				articleUploads.POST("", uploadController.CreateUploadSession)
+				articleUploads.PATCH("/:id", uploadController.AppendUploadChunk)
+				articleUploads.POST("/:id/finalize", uploadController.FinalizeUpload)
+			}
+		}
+
 		v1 := api.Group("/v1")
 		{
 			// Articles
 			articles := v1.Group("/articles")
 			{
 				articles.GET("", controllers.ListArticles(articleRepo))
+				articles.DELETE("", controllers.DeleteArticlesBulk(articleReaper))
+				articles.GET("/by-hash/:sha256", controllers.GetArticleByHash(articleRepo))
 				articles.GET("/:id", controllers.GetArticle(articleRepo))
 				articles.DELETE("/:id", controllers.DeleteArticle(articleRepo))
+				articles.POST("/:id/restore", controllers.RestoreArticle(articleRepo))
 				articles.GET("/:id/chunks", controllers.GetArticleChunks(chunkRepo))
 				articles.DELETE("/:id/chunks", controllers.DeleteArticleChunks(chunkRepo))
+				articles.GET("/:id/events", controllers.ArticleEvents(eventPublisher))
+			}
+
+			// Categories
+			categories := v1.Group("/categories")
+			{
+				categories.POST("", controllers.CreateCategory(categoryRepo))
+				categories.GET("", controllers.ListCategories(categoryRepo))
+				categories.GET("/:slug", controllers.GetCategory(categoryRepo))
+				categories.GET("/:slug/articles", controllers.GetArticlesByCategory(articleRepo))
+				categories.PATCH("/:id", controllers.UpdateCategory(categoryRepo))
+				categories.DELETE("/:id", controllers.DeleteCategory(categoryRepo))
 			}
 
 			// Chunks
@@ -68,8 +187,57 @@ func SetupRoutes(r *gin.Engine, db *database.DB, articleService *services.Articl
 			{
 				search.POST("/recommendations", searchController.SearchByQuery)
 				search.POST("/immediate", searchController.SearchWithImmediateResponse)
+				search.GET("/jobs", searchController.ListSearchJobs)
+				search.GET("/jobs/dead", searchController.ListDeadSearchJobs)
+				search.POST("/jobs/dead/:id/replay", searchController.ReplaySearchJob)
 				search.GET("/jobs/:job_id", searchController.GetQuerySearchJobStatus)
+				search.GET("/jobs/:job_id/stream", searchController.SearchStream)
+				search.GET("/jobs/:job_id/logs", searchController.GetSearchJobLogs)
+				search.DELETE("/jobs/:job_id", searchController.CancelSearchJob)
+				search.POST("/jobs/:job_id/retry", searchController.RetrySearchJob)
+			}
+
+			// Feeds: dynamic RSS feed configuration, hot-reloaded by
+			// GlobalScraperManager without a redeploy.
+			feedRoutes := v1.Group("/feeds")
+			{
+				feedRoutes.POST("", controllers.CreateFeed(feedStore))
+				feedRoutes.GET("", controllers.ListFeeds(feedStore))
+				feedRoutes.GET("/:slug", controllers.GetFeed(feedStore))
+				feedRoutes.PATCH("/:slug", controllers.UpdateFeed(feedStore))
+				feedRoutes.DELETE("/:slug", controllers.DeleteFeed(feedStore))
+				feedRoutes.POST("/:slug/enable", controllers.EnableFeed(feedStore))
+				feedRoutes.POST("/:slug/disable", controllers.DisableFeed(feedStore))
+			}
+
+			// Scraper: reports GlobalScraperManager's latest aggregated
+			// result across every scheduled feed; only registered if dynamic
+			// feed scraping is enabled (see scraperManager above).
+			if scraperManager != nil {
+				v1.GET("/scraper/status", controllers.ScrapeArticles(scraperManager))
+			}
+
+			// Durable background jobs (currently embedding jobs enqueued by
+			// ArticleService.CreateArticleWithAsyncEmbedding via
+			// internal/jobs), polled the same way as /search/jobs/:job_id.
+			v1.GET("/jobs/:job_id", controllers.GetJobStatus(articleService.JobQueue()))
+
+			// Admin: inspect and recover dead-lettered jobs that exhausted
+			// their retry ladder (see mq.DeclareRetryTopology).
+			admin := v1.Group("/admin")
+			{
+				admin.GET("/dead-letters/:queue", controllers.ListDeadLetters)
+				admin.POST("/dead-letters/:queue/requeue", controllers.RequeueDeadLetter)
 			}
 		}
 	}
+}
+
+// searchIndexPath returns where the Bleve full-text index lives on disk,
+// defaulting to a local data directory if SEARCH_INDEX_PATH isn't set.
+func searchIndexPath() string {
+	if path := os.Getenv("SEARCH_INDEX_PATH"); path != "" {
+		return path
+	}
+	return "./data/search.bleve"
 }
\ No newline at end of file
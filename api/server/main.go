@@ -1,13 +1,18 @@
 package main
 
 import (
-	"log"
+	"context"
+	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"rec-mind/config"
 	"rec-mind/internal/database"
+	"rec-mind/internal/events"
+	"rec-mind/internal/jobs"
+	"rec-mind/internal/logging"
+	"rec-mind/internal/metrics"
 	"rec-mind/internal/mlclient"
 	"rec-mind/internal/redis"
 	"rec-mind/internal/repository"
@@ -17,6 +22,12 @@ import (
 	_ "rec-mind/docs"
 )
 
+// embeddingWorkerConcurrency bounds how many embedding jobs ArticleService's
+// pool runs at once against the Python ML service.
+const embeddingWorkerConcurrency = 10
+
+var serverLog = logging.New("api-server")
+
 func main() {
 	startTime := time.Now()
 
@@ -28,43 +39,65 @@ func main() {
 
 	mq.InitRabbitMQ()
 
+	metrics.SetBuildInfo(getEnvOrDefault("GIT_SHA", "unknown"), getEnvOrDefault("APP_VERSION", "unknown"))
+
 	r := gin.Default()
 	articleService := initializeServices(db)
-	
+	articleService.StartEmbeddingWorkers(context.Background(), redis.RedisClient, embeddingWorkerConcurrency)
+
 	routes.SetupRoutes(r, db, articleService)
 
-	log.Printf("Server ready on :8080 (startup: %v)", time.Since(startTime))
-	log.Println("API Documentation: http://localhost:8080/swagger/index.html")
-	
+	serverLog.Info("server ready", "addr", ":8080", "startup_ms", time.Since(startTime).Milliseconds())
+	serverLog.Info("API documentation available", "url", "http://localhost:8080/swagger/index.html")
+
 	if err := r.Run(":8080"); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		serverLog.Error("server failed", "error", err)
+		os.Exit(1)
 	}
 }
 
 func initializeDatabase() *database.DB {
 	dbConfig, err := config.LoadDatabaseConfig()
 	if err != nil {
-		log.Fatalf("Failed to load database config: %v", err)
+		serverLog.Error("failed to load database config", "error", err)
+		os.Exit(1)
 	}
 
 	db, err := database.NewConnection(dbConfig)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		serverLog.Error("failed to connect to database", "error", err)
+		os.Exit(1)
 	}
 
-	log.Println("Database connected")
+	serverLog.Info("database connected")
 	return db
 }
 
 func initializeRedis() {
 	if err := redis.InitRedis(); err != nil {
-		log.Fatalf("Failed to initialize Redis: %v", err)
+		serverLog.Error("failed to initialize redis", "error", err)
+		os.Exit(1)
 	}
-	log.Println("Redis connected")
+	serverLog.Info("redis connected")
 }
 
 func initializeServices(db *database.DB) *services.ArticleService {
-	articleRepo := repository.NewArticleRepository(db.Pool)
+	dbConfig, err := config.LoadDatabaseConfig()
+	if err != nil {
+		serverLog.Error("failed to load database config", "error", err)
+		os.Exit(1)
+	}
+
+	articleRepo := repository.NewArticleRepository(db.Pool, nil, dbConfig.ArticleCacheMaxBytes)
 	mlClient := mlclient.NewMLClient()
-	return services.NewArticleService(articleRepo, mlClient)
+	eventPublisher := events.NewPublisher(redis.RedisClient, "article_events")
+	embeddingJobs := jobs.NewQueue(redis.RedisClient, "embeddings", jobs.DefaultMaxAttempts)
+	return services.NewArticleService(articleRepo, mlClient, eventPublisher, embeddingJobs)
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
 }
\ No newline at end of file